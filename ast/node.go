@@ -30,6 +30,54 @@ type Node struct {
 	LastChild  *Node    // 最后一个子节点
 	Tokens     []byte   // 词法分析结果 Tokens，语法分析阶段会继续操作这些 Tokens
 
+	// 源码位置，行号和列号从 1 开始，列号按字节计算，结束位置不包含在节点范围内
+
+	StartLn     int // 起始行号
+	StartCol    int // 起始列号
+	StartOffset int // 起始位置在原始输入中的字节偏移
+	EndLn       int // 结束行号
+	EndCol      int // 结束列号
+	EndOffset   int // 结束位置在原始输入中的字节偏移
+
+	// 属性列表 {#id .class key=value}
+
+	Attributes map[string]string // 节点属性，多个类名使用空格分隔保存在 class 中
+
+	// 维基链接
+
+	WikiLinkPage   string // 维基链接页面名称
+	WikiLinkAnchor string // 维基链接页面锚点
+	WikiLinkLabel  string // 维基链接显示文本，未指定时为空
+
+	// 缩写
+
+	AbbrTitle string // 缩写全称
+
+	// 标签
+
+	TagClosed bool // 是否使用闭合写法 #标签#
+
+	// 引用
+
+	ReferenceType string // 引用类型，mention、issue 或者 commit
+	ReferenceRepo string // 引用的仓库 owner/repo，未指定时为空
+	ReferenceID   string // 引用的用户名、问题编号或者提交 SHA
+
+	// 交叉引用
+
+	CrossRefLabel string // 引用的标签，比如 fig:arch
+	CrossRefText  string // 解析后的引用文本，比如 Figure 3，标签未定义时为空
+
+	// 文献引用
+
+	CitationItems  []*CitationItem // 引用的文献条目
+	CitationInText bool            // 是否是行文中的引用 @key，否则是方括号中的引用 [@key]
+
+	// 注音
+
+	RubyBases []string // 注音基文，逐字注音时每个字一项
+	RubyTexts []string // 注音文本，和 RubyBases 一一对应
+
 	// 解析过程标识
 
 	Close           bool // 标识是否关闭
@@ -58,9 +106,11 @@ type Node struct {
 
 	*ListData
 
-	// 任务列表项 [ ]、[x] 或者 [X]
+	// 任务列表项 [ ]、[x] 或者 [X]，以及 [-]、[/] 等扩展状态
 
 	TaskListItemChecked bool // 是否勾选
+	TaskListItemState   byte // 方括号中的原始状态字符
+	TaskListItemIndex   int  // 在文档中的序号，从 0 开始，嵌入文档中只读的任务列表项为 -1
 
 	// 表
 
@@ -70,6 +120,19 @@ type Node struct {
 	TableCellContentMaxWidth int    // 表的单元格内容最大宽度
 	TableCellContent         []byte // 表的单元格内容
 	TableCellMaxWidthContent []byte // 表的单元格最大宽度格的内容
+	TableCellColspan         int    // 表的单元格跨越的列数，大于 1 时有效
+	TableCellRowspan         int    // 表的单元格跨越的行数，大于 1 时有效
+	TableCellSpanned         byte   // 被合并的单元格：'|' 表示被左侧单元格合并（||），'^' 表示被上方单元格合并（^^），0 表示未被合并
+	TableRowLines            int    // 表行在源码中占用的行数，大于 1 时表示使用了 \ 续行的多行单元格
+	TableCaptionBefore       bool   // 表标题是否位于表之前
+
+	// 表和图编号
+
+	CaptionNumber int // 带标题或者标签的表、图以及带标签的公式的自动编号，从 1 开始，0 表示未编号
+
+	// 目录
+
+	ToCType string // 目录类型，toc：标题目录，lot：表目录，lof：图目录
 
 	// 链接
 
@@ -82,16 +145,32 @@ type Node struct {
 	HeadingSetext       bool   // 是否为 Setext
 	HeadingID           []byte // 标题自定义 ID
 	HeadingNormalizedID string // 规范化后的 ID
+	HeadingNumber       string // 标题编号，比如 2.1
 
 	// 数学公式块
 
 	MathBlockDollarOffset int
 
+	// 前置元数据
+
+	FrontMatterMarker []byte // --- 为 YAML，+++ 为 TOML
+
+	// GitHub 提示
+
+	GitHubAlertType string // 提示类型，note、tip、important、warning 或者 caution
+
+	// 自定义容器
+
+	CustomContainerName     string // 容器名称，::: warning Title 中的 warning
+	CustomContainerArgs     string // 容器名称后的参数，::: warning Title 中的 Title
+	CustomContainerFenceLen int    // 围栏 : 的长度
+
 	// 脚注
 
 	FootnotesRefLabel []byte  // 脚注引用 label，[^label]
 	FootnotesRefId    string  // 脚注 id
 	FootnotesRefs     []*Node // 脚注引用
+	FootnotesInline   []byte  // 行内脚注内容，^[note] 中的 note，非行内脚注时为空
 
 	// HTML 实体
 
@@ -112,6 +191,14 @@ type ListData struct {
 	Num          int    // 有序列表项修正过的序号
 }
 
+// CitationItem 用于记录文献引用中的一个条目，比如 [see @smith2020, p. 33] 中的 @smith2020。
+type CitationItem struct {
+	Key            string // 文献键
+	Prefix         string // 前缀，比如 see
+	Suffix         string // 后缀，通常是页码等定位信息，比如 , p. 33
+	SuppressAuthor bool   // 是否省略作者，即 [-@smith2020]
+}
+
 // TokensStr 返回 n 的 Tokens 字符串。
 func (n *Node) TokensStr() string {
 	return util.BytesToStr(n.Tokens)
@@ -275,7 +362,7 @@ func (n *Node) ParentIs(nodeType NodeType, nodeTypes ...NodeType) bool {
 // AcceptLines 判断是否节点是否可以接受更多的文本行。比如 HTML 块、代码块和段落是可以接受更多的文本行的。
 func (n *Node) AcceptLines() bool {
 	switch n.Type {
-	case NodeParagraph, NodeCodeBlock, NodeHTMLBlock, NodeTable, NodeMathBlock:
+	case NodeParagraph, NodeCodeBlock, NodeHTMLBlock, NodeTable, NodeMathBlock, NodeFrontMatter:
 		return true
 	}
 	return false
@@ -285,10 +372,14 @@ func (n *Node) AcceptLines() bool {
 // 块引用节点（块级容器）可以包含任意节点；段落节点（叶子块节点）不能包含任何其他块级节点。
 func (n *Node) CanContain(nodeType NodeType) bool {
 	switch n.Type {
-	case NodeCodeBlock, NodeHTMLBlock, NodeParagraph, NodeThematicBreak, NodeTable, NodeMathBlock:
+	case NodeCodeBlock, NodeHTMLBlock, NodeParagraph, NodeThematicBreak, NodeTable, NodeMathBlock, NodeFrontMatter:
 		return false
 	case NodeList:
 		return NodeListItem == nodeType
+	case NodeDefinitionList:
+		return NodeDefinitionTerm == nodeType || NodeDefinitionDescription == nodeType
+	case NodeDefinitionTerm:
+		return false
 	case NodeFootnotesDef:
 		return NodeFootnotesDef != nodeType // 脚注不能包含脚注
 	}
@@ -402,5 +493,71 @@ const (
 
 	NodeToC NodeType = 600 // 目录 [toc]
 
-	NodeTypeMaxVal NodeType = 1024 // 节点类型最大值
+	// 前置元数据
+
+	NodeFrontMatter            NodeType = 700 // 前置元数据
+	NodeFrontMatterOpenMarker  NodeType = 701 // 开始前置元数据标记符 --- 或者 +++
+	NodeFrontMatterContent     NodeType = 702 // 前置元数据内容
+	NodeFrontMatterCloseMarker NodeType = 703 // 结束前置元数据标记符 --- 或者 +++
+
+	// 定义列表
+
+	NodeDefinitionList        NodeType = 800 // 定义列表
+	NodeDefinitionTerm        NodeType = 801 // 定义术语
+	NodeDefinitionDescription NodeType = 802 // 定义描述 :
+
+	// GitHub 提示
+
+	NodeGitHubAlert       NodeType = 900 // GitHub 提示块 > [!NOTE]
+	NodeGitHubAlertMarker NodeType = 901 // GitHub 提示标记符 [!NOTE]
+
+	// 自定义容器
+
+	NodeCustomContainer NodeType = 1000 // 自定义容器 ::: name
+
+	// 高亮、上标和下标
+
+	NodeMark            NodeType = 1100 // 高亮
+	NodeMarkOpenMarker  NodeType = 1101 // 开始高亮标记符 ==
+	NodeMarkCloseMarker NodeType = 1102 // 结束高亮标记符 ==
+	NodeSup             NodeType = 1103 // 上标
+	NodeSupOpenMarker   NodeType = 1104 // 开始上标标记符 ^
+	NodeSupCloseMarker  NodeType = 1105 // 结束上标标记符 ^
+	NodeSub             NodeType = 1106 // 下标
+	NodeSubOpenMarker   NodeType = 1107 // 开始下标标记符 ~
+	NodeSubCloseMarker  NodeType = 1108 // 结束下标标记符 ~
+
+	// 维基链接
+
+	NodeWikiLink NodeType = 1200 // 维基链接 [[Page#Heading|label]]
+
+	// 缩写
+
+	NodeAbbr NodeType = 1300 // 缩写 *[HTML]: Hyper Text Markup Language
+
+	// 标签
+
+	NodeTag NodeType = 1400 // 标签 #tag 或者 #多个词的标签#
+
+	// 引用
+
+	NodeReference NodeType = 1500 // 引用 @username、#123、owner/repo#123 或者提交 SHA
+
+	// 表标题
+
+	NodeTableCaption NodeType = 1600 // 表标题 Table: caption
+
+	// 交叉引用
+
+	NodeCrossRef NodeType = 1700 // 交叉引用 @fig:arch 或者 [@sec:intro]
+
+	// 文献引用
+
+	NodeCitation NodeType = 1800 // 文献引用 @smith2020 或者 [@smith2020, p. 33]
+
+	// 注音
+
+	NodeRuby NodeType = 1900 // 注音 {漢字|かんじ} 或者 {汉字|hàn|zì}
+
+	NodeTypeMaxVal NodeType = 2048 // 节点类型最大值
 )
@@ -80,27 +80,71 @@ func _() {
 	_ = x[NodeFootnotesDef-500]
 	_ = x[NodeFootnotesRef-501]
 	_ = x[NodeToC-600]
-	_ = x[NodeTypeMaxVal-1024]
+	_ = x[NodeFrontMatter-700]
+	_ = x[NodeFrontMatterOpenMarker-701]
+	_ = x[NodeFrontMatterContent-702]
+	_ = x[NodeFrontMatterCloseMarker-703]
+	_ = x[NodeDefinitionList-800]
+	_ = x[NodeDefinitionTerm-801]
+	_ = x[NodeDefinitionDescription-802]
+	_ = x[NodeGitHubAlert-900]
+	_ = x[NodeGitHubAlertMarker-901]
+	_ = x[NodeCustomContainer-1000]
+	_ = x[NodeMark-1100]
+	_ = x[NodeMarkOpenMarker-1101]
+	_ = x[NodeMarkCloseMarker-1102]
+	_ = x[NodeSup-1103]
+	_ = x[NodeSupOpenMarker-1104]
+	_ = x[NodeSupCloseMarker-1105]
+	_ = x[NodeSub-1106]
+	_ = x[NodeSubOpenMarker-1107]
+	_ = x[NodeSubCloseMarker-1108]
+	_ = x[NodeWikiLink-1200]
+	_ = x[NodeAbbr-1300]
+	_ = x[NodeTag-1400]
+	_ = x[NodeReference-1500]
+	_ = x[NodeTableCaption-1600]
+	_ = x[NodeCrossRef-1700]
+	_ = x[NodeCitation-1800]
+	_ = x[NodeRuby-1900]
+	_ = x[NodeTypeMaxVal-2048]
 }
 
 const (
-	_NodeType_name_0 = "NodeDocumentNodeParagraphNodeHeadingNodeHeadingC8hMarkerNodeThematicBreakNodeBlockquoteNodeBlockquoteMarkerNodeListNodeListItemNodeHTMLBlockNodeInlineHTMLNodeCodeBlockNodeCodeBlockFenceOpenMarkerNodeCodeBlockFenceCloseMarkerNodeCodeBlockFenceInfoMarkerNodeCodeBlockCodeNodeTextNodeEmphasisNodeEmA6kOpenMarkerNodeEmA6kCloseMarkerNodeEmU8eOpenMarkerNodeEmU8eCloseMarkerNodeStrongNodeStrongA6kOpenMarkerNodeStrongA6kCloseMarkerNodeStrongU8eOpenMarkerNodeStrongU8eCloseMarkerNodeCodeSpanNodeCodeSpanOpenMarkerNodeCodeSpanContentNodeCodeSpanCloseMarkerNodeHardBreakNodeSoftBreakNodeLinkNodeImageNodeBangNodeOpenBracketNodeCloseBracketNodeOpenParenNodeCloseParenNodeLinkTextNodeLinkDestNodeLinkTitleNodeLinkSpaceNodeHTMLEntity"
-	_NodeType_name_1 = "NodeTaskListItemMarkerNodeStrikethroughNodeStrikethrough1OpenMarkerNodeStrikethrough1CloseMarkerNodeStrikethrough2OpenMarkerNodeStrikethrough2CloseMarkerNodeTableNodeTableHeadNodeTableRowNodeTableCell"
-	_NodeType_name_2 = "NodeEmojiNodeEmojiUnicodeNodeEmojiImgNodeEmojiAlias"
-	_NodeType_name_3 = "NodeMathBlockNodeMathBlockOpenMarkerNodeMathBlockContentNodeMathBlockCloseMarkerNodeInlineMathNodeInlineMathOpenMarkerNodeInlineMathContentNodeInlineMathCloseMarker"
-	_NodeType_name_4 = "NodeBackslashNodeBackslashContent"
-	_NodeType_name_5 = "NodeFootnotesDefNodeFootnotesRef"
-	_NodeType_name_6 = "NodeToC"
-	_NodeType_name_7 = "NodeTypeMaxVal"
+	_NodeType_name_0  = "NodeDocumentNodeParagraphNodeHeadingNodeHeadingC8hMarkerNodeThematicBreakNodeBlockquoteNodeBlockquoteMarkerNodeListNodeListItemNodeHTMLBlockNodeInlineHTMLNodeCodeBlockNodeCodeBlockFenceOpenMarkerNodeCodeBlockFenceCloseMarkerNodeCodeBlockFenceInfoMarkerNodeCodeBlockCodeNodeTextNodeEmphasisNodeEmA6kOpenMarkerNodeEmA6kCloseMarkerNodeEmU8eOpenMarkerNodeEmU8eCloseMarkerNodeStrongNodeStrongA6kOpenMarkerNodeStrongA6kCloseMarkerNodeStrongU8eOpenMarkerNodeStrongU8eCloseMarkerNodeCodeSpanNodeCodeSpanOpenMarkerNodeCodeSpanContentNodeCodeSpanCloseMarkerNodeHardBreakNodeSoftBreakNodeLinkNodeImageNodeBangNodeOpenBracketNodeCloseBracketNodeOpenParenNodeCloseParenNodeLinkTextNodeLinkDestNodeLinkTitleNodeLinkSpaceNodeHTMLEntity"
+	_NodeType_name_1  = "NodeTaskListItemMarkerNodeStrikethroughNodeStrikethrough1OpenMarkerNodeStrikethrough1CloseMarkerNodeStrikethrough2OpenMarkerNodeStrikethrough2CloseMarkerNodeTableNodeTableHeadNodeTableRowNodeTableCell"
+	_NodeType_name_2  = "NodeEmojiNodeEmojiUnicodeNodeEmojiImgNodeEmojiAlias"
+	_NodeType_name_3  = "NodeMathBlockNodeMathBlockOpenMarkerNodeMathBlockContentNodeMathBlockCloseMarkerNodeInlineMathNodeInlineMathOpenMarkerNodeInlineMathContentNodeInlineMathCloseMarker"
+	_NodeType_name_4  = "NodeBackslashNodeBackslashContent"
+	_NodeType_name_5  = "NodeFootnotesDefNodeFootnotesRef"
+	_NodeType_name_6  = "NodeToC"
+	_NodeType_name_7  = "NodeFrontMatterNodeFrontMatterOpenMarkerNodeFrontMatterContentNodeFrontMatterCloseMarker"
+	_NodeType_name_8  = "NodeDefinitionListNodeDefinitionTermNodeDefinitionDescription"
+	_NodeType_name_9  = "NodeGitHubAlertNodeGitHubAlertMarker"
+	_NodeType_name_10 = "NodeCustomContainer"
+	_NodeType_name_11 = "NodeMarkNodeMarkOpenMarkerNodeMarkCloseMarkerNodeSupNodeSupOpenMarkerNodeSupCloseMarkerNodeSubNodeSubOpenMarkerNodeSubCloseMarker"
+	_NodeType_name_12 = "NodeWikiLink"
+	_NodeType_name_13 = "NodeAbbr"
+	_NodeType_name_14 = "NodeTag"
+	_NodeType_name_15 = "NodeReference"
+	_NodeType_name_16 = "NodeTableCaption"
+	_NodeType_name_17 = "NodeCrossRef"
+	_NodeType_name_18 = "NodeCitation"
+	_NodeType_name_19 = "NodeRuby"
+	_NodeType_name_20 = "NodeTypeMaxVal"
 )
 
 var (
-	_NodeType_index_0 = [...]uint16{0, 12, 25, 36, 56, 73, 87, 107, 115, 127, 140, 154, 167, 195, 224, 252, 269, 277, 289, 308, 328, 347, 367, 377, 400, 424, 447, 471, 483, 505, 524, 547, 560, 573, 581, 590, 598, 613, 629, 642, 656, 668, 680, 693, 706, 720}
-	_NodeType_index_1 = [...]uint8{0, 22, 39, 67, 96, 124, 153, 162, 175, 187, 200}
-	_NodeType_index_2 = [...]uint8{0, 9, 25, 37, 51}
-	_NodeType_index_3 = [...]uint8{0, 13, 36, 56, 80, 94, 118, 139, 164}
-	_NodeType_index_4 = [...]uint8{0, 13, 33}
-	_NodeType_index_5 = [...]uint8{0, 16, 32}
+	_NodeType_index_0  = [...]uint16{0, 12, 25, 36, 56, 73, 87, 107, 115, 127, 140, 154, 167, 195, 224, 252, 269, 277, 289, 308, 328, 347, 367, 377, 400, 424, 447, 471, 483, 505, 524, 547, 560, 573, 581, 590, 598, 613, 629, 642, 656, 668, 680, 693, 706, 720}
+	_NodeType_index_1  = [...]uint8{0, 22, 39, 67, 96, 124, 153, 162, 175, 187, 200}
+	_NodeType_index_2  = [...]uint8{0, 9, 25, 37, 51}
+	_NodeType_index_3  = [...]uint8{0, 13, 36, 56, 80, 94, 118, 139, 164}
+	_NodeType_index_4  = [...]uint8{0, 13, 33}
+	_NodeType_index_5  = [...]uint8{0, 16, 32}
+	_NodeType_index_7  = [...]uint8{0, 15, 40, 62, 88}
+	_NodeType_index_8  = [...]uint8{0, 18, 36, 61}
+	_NodeType_index_9  = [...]uint8{0, 15, 36}
+	_NodeType_index_11 = [...]uint8{0, 8, 26, 45, 52, 69, 87, 94, 111, 129}
 )
 
 func (i NodeType) String() string {
@@ -124,8 +168,38 @@ func (i NodeType) String() string {
 		return _NodeType_name_5[_NodeType_index_5[i]:_NodeType_index_5[i+1]]
 	case i == 600:
 		return _NodeType_name_6
-	case i == 1024:
-		return _NodeType_name_7
+	case 700 <= i && i <= 703:
+		i -= 700
+		return _NodeType_name_7[_NodeType_index_7[i]:_NodeType_index_7[i+1]]
+	case 800 <= i && i <= 802:
+		i -= 800
+		return _NodeType_name_8[_NodeType_index_8[i]:_NodeType_index_8[i+1]]
+	case 900 <= i && i <= 901:
+		i -= 900
+		return _NodeType_name_9[_NodeType_index_9[i]:_NodeType_index_9[i+1]]
+	case i == 1000:
+		return _NodeType_name_10
+	case 1100 <= i && i <= 1108:
+		i -= 1100
+		return _NodeType_name_11[_NodeType_index_11[i]:_NodeType_index_11[i+1]]
+	case i == 1200:
+		return _NodeType_name_12
+	case i == 1300:
+		return _NodeType_name_13
+	case i == 1400:
+		return _NodeType_name_14
+	case i == 1500:
+		return _NodeType_name_15
+	case i == 1600:
+		return _NodeType_name_16
+	case i == 1700:
+		return _NodeType_name_17
+	case i == 1800:
+		return _NodeType_name_18
+	case i == 1900:
+		return _NodeType_name_19
+	case i == 2048:
+		return _NodeType_name_20
 	default:
 		return "NodeType(" + strconv.FormatInt(int64(i), 10) + ")"
 	}
@@ -12,11 +12,14 @@ package lute
 
 import (
 	"bytes"
+	"strconv"
 	"strings"
+	"unicode/utf8"
 
 	"lute/ast"
 	"lute/html"
 	"lute/html/atom"
+	"lute/lex"
 	"lute/parse"
 	"lute/render"
 	"lute/util"
@@ -183,6 +186,30 @@ func (lute *Lute) genASTByDOM(n *html.Node, tree *parse.Tree) {
 		tree.Context.Tip.AppendChild(node)
 		tree.Context.Tip = node
 		defer tree.Context.ParentTip()
+	case atom.Dl:
+		node.Type = ast.NodeDefinitionList
+		node.ListData = &ast.ListData{Tight: true}
+		tree.Context.Tip.AppendChild(node)
+		tree.Context.Tip = node
+		defer tree.Context.ParentTip()
+	case atom.Dt:
+		node.Type = ast.NodeDefinitionTerm
+		tree.Context.Tip.AppendChild(node)
+		tree.Context.Tip = node
+		defer tree.Context.ParentTip()
+	case atom.Dd:
+		node.Type = ast.NodeDefinitionDescription
+		node.ListData = &ast.ListData{Tight: true, Marker: []byte{':'}, Padding: 2}
+		paragraphs := 0
+		for c := n.FirstChild; nil != c; c = c.NextSibling {
+			if atom.P == c.DataAtom {
+				paragraphs++
+			}
+		}
+		node.Tight = 2 > paragraphs
+		tree.Context.Tip.AppendChild(node)
+		tree.Context.Tip = node
+		defer tree.Context.ParentTip()
 	case atom.Pre:
 		firstc := n.FirstChild
 		if nil != firstc {
@@ -301,11 +328,40 @@ func (lute *Lute) genASTByDOM(n *html.Node, tree *parse.Tree) {
 		}
 	case atom.Del, atom.S, atom.Strike:
 		node.Type = ast.NodeStrikethrough
-		marker := "~"
-		node.AppendChild(&ast.Node{Type: ast.NodeStrikethrough1OpenMarker, Tokens: util.StrToBytes(marker)})
+		if lute.Sub { // 单个 ~ 会被解析为下标
+			node.AppendChild(&ast.Node{Type: ast.NodeStrikethrough2OpenMarker, Tokens: util.StrToBytes("~~")})
+		} else {
+			node.AppendChild(&ast.Node{Type: ast.NodeStrikethrough1OpenMarker, Tokens: util.StrToBytes("~")})
+		}
 		tree.Context.Tip.AppendChild(node)
 		tree.Context.Tip = node
 		defer tree.Context.ParentTip()
+	case atom.Mark, atom.Sup, atom.Sub:
+		switch {
+		case atom.Mark == n.DataAtom && lute.Mark:
+			node.Type = ast.NodeMark
+			node.AppendChild(&ast.Node{Type: ast.NodeMarkOpenMarker, Tokens: util.StrToBytes("==")})
+		case atom.Sup == n.DataAtom && lute.Sup:
+			node.Type = ast.NodeSup
+			node.AppendChild(&ast.Node{Type: ast.NodeSupOpenMarker, Tokens: util.StrToBytes("^")})
+		case atom.Sub == n.DataAtom && lute.Sub:
+			node.Type = ast.NodeSub
+			node.AppendChild(&ast.Node{Type: ast.NodeSubOpenMarker, Tokens: util.StrToBytes("~")})
+		}
+		if ast.NodeText != node.Type { // 未打开对应选项时仅保留文本
+			tree.Context.Tip.AppendChild(node)
+			tree.Context.Tip = node
+			defer tree.Context.ParentTip()
+		}
+	case atom.Ruby:
+		if lute.Ruby {
+			if rubies := lute.domRubies(n); 0 < len(rubies) {
+				for _, ruby := range rubies {
+					tree.Context.Tip.AppendChild(ruby)
+				}
+				return
+			}
+		}
 	case atom.Table:
 		node.Type = ast.NodeTable
 		var tableAligns []int
@@ -360,6 +416,10 @@ func (lute *Lute) genASTByDOM(n *html.Node, tree *parse.Tree) {
 			tableAlign = 0
 		}
 		node.TableCellAlign = tableAlign
+		if lute.TableSpan {
+			node.TableCellColspan, _ = strconv.Atoi(lute.domAttrValue(n, "colspan"))
+			node.TableCellRowspan, _ = strconv.Atoi(lute.domAttrValue(n, "rowspan"))
+		}
 		tree.Context.Tip.AppendChild(node)
 		tree.Context.Tip = node
 		defer tree.Context.ParentTip()
@@ -406,9 +466,165 @@ func (lute *Lute) genASTByDOM(n *html.Node, tree *parse.Tree) {
 		}
 		node.AppendChild(&ast.Node{Type: ast.NodeCloseParen})
 	case atom.Del, atom.S, atom.Strike:
-		marker := "~"
-		node.AppendChild(&ast.Node{Type: ast.NodeStrikethrough1CloseMarker, Tokens: util.StrToBytes(marker)})
+		if lute.Sub {
+			node.AppendChild(&ast.Node{Type: ast.NodeStrikethrough2CloseMarker, Tokens: util.StrToBytes("~~")})
+		} else {
+			node.AppendChild(&ast.Node{Type: ast.NodeStrikethrough1CloseMarker, Tokens: util.StrToBytes("~")})
+		}
+	case atom.Mark, atom.Sup, atom.Sub:
+		switch node.Type {
+		case ast.NodeMark:
+			node.AppendChild(&ast.Node{Type: ast.NodeMarkCloseMarker, Tokens: util.StrToBytes("==")})
+		case ast.NodeSup:
+			node.AppendChild(&ast.Node{Type: ast.NodeSupCloseMarker, Tokens: util.StrToBytes("^")})
+		case ast.NodeSub:
+			node.AppendChild(&ast.Node{Type: ast.NodeSubCloseMarker, Tokens: util.StrToBytes("~")})
+		}
 	case atom.Details:
 		tree.Context.Tip.AppendChild(&ast.Node{Type: ast.NodeHTMLBlock, Tokens: []byte("</details>")})
+	case atom.Table:
+		if lute.TableSpan {
+			lute.tableSpans(node)
+		}
+	}
+}
+
+// tableSpans 将表 table 中设置了 colspan 和 rowspan 的单元格展开为 || 和 ^^ 占位单元格，并标记包含换行的多行表行。
+func (lute *Lute) tableSpans(table *ast.Node) {
+	var rows []*ast.Node
+	for n := table.FirstChild; nil != n; n = n.Next {
+		if ast.NodeTableHead == n.Type {
+			if nil != n.FirstChild {
+				rows = append(rows, n.FirstChild)
+			}
+			continue
+		}
+		if ast.NodeTableRow == n.Type {
+			rows = append(rows, n)
+		}
 	}
+
+	var rowspans []int // 每一列还需要被上方单元格合并的行数
+	var owners []*ast.Node
+	for i, row := range rows {
+		col := 0
+		fill := func(before *ast.Node) {
+			for ; col < len(rowspans) && 0 < rowspans[col]; col++ {
+				rowspans[col]--
+				spanned := &ast.Node{Type: ast.NodeTableCell, TableCellAlign: owners[col].TableCellAlign, TableCellSpanned: lex.ItemPipe}
+				if col == 0 || owners[col] != owners[col-1] {
+					spanned.TableCellSpanned = lex.ItemCaret
+				}
+				if nil != before {
+					before.InsertBefore(spanned)
+				} else {
+					row.AppendChild(spanned)
+				}
+			}
+		}
+
+		for cell := row.FirstChild; nil != cell; {
+			next := cell.Next
+			fill(cell)
+
+			colspan := 1
+			if 1 < cell.TableCellColspan {
+				colspan = cell.TableCellColspan
+			}
+			rowspan := 0
+			if 0 < i && 1 < cell.TableCellRowspan { // 表头不能跨行
+				rowspan = cell.TableCellRowspan - 1
+			}
+			last := cell
+			for c := 0; c < colspan; c++ {
+				if 0 < c {
+					spanned := &ast.Node{Type: ast.NodeTableCell, TableCellAlign: cell.TableCellAlign, TableCellSpanned: lex.ItemPipe}
+					last.InsertAfter(spanned)
+					last = spanned
+				}
+				for len(rowspans) <= col {
+					rowspans = append(rowspans, 0)
+					owners = append(owners, nil)
+				}
+				if 0 < rowspan {
+					rowspans[col], owners[col] = rowspan, cell
+				}
+				col++
+			}
+			cell = next
+		}
+		fill(nil)
+
+		lines := 1
+		for cell := row.FirstChild; nil != cell; cell = cell.Next {
+			cellLines := 1
+			ast.Walk(cell, func(n *ast.Node, entering bool) ast.WalkStatus {
+				if entering && ast.NodeHardBreak == n.Type {
+					cellLines++
+				}
+				return ast.WalkContinue
+			})
+			if lines < cellLines {
+				lines = cellLines
+			}
+		}
+		if 1 < lines {
+			row.TableRowLines = lines
+		}
+	}
+
+	var aligns []int
+	if 0 < len(rows) {
+		for cell := rows[0].FirstChild; nil != cell; cell = cell.Next {
+			aligns = append(aligns, cell.TableCellAlign)
+		}
+	}
+	table.TableAligns = aligns
+}
+
+// domRubies 将 <ruby> 节点 n 转换为注音节点。每个字都有注音时合并为一个逐字注音节点，否则每组基文和注音文本生成一个节点，没有注音文本时返回 nil。
+func (lute *Lute) domRubies(n *html.Node) (ret []*ast.Node) {
+	var bases, texts []string
+	base := ""
+	for c := n.FirstChild; nil != c; c = c.NextSibling {
+		switch c.DataAtom {
+		case 0:
+			base += c.Data
+		case atom.Rb:
+			base += lute.domText(c)
+		case atom.Rt:
+			bases = append(bases, strings.TrimSpace(base))
+			texts = append(texts, strings.TrimSpace(lute.domText(c)))
+			base = ""
+		}
+	}
+	if 1 > len(texts) {
+		return nil
+	}
+	for i := range texts {
+		if strings.ContainsAny(bases[i]+texts[i], "{}|\n") {
+			// 无法使用注音语法表示，保留原样
+			return nil
+		}
+	}
+
+	perChar := 1 < len(bases)
+	for _, b := range bases {
+		if 1 != utf8.RuneCountInString(b) {
+			perChar = false
+			break
+		}
+	}
+	if perChar {
+		content := strings.Join(bases, "") + "|" + strings.Join(texts, "|")
+		return []*ast.Node{{Type: ast.NodeRuby, Tokens: []byte(content), RubyBases: bases, RubyTexts: texts}}
+	}
+
+	for i, b := range bases {
+		if "" == b || "" == texts[i] {
+			return nil
+		}
+		ret = append(ret, &ast.Node{Type: ast.NodeRuby, Tokens: []byte(b + "|" + texts[i]), RubyBases: []string{b}, RubyTexts: []string{texts[i]}})
+	}
+	return
 }
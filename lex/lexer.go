@@ -18,6 +18,10 @@ type Lexer struct {
 	length int    // 输入的文本字节数组的长度
 	offset int    // 当前读取字节位置
 	width  int    // 最新一个字符的长度（字节数）
+
+	srcOffset          int   // 当前读取位置对应的原始输入字节位置
+	lineStart, lineEnd int   // 最新一行在原始输入中的起始和结尾（不含换行符）字节位置
+	lineNuls           []int // 最新一行中由 \u0000 替换而来的 \uFFFD 在该行中的起始下标
 }
 
 // NewLexer 创建一个词法分析器。
@@ -40,6 +44,9 @@ func (l *Lexer) NextLine() (ret []byte) {
 	}
 
 	var b, nb byte
+	var crlf bool // 是否以 \r\n 结尾
+	var nuls int  // \u0000 个数
+	l.lineNuls = nil
 	i := l.offset
 	for ; i < l.length; i += l.width {
 		b = l.input[i]
@@ -52,6 +59,7 @@ func (l *Lexer) NextLine() (ret []byte) {
 				if ItemNewline == nb { // \r\n
 					l.input = append(l.input[:i], l.input[i+1:]...) // 移除 \r，依靠下一个的 \n 切行
 					l.length--                                      // 重新计算总长
+					crlf = true
 				} else { // \rX
 					l.input[i] = ItemNewline // 将 \r 替换为 \n
 				}
@@ -68,6 +76,8 @@ func (l *Lexer) NextLine() (ret []byte) {
 			l.input[i], l.input[i+1], l.input[i+2] = '\xEF', '\xBF', '\xBD'
 			l.length += 2 // 重新计算总长
 			l.width = 3
+			nuls++
+			l.lineNuls = append(l.lineNuls, i-l.offset)
 			continue
 		}
 
@@ -79,5 +89,27 @@ func (l *Lexer) NextLine() (ret []byte) {
 	}
 	ret = l.input[l.offset:i]
 	l.offset = i
+
+	// 换算原始输入中的位置，\r\n 被移除了 \r，\u0000 被替换为了三个字节
+	l.lineStart = l.srcOffset
+	contentLen := len(ret)
+	if 0 < contentLen && ItemNewline == ret[contentLen-1] {
+		contentLen--
+	}
+	l.lineEnd = l.lineStart + contentLen - nuls*2
+	l.srcOffset = l.lineEnd + len(ret) - contentLen
+	if crlf {
+		l.srcOffset++
+	}
 	return
 }
+
+// LinePos 返回最新一行在原始输入中的起始和结尾（不含换行符）字节位置。
+func (l *Lexer) LinePos() (start, end int) {
+	return l.lineStart, l.lineEnd
+}
+
+// LineNuls 返回最新一行中由 \u0000 替换而来的 \uFFFD 在该行中的起始下标，替换后该行每个 \u0000 多占用两个字节。
+func (l *Lexer) LineNuls() []int {
+	return l.lineNuls
+}
@@ -12,6 +12,7 @@ package lex
 
 import (
 	"unicode"
+	"unicode/utf8"
 )
 
 const (
@@ -29,6 +30,8 @@ const (
 	ItemTab            = byte('\t')
 	ItemOpenBracket    = byte('[')
 	ItemCloseBracket   = byte(']')
+	ItemOpenBrace      = byte('{')
+	ItemCloseBrace     = byte('}')
 	ItemDoublequote    = byte('"')
 	ItemSinglequote    = byte('\'')
 	ItemLess           = byte('<')
@@ -58,6 +61,21 @@ func IsUnicodeWhitespace(r rune) bool {
 	return unicode.IsSpace(r) || unicode.Is(unicode.Zs, r)
 }
 
+// IsSameWord 判断相邻的字符 before 和 after 是否属于同一个词：两者都是字母或者数字，并且同为 ASCII 或者同为非 ASCII 字符。
+// 缩写等按词匹配的语法使用它判定词边界。
+func IsSameWord(before, after rune) bool {
+	if !(unicode.IsLetter(before) || unicode.IsDigit(before)) || !(unicode.IsLetter(after) || unicode.IsDigit(after)) {
+		return false
+	}
+	return (utf8.RuneSelf > before) == (utf8.RuneSelf > after)
+}
+
+// IsCJK 判断 r 是否是中日韩字符，包括汉字、假名、谚文、注音、中日韩符号和标点以及全角字符。
+func IsCJK(r rune) bool {
+	return unicode.Is(unicode.Han, r) || unicode.Is(unicode.Hiragana, r) || unicode.Is(unicode.Katakana, r) || unicode.Is(unicode.Hangul, r) ||
+		unicode.Is(unicode.Bopomofo, r) || (0x3000 <= r && 0x303F >= r) || (0xFF01 <= r && 0xFF60 >= r) || (0xFFE0 <= r && 0xFFE6 >= r)
+}
+
 // IsDigit 判断 token 是否为数字 0-9。
 func IsDigit(token byte) bool {
 	return '0' <= token && '9' >= token
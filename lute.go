@@ -64,21 +64,41 @@ func NewOptions() *parse.Options {
 	emojis, emoji := parse.NewEmojis()
 	return &parse.Options{
 		GFMTable:                       true,
+		TableSpan:                      false,
+		TableCaption:                   false,
+		CaptionNumbering:               false,
+		TableNumberPrefix:              "Table ",
+		FigureNumberPrefix:             "Figure ",
+		CrossRef:                       false,
+		SectionNumberPrefix:            "Section ",
+		EquationNumberPrefix:           "Equation ",
+		HeadingNumbering:               false,
+		HeadingNumberingStartLevel:     0,
+		HeadingNumberingMaxDepth:       6,
+		HeadingNumberingSeparator:      ".",
+		FormatHeadingNumber:            false,
 		GFMTaskListItem:                true,
 		GFMTaskListItemClass:           "vditor-task",
+		TaskListItemStates:             nil,
+		TaskListItemIndex:              false,
 		GFMStrikethrough:               true,
 		GFMAutoLink:                    true,
+		CJKFriendlyEmphasis:            false,
 		SoftBreak2HardBreak:            true,
+		CJKSoftBreak:                   false,
 		CodeSyntaxHighlight:            true,
 		CodeSyntaxHighlightInlineStyle: false,
 		CodeSyntaxHighlightLineNum:     false,
 		CodeSyntaxHighlightStyleName:   "github",
 		Footnotes:                      true,
+		InlineFootnotes:                false,
+		HoistInlineFootnotes:           false,
 		ToC:                            false,
 		HeadingID:                      true,
 		AutoSpace:                      true,
 		FixTermTypo:                    true,
 		ChinesePunct:                   true,
+		ChineseConvert:                 "",
 		Emoji:                          true,
 		AliasEmoji:                     emojis,
 		EmojiAlias:                     emoji,
@@ -89,6 +109,28 @@ func NewOptions() *parse.Options {
 		RenderListMarker:               false,
 		Setext:                         true,
 		ChineseParagraphBeginningSpace: false,
+		RenderSourceLine:               false,
+		FrontMatter:                    false,
+		DefinitionList:                 false,
+		GitHubAlert:                    false,
+		CustomContainer:                false,
+		Mark:                           false,
+		Sup:                            false,
+		Sub:                            false,
+		AttributeList:                  false,
+		WikiLink:                       false,
+		Abbreviation:                   false,
+		Tag:                            false,
+		TagURLTemplate:                 "",
+		Mention:                        false,
+		IssueRef:                       false,
+		RepoIssueRef:                   false,
+		CommitRef:                      false,
+		Transclusion:                   false,
+		MaxIncludeDepth:                8,
+		Citation:                       false,
+		CitationStyle:                  parse.CitationStyleAuthorYear,
+		Ruby:                           false,
 	}
 }
 
@@ -100,6 +142,9 @@ func (lute *Lute) Markdown(name string, markdown []byte) (html []byte) {
 		renderer.ExtRendererFuncs[nodeType] = rendererFunc
 	}
 	html = renderer.Render()
+	if lute.Options.Citation && 0 < len(tree.Context.Citations) {
+		html = renderer.RenderBibliography(tree.Context)
+	}
 	if lute.Options.Footnotes && 0 < len(tree.Context.FootnotesDefs) {
 		html = renderer.RenderFootnotesDefs(tree.Context)
 	}
@@ -115,7 +160,10 @@ func (lute *Lute) MarkdownStr(name, markdown string) (html string) {
 
 // Format 将 markdown 文本字节数组进行格式化。
 func (lute *Lute) Format(name string, markdown []byte) (formatted []byte) {
-	tree := parse.Parse(name, markdown, lute.Options)
+	// 格式化时保留嵌入指令，不展开被嵌入的文档
+	options := *lute.Options
+	options.Transclusion = false
+	tree := parse.Parse(name, markdown, &options)
 	renderer := render.NewFormatRenderer(tree)
 	formatted = renderer.Render()
 	return
@@ -134,6 +182,11 @@ func (lute *Lute) Space(text string) string {
 	return render.Space0(text)
 }
 
+// ConvertChinese 用于将 text 按照 direction（render.ChineseS2T 或者 render.ChineseT2S）进行简繁转换。
+func (lute *Lute) ConvertChinese(text, direction string) string {
+	return render.ConvertChinese0(text, direction)
+}
+
 // GetEmojis 返回 Emoji 别名和对应 Unicode 字符的字典列表。
 func (lute *Lute) GetEmojis() (ret map[string]string) {
 	ret = make(map[string]string, len(lute.AliasEmoji))
@@ -176,6 +229,65 @@ func (lute *Lute) SetGFMTable(b bool) {
 	lute.GFMTable = b
 }
 
+func (lute *Lute) SetTableSpan(b bool) {
+	lute.TableSpan = b
+}
+
+func (lute *Lute) SetTableCaption(b bool) {
+	lute.TableCaption = b
+}
+
+func (lute *Lute) SetCaptionNumbering(b bool) {
+	lute.CaptionNumbering = b
+}
+
+// SetTableNumberPrefix 设置表编号的前缀，比如 "表 "。
+func (lute *Lute) SetTableNumberPrefix(prefix string) {
+	lute.TableNumberPrefix = prefix
+}
+
+// SetFigureNumberPrefix 设置图编号的前缀，比如 "图 "。
+func (lute *Lute) SetFigureNumberPrefix(prefix string) {
+	lute.FigureNumberPrefix = prefix
+}
+
+func (lute *Lute) SetCrossRef(b bool) {
+	lute.CrossRef = b
+}
+
+// SetSectionNumberPrefix 设置交叉引用章节时编号的前缀，比如 "第 "。
+func (lute *Lute) SetSectionNumberPrefix(prefix string) {
+	lute.SectionNumberPrefix = prefix
+}
+
+// SetEquationNumberPrefix 设置交叉引用公式时编号的前缀，比如 "公式 "。
+func (lute *Lute) SetEquationNumberPrefix(prefix string) {
+	lute.EquationNumberPrefix = prefix
+}
+
+func (lute *Lute) SetHeadingNumbering(b bool) {
+	lute.HeadingNumbering = b
+}
+
+// SetHeadingNumberingStartLevel 设置从哪一级标题开始编号，0 表示从文档中最高的标题层级开始编号。
+func (lute *Lute) SetHeadingNumberingStartLevel(level int) {
+	lute.HeadingNumberingStartLevel = level
+}
+
+// SetHeadingNumberingMaxDepth 设置标题编号的最大层数。
+func (lute *Lute) SetHeadingNumberingMaxDepth(depth int) {
+	lute.HeadingNumberingMaxDepth = depth
+}
+
+// SetHeadingNumberingSeparator 设置标题编号各层之间的分隔符，比如 "-"。
+func (lute *Lute) SetHeadingNumberingSeparator(separator string) {
+	lute.HeadingNumberingSeparator = separator
+}
+
+func (lute *Lute) SetFormatHeadingNumber(b bool) {
+	lute.FormatHeadingNumber = b
+}
+
 func (lute *Lute) SetGFMTaskListItem(b bool) {
 	lute.GFMTaskListItem = b
 }
@@ -184,6 +296,15 @@ func (lute *Lute) SetGFMTaskListItemClass(class string) {
 	lute.GFMTaskListItemClass = class
 }
 
+// SetTaskListItemStates 设置除了 [ ] 和 [x] 以外可识别的任务列表项状态，键为状态字符，值为状态名称，常用的扩展状态可使用 parse.ExtendedTaskListItemStates。
+func (lute *Lute) SetTaskListItemStates(states map[byte]string) {
+	lute.TaskListItemStates = states
+}
+
+func (lute *Lute) SetTaskListItemIndex(b bool) {
+	lute.TaskListItemIndex = b
+}
+
 func (lute *Lute) SetGFMStrikethrough(b bool) {
 	lute.GFMStrikethrough = b
 }
@@ -192,10 +313,18 @@ func (lute *Lute) SetGFMAutoLink(b bool) {
 	lute.GFMAutoLink = b
 }
 
+func (lute *Lute) SetCJKFriendlyEmphasis(b bool) {
+	lute.CJKFriendlyEmphasis = b
+}
+
 func (lute *Lute) SetSoftBreak2HardBreak(b bool) {
 	lute.SoftBreak2HardBreak = b
 }
 
+func (lute *Lute) SetCJKSoftBreak(b bool) {
+	lute.CJKSoftBreak = b
+}
+
 func (lute *Lute) SetCodeSyntaxHighlight(b bool) {
 	lute.CodeSyntaxHighlight = b
 }
@@ -220,6 +349,14 @@ func (lute *Lute) SetFootnotes(b bool) {
 	lute.Footnotes = b
 }
 
+func (lute *Lute) SetInlineFootnotes(b bool) {
+	lute.InlineFootnotes = b
+}
+
+func (lute *Lute) SetHoistInlineFootnotes(b bool) {
+	lute.HoistInlineFootnotes = b
+}
+
 func (lute *Lute) SetToC(b bool) {
 	lute.ToC = b
 }
@@ -240,6 +377,11 @@ func (lute *Lute) SetChinesePunct(b bool) {
 	lute.ChinesePunct = b
 }
 
+// SetChineseConvert 设置简繁转换方向，render.ChineseS2T 或者 render.ChineseT2S，为空时不转换。
+func (lute *Lute) SetChineseConvert(direction string) {
+	lute.ChineseConvert = direction
+}
+
 func (lute *Lute) SetEmoji(b bool) {
 	lute.Emoji = b
 }
@@ -296,6 +438,131 @@ func (lute *Lute) SetChineseParagraphBeginningSpace(b bool) {
 	lute.ChineseParagraphBeginningSpace = b
 }
 
+func (lute *Lute) SetRenderSourceLine(b bool) {
+	lute.RenderSourceLine = b
+}
+
+func (lute *Lute) SetFrontMatter(b bool) {
+	lute.FrontMatter = b
+}
+
+func (lute *Lute) SetDefinitionList(b bool) {
+	lute.DefinitionList = b
+}
+
+func (lute *Lute) SetGitHubAlert(b bool) {
+	lute.GitHubAlert = b
+}
+
+func (lute *Lute) SetCustomContainer(b bool) {
+	lute.CustomContainer = b
+}
+
+func (lute *Lute) SetMark(b bool) {
+	lute.Mark = b
+}
+
+func (lute *Lute) SetSup(b bool) {
+	lute.Sup = b
+}
+
+func (lute *Lute) SetSub(b bool) {
+	lute.Sub = b
+}
+
+func (lute *Lute) SetAttributeList(b bool) {
+	lute.AttributeList = b
+}
+
+func (lute *Lute) SetWikiLink(b bool) {
+	lute.WikiLink = b
+}
+
+// SetWikiLinkResolver 设置维基链接解析器，用于将页面名称和锚点映射为链接地址，以及判断页面是否存在。
+func (lute *Lute) SetWikiLinkResolver(resolver parse.WikiLinkResolver) {
+	lute.WikiLinkResolver = resolver
+}
+
+func (lute *Lute) SetAbbreviation(b bool) {
+	lute.Abbreviation = b
+}
+
+func (lute *Lute) SetTag(b bool) {
+	lute.Tag = b
+}
+
+// SetTagURLTemplate 设置标签链接地址模板，比如 "/tags/${tag}"。
+func (lute *Lute) SetTagURLTemplate(template string) {
+	lute.TagURLTemplate = template
+}
+
+func (lute *Lute) SetMention(b bool) {
+	lute.Mention = b
+}
+
+func (lute *Lute) SetIssueRef(b bool) {
+	lute.IssueRef = b
+}
+
+func (lute *Lute) SetRepoIssueRef(b bool) {
+	lute.RepoIssueRef = b
+}
+
+func (lute *Lute) SetCommitRef(b bool) {
+	lute.CommitRef = b
+}
+
+// SetReferenceResolver 设置引用解析器，用于判断提及、问题引用和提交引用是否存在以及映射为链接地址。
+func (lute *Lute) SetReferenceResolver(resolver parse.ReferenceResolver) {
+	lute.ReferenceResolver = resolver
+}
+
+func (lute *Lute) SetTransclusion(b bool) {
+	lute.Transclusion = b
+}
+
+// SetContentLoader 设置嵌入文档的内容加载器，用于按照路径读取被 !include 或者 ![[page]] 嵌入的文档。
+func (lute *Lute) SetContentLoader(loader parse.ContentLoader) {
+	lute.ContentLoader = loader
+}
+
+// SetMaxIncludeDepth 设置嵌入文档的最大嵌套层数，超过后不再展开并记录诊断信息。
+func (lute *Lute) SetMaxIncludeDepth(depth int) {
+	lute.MaxIncludeDepth = depth
+}
+
+func (lute *Lute) SetCitation(b bool) {
+	lute.Citation = b
+}
+
+// SetBibliography 设置参考文献库，可以使用 parse.ParseBibTeX 或者 parse.ParseCSLJSON 加载。
+func (lute *Lute) SetBibliography(bibliography parse.Bibliography) {
+	lute.Bibliography = bibliography
+}
+
+// SetCitationStyle 设置文献引用样式，parse.CitationStyleAuthorYear 或者 parse.CitationStyleNumeric。
+func (lute *Lute) SetCitationStyle(style string) {
+	lute.CitationStyle = style
+}
+
+func (lute *Lute) SetRuby(b bool) {
+	lute.Ruby = b
+}
+
+// PutCustomContainerRenderer 通过 Md2HTMLRendererFuncs 为名称为 name 的自定义容器注册 HTML 渲染函数，其他名称的容器仍然使用之前注册的或者默认的渲染函数。
+func (lute *Lute) PutCustomContainerRenderer(name string, rendererFunc render.ExtRendererFunc) {
+	next := lute.Md2HTMLRendererFuncs[ast.NodeCustomContainer]
+	if nil == next {
+		next = render.CustomContainerHTML
+	}
+	lute.Md2HTMLRendererFuncs[ast.NodeCustomContainer] = func(node *ast.Node, entering bool) (string, ast.WalkStatus) {
+		if name == node.CustomContainerName {
+			return rendererFunc(node, entering)
+		}
+		return next(node, entering)
+	}
+}
+
 func (lute *Lute) SetJSRenderers(options map[string]map[string]*js.Object) {
 	for rendererType, extRenderer := range options["renderers"] {
 		switch extRenderer.Interface().(type) { // 稍微进行一点格式校验
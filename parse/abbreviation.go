@@ -0,0 +1,148 @@
+// Lute - 一款对中文语境优化的 Markdown 引擎，支持 Go 和 JavaScript
+// Copyright (c) 2019-present, b3log.org
+//
+// Lute is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//         http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND, EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT, MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+package parse
+
+import (
+	"bytes"
+	"sort"
+	"unicode/utf8"
+
+	"lute/ast"
+	"lute/lex"
+	"lute/util"
+)
+
+// parseAbbreviationDef 解析 tokens 开头的缩写定义 *[HTML]: Hyper Text Markup Language，成功时返回剩余的 tokens，否则返回 nil。
+func (context *Context) parseAbbreviationDef(tokens []byte) []byte {
+	if 3 > len(tokens) || lex.ItemAsterisk != tokens[0] || lex.ItemOpenBracket != tokens[1] {
+		return nil
+	}
+
+	line, remains := tokens, []byte{}
+	if i := bytes.IndexByte(tokens, lex.ItemNewline); -1 < i {
+		line, remains = tokens[:i], tokens[i+1:]
+	}
+
+	closeBracket := bytes.IndexByte(line, lex.ItemCloseBracket)
+	if 2 > closeBracket || len(line) <= closeBracket+1 || lex.ItemColon != line[closeBracket+1] {
+		return nil
+	}
+	label := lex.TrimWhitespace(line[2:closeBracket])
+	if 1 > len(label) || -1 < bytes.IndexByte(label, lex.ItemOpenBracket) {
+		return nil
+	}
+
+	title := lex.TrimWhitespace(line[closeBracket+2:])
+	if _, ok := context.Abbreviations[string(label)]; !ok {
+		// 重复定义时以第一个定义为准
+		context.Abbreviations[string(label)] = util.BytesToStr(title)
+	}
+	return remains
+}
+
+// paragraphAbbreviationDefs 解析段落 p 末尾连续的缩写定义行，开头的缩写定义行已经在解析链接引用定义时一并处理。
+func (context *Context) paragraphAbbreviationDefs(p *ast.Node) {
+	for lastNewline := bytes.LastIndexByte(p.Tokens, lex.ItemNewline); 0 < lastNewline; lastNewline = bytes.LastIndexByte(p.Tokens, lex.ItemNewline) {
+		if nil == context.parseAbbreviationDef(p.Tokens[lastNewline+1:]) {
+			return
+		}
+		p.Tokens = lex.TrimWhitespace(p.Tokens[:lastNewline])
+	}
+}
+
+// abbreviation 将 node 下文本节点中出现的缩写替换为缩写节点。
+func (t *Tree) abbreviation(node *ast.Node) {
+	keys := make([]string, 0, len(t.Context.Abbreviations))
+	for key := range t.Context.Abbreviations {
+		keys = append(keys, key)
+	}
+	// 优先匹配较长的缩写
+	sort.Slice(keys, func(i, j int) bool {
+		if len(keys[i]) != len(keys[j]) {
+			return len(keys[i]) > len(keys[j])
+		}
+		return keys[i] < keys[j]
+	})
+	t.abbreviation0(node, keys)
+}
+
+func (t *Tree) abbreviation0(node *ast.Node, keys []string) {
+	for child := node.FirstChild; nil != child; {
+		next := child.Next
+		if ast.NodeText == child.Type {
+			previous := child.Previous
+			t.abbreviationText(child, keys)
+			t.Context.repositionSplit(child, node, previous, next)
+			if 1 > len(child.Tokens) {
+				child.Unlink()
+			}
+		} else if ast.NodeAbbr != child.Type {
+			t.abbreviation0(child, keys) // 递归处理子节点
+		}
+		child = next
+	}
+}
+
+func (t *Tree) abbreviationText(node *ast.Node, keys []string) {
+	tokens := node.Tokens
+	length := len(tokens)
+	current := node
+	pos := 0
+	for i := 0; i < length; {
+		if !abbreviationBoundary(tokens, i) {
+			i++
+			continue
+		}
+
+		var key string
+		for _, k := range keys {
+			if bytes.HasPrefix(tokens[i:], util.StrToBytes(k)) && abbreviationBoundary(tokens, i+len(k)) {
+				key = k
+				break
+			}
+		}
+		if "" == key {
+			i++
+			continue
+		}
+
+		if current == node {
+			node.Tokens = tokens[pos:i]
+		} else if pos < i {
+			text := &ast.Node{Type: ast.NodeText, Tokens: tokens[pos:i]}
+			current.InsertAfter(text)
+			current = text
+		}
+		abbr := &ast.Node{Type: ast.NodeAbbr, AbbrTitle: t.Context.Abbreviations[key]}
+		abbr.AppendChild(&ast.Node{Type: ast.NodeText, Tokens: tokens[i : i+len(key)]})
+		current.InsertAfter(abbr)
+		current = abbr
+		i += len(key)
+		pos = i
+	}
+	if current != node && pos < length {
+		current.InsertAfter(&ast.Node{Type: ast.NodeText, Tokens: tokens[pos:]})
+	}
+}
+
+// abbreviationBoundary 判断 tokens 中下标 i 处是否是词边界，同一个词使用 lex.IsSameWord 判定。
+// 中日韩文本不使用空格分词，所以 lex.IsCJK 包括的字符（含中日韩标点和全角字符）前后总是词边界，比如全角的 ＡＰＩ 在 ＡＰＩＳ 中也会被识别为缩写。
+func abbreviationBoundary(tokens []byte, i int) bool {
+	if 0 >= i || len(tokens) <= i {
+		return true
+	}
+	before, _ := utf8.DecodeLastRune(tokens[:i])
+	after, _ := utf8.DecodeRune(tokens[i:])
+	if lex.IsCJK(before) || lex.IsCJK(after) {
+		return true
+	}
+	return !lex.IsSameWord(before, after)
+}
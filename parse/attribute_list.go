@@ -0,0 +1,213 @@
+// Lute - 一款对中文语境优化的 Markdown 引擎，支持 Go 和 JavaScript
+// Copyright (c) 2019-present, b3log.org
+//
+// Lute is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//         http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND, EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT, MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+package parse
+
+import (
+	"bytes"
+	"sort"
+	"strings"
+
+	"lute/ast"
+	"lute/lex"
+)
+
+// unnumberedClass 是标记标题不编号的类名，属性列表中的 {-} 是它的简写。
+const unnumberedClass = "unnumbered"
+
+// ParseAttributeList 解析 tokens 开头的属性列表 {#id .class key=value}，返回解析得到的属性以及属性列表占用的字节数。
+// 兼容 kramdown 的 {: .class} 写法，值可以使用单引号或者双引号包裹，单独的 - 等同于 .unnumbered。不是合法的属性列表时返回 nil。
+func ParseAttributeList(tokens []byte) (attrs map[string]string, n int) {
+	length := len(tokens)
+	if 2 > length || lex.ItemOpenBrace != tokens[0] {
+		return nil, 0
+	}
+
+	attrs = map[string]string{}
+	var classes []string
+	i := 1
+	if i < length && lex.ItemColon == tokens[i] {
+		i++
+	}
+	for {
+		for ; i < length && lex.IsWhitespace(tokens[i]) && lex.ItemNewline != tokens[i]; i++ {
+		}
+		if i >= length {
+			return nil, 0
+		}
+
+		token := tokens[i]
+		if lex.ItemCloseBrace == token {
+			break
+		}
+
+		if lex.ItemHyphen == token && (i+1 >= length || lex.IsWhitespace(tokens[i+1]) || lex.ItemCloseBrace == tokens[i+1]) {
+			classes = append(classes, unnumberedClass)
+			i++
+		} else if lex.ItemCrosshatch == token || lex.ItemDot == token {
+			start := i + 1
+			for i = start; i < length && isAttributeNameChar(tokens[i]); i++ {
+			}
+			if start == i {
+				return nil, 0
+			}
+			if lex.ItemCrosshatch == token {
+				attrs["id"] = string(tokens[start:i])
+			} else {
+				classes = append(classes, string(tokens[start:i]))
+			}
+		} else {
+			start := i
+			for ; i < length && isAttributeNameChar(tokens[i]); i++ {
+			}
+			if start == i || i >= length || lex.ItemEqual != tokens[i] {
+				return nil, 0
+			}
+			key := string(tokens[start:i])
+			if lowerKey := strings.ToLower(key); "id" == lowerKey || "class" == lowerKey {
+				key = lowerKey
+			}
+			i++
+			if i >= length {
+				return nil, 0
+			}
+			var value []byte
+			if quote := tokens[i]; lex.ItemDoublequote == quote || lex.ItemSinglequote == quote {
+				end := bytes.IndexByte(tokens[i+1:], quote)
+				if 0 > end {
+					return nil, 0
+				}
+				value = tokens[i+1 : i+1+end]
+				i += end + 2
+			} else {
+				start = i
+				for ; i < length && !lex.IsWhitespace(tokens[i]) && lex.ItemCloseBrace != tokens[i] && lex.ItemDoublequote != tokens[i] && lex.ItemSinglequote != tokens[i]; i++ {
+				}
+				value = tokens[start:i]
+			}
+			if "class" == key {
+				classes = append(classes, strings.Fields(string(value))...)
+			} else {
+				attrs[key] = string(value)
+			}
+		}
+
+		if i < length && !lex.IsWhitespace(tokens[i]) && lex.ItemCloseBrace != tokens[i] {
+			return nil, 0
+		}
+	}
+
+	if 0 < len(classes) {
+		attrs["class"] = strings.Join(classes, " ")
+	}
+	if 1 > len(attrs) {
+		return nil, 0
+	}
+	return attrs, i + 1
+}
+
+// parseTrailingAttributeList 解析 tokens 末尾的属性列表，返回属性以及剔除属性列表后的剩余部分。
+func parseTrailingAttributeList(tokens []byte) (attrs map[string]string, remains []byte) {
+	_, tokens = lex.TrimRight(tokens)
+	length := len(tokens)
+	if 1 > length || lex.ItemCloseBrace != tokens[length-1] {
+		return nil, tokens
+	}
+
+	for i := bytes.LastIndexByte(tokens, lex.ItemOpenBrace); 0 <= i; i = bytes.LastIndexByte(tokens[:i], lex.ItemOpenBrace) {
+		if attrs, n := ParseAttributeList(tokens[i:]); nil != attrs && length == i+n {
+			_, remains = lex.TrimRight(tokens[:i])
+			return attrs, remains
+		}
+	}
+	return nil, tokens
+}
+
+// isAttributeNameChar 判断 token 是否可以用于属性名、ID 或者类名。
+func isAttributeNameChar(token byte) bool {
+	return lex.IsASCIILetterNum(token) || lex.ItemHyphen == token || lex.ItemUnderscore == token || lex.ItemColon == token || lex.ItemDot == token
+}
+
+// paragraphAttributeList 解析段落最后一行的属性列表。
+func paragraphAttributeList(p *ast.Node) {
+	lastNewline := bytes.LastIndexByte(p.Tokens, lex.ItemNewline)
+	if 0 > lastNewline {
+		return
+	}
+	line := lex.TrimWhitespace(p.Tokens[lastNewline+1:])
+	if attrs, n := ParseAttributeList(line); nil != attrs && len(line) == n {
+		p.Attributes = attrs
+		p.Tokens = lex.TrimWhitespace(p.Tokens[:lastNewline])
+	}
+}
+
+// inlineAttributeList 解析行级节点（强调、加粗、链接和图片）后紧跟着的属性列表。
+func (t *Tree) inlineAttributeList(block *ast.Node) {
+	ast.Walk(block, func(n *ast.Node, entering bool) ast.WalkStatus {
+		if !entering {
+			return ast.WalkContinue
+		}
+		if ast.NodeEmphasis != n.Type && ast.NodeStrong != n.Type && ast.NodeLink != n.Type && ast.NodeImage != n.Type {
+			return ast.WalkContinue
+		}
+
+		next := n.Next
+		if nil == next || ast.NodeText != next.Type {
+			return ast.WalkContinue
+		}
+		if attrs, length := ParseAttributeList(next.Tokens); nil != attrs {
+			n.Attributes = attrs
+			next.Tokens = next.Tokens[length:]
+			moveStart(next, length)
+			if 1 > len(next.Tokens) {
+				next.Unlink()
+			}
+		}
+		return ast.WalkContinue
+	})
+}
+
+// AttributeListStr 将属性 attrs 转换为 {#id .class key=value} 形式的属性列表文本，.unnumbered 使用简写 - 表示。
+func AttributeListStr(attrs map[string]string) string {
+	var items []string
+	if id := attrs["id"]; "" != id {
+		items = append(items, "#"+id)
+	}
+	for _, class := range strings.Fields(attrs["class"]) {
+		if unnumberedClass == class {
+			items = append(items, "-")
+			continue
+		}
+		items = append(items, "."+class)
+	}
+	for _, key := range SortedAttributeKeys(attrs) {
+		value := attrs[key]
+		if "" == value || strings.ContainsAny(value, " \t\"'}") {
+			quote := "\""
+			if strings.Contains(value, quote) {
+				quote = "'"
+			}
+			value = quote + value + quote
+		}
+		items = append(items, key+"="+value)
+	}
+	return "{" + strings.Join(items, " ") + "}"
+}
+
+// SortedAttributeKeys 返回属性 attrs 中除 id 和 class 以外的属性名，按字典序排列。
+func SortedAttributeKeys(attrs map[string]string) (ret []string) {
+	for key := range attrs {
+		if "id" != key && "class" != key {
+			ret = append(ret, key)
+		}
+	}
+	sort.Strings(ret)
+	return
+}
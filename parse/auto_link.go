@@ -24,7 +24,9 @@ func (t *Tree) parseGFMAutoEmailLink(node *ast.Node) {
 		next := child.Next
 		if ast.NodeText == child.Type && nil != child.Parent &&
 			ast.NodeLink != child.Parent.Type /* 不处理链接 label */ {
+			previous := child.Previous
 			t.parseGFMAutoEmailLink0(child)
+			t.Context.repositionSplit(child, node, previous, next)
 		} else {
 			t.parseGFMAutoEmailLink(child) // 递归处理子节点
 		}
@@ -36,7 +38,9 @@ func (t *Tree) parseGFMAutoLink(node *ast.Node) {
 	for child := node.FirstChild; nil != child; {
 		next := child.Next
 		if ast.NodeText == child.Type {
+			previous := child.Previous
 			t.parseGFMAutoLink0(child)
+			t.Context.repositionSplit(child, node, previous, next)
 		} else {
 			t.parseGFMAutoLink(child) // 递归处理子节点
 		}
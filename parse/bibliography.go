@@ -0,0 +1,371 @@
+// Lute - 一款对中文语境优化的 Markdown 引擎，支持 Go 和 JavaScript
+// Copyright (c) 2019-present, b3log.org
+//
+// Lute is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//         http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND, EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT, MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+package parse
+
+import (
+	"encoding/json"
+	"errors"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Bibliography 描述了参考文献库，键为文献键。
+type Bibliography map[string]*BibEntry
+
+// BibEntry 描述了参考文献库中的一条文献。
+type BibEntry struct {
+	Key       string     // 文献键
+	Type      string     // 文献类型，比如 article、book
+	Authors   []*BibName // 作者
+	Title     string     // 标题
+	Year      string     // 出版年份
+	Container string     // 所在的期刊、会议论文集或者书名
+	Publisher string     // 出版者
+	Pages     string     // 页码范围
+	URL       string     // 链接地址
+	DOI       string     // DOI
+}
+
+// BibName 描述了文献作者的姓名。
+type BibName struct {
+	Family  string // 姓
+	Given   string // 名
+	Literal string // 不区分姓名的完整名称，比如机构名
+}
+
+// String 返回 Given Family 形式的姓名。
+func (name *BibName) String() string {
+	if "" != name.Literal {
+		return name.Literal
+	}
+	return strings.TrimSpace(name.Given + " " + name.Family)
+}
+
+// FamilyName 返回用于引用和排序的姓。
+func (name *BibName) FamilyName() string {
+	if "" != name.Literal {
+		return name.Literal
+	}
+	return name.Family
+}
+
+// ParseBibTeX 解析 BibTeX 格式的参考文献库，支持 @string 宏定义，忽略 @comment 和 @preamble。
+func ParseBibTeX(data []byte) (ret Bibliography, err error) {
+	p := &bibTeXParser{data: []rune(string(data)), macros: map[string]string{}}
+	ret = Bibliography{}
+	for {
+		p.skipUntil('@')
+		if p.eof() {
+			return
+		}
+		p.pos++ // @
+		typ := strings.ToLower(p.readWord())
+		p.skipSpace()
+		if p.eof() || ('{' != p.peek() && '(' != p.peek()) {
+			return nil, p.error("expected { after @" + typ)
+		}
+		closer := '}'
+		if '(' == p.peek() {
+			closer = ')'
+		}
+		p.pos++
+
+		switch typ {
+		case "comment", "preamble":
+			if err = p.skipBalanced(closer); nil != err {
+				return nil, err
+			}
+			continue
+		case "string":
+			fields, err := p.readFields(closer)
+			if nil != err {
+				return nil, err
+			}
+			for name, value := range fields {
+				p.macros[name] = value
+			}
+			continue
+		}
+
+		p.skipSpace()
+		start := p.pos
+		p.skipUntil(',')
+		if p.eof() {
+			return nil, p.error("expected , after entry key")
+		}
+		key := strings.TrimSpace(string(p.data[start:p.pos]))
+		p.pos++ // ,
+		fields, err := p.readFields(closer)
+		if nil != err {
+			return nil, err
+		}
+		ret[key] = newBibTeXEntry(key, typ, fields)
+	}
+}
+
+// bibTeXParser 用于解析 BibTeX 文本。
+type bibTeXParser struct {
+	data   []rune            // BibTeX 文本
+	pos    int               // 当前解析位置
+	macros map[string]string // @string 宏定义，键为小写的宏名
+}
+
+func (p *bibTeXParser) eof() bool {
+	return p.pos >= len(p.data)
+}
+
+func (p *bibTeXParser) peek() rune {
+	return p.data[p.pos]
+}
+
+func (p *bibTeXParser) error(message string) error {
+	ln := 1 + strings.Count(string(p.data[:p.pos]), "\n")
+	return errors.New("bibtex: line " + strconv.Itoa(ln) + ": " + message)
+}
+
+func (p *bibTeXParser) skipSpace() {
+	for ; !p.eof() && unicode.IsSpace(p.peek()); p.pos++ {
+	}
+}
+
+func (p *bibTeXParser) skipUntil(r rune) {
+	for ; !p.eof() && r != p.peek(); p.pos++ {
+	}
+}
+
+func (p *bibTeXParser) readWord() string {
+	start := p.pos
+	for ; !p.eof(); p.pos++ {
+		if r := p.peek(); !unicode.IsLetter(r) && !unicode.IsDigit(r) && -1 == strings.IndexRune("_-:.+/", r) {
+			break
+		}
+	}
+	return string(p.data[start:p.pos])
+}
+
+// skipBalanced 跳过直到和已经读取的开括号配对的闭括号 closer。
+func (p *bibTeXParser) skipBalanced(closer rune) error {
+	opener := '{'
+	if ')' == closer {
+		opener = '('
+	}
+	for depth := 1; !p.eof(); p.pos++ {
+		if r := p.peek(); opener == r {
+			depth++
+		} else if closer == r {
+			if depth--; 0 == depth {
+				p.pos++
+				return nil
+			}
+		}
+	}
+	return p.error("unexpected end of input")
+}
+
+// readFields 读取 name = value 形式的字段直到条目结束符 closer，返回的字段名为小写。
+func (p *bibTeXParser) readFields(closer rune) (ret map[string]string, err error) {
+	ret = map[string]string{}
+	for {
+		p.skipSpace()
+		if p.eof() {
+			return nil, p.error("unexpected end of input")
+		}
+		if closer == p.peek() {
+			p.pos++
+			return
+		}
+		if ',' == p.peek() {
+			p.pos++
+			continue
+		}
+
+		name := strings.ToLower(p.readWord())
+		if "" == name {
+			return nil, p.error("expected field name")
+		}
+		p.skipSpace()
+		if p.eof() || '=' != p.peek() {
+			return nil, p.error("expected = after field [" + name + "]")
+		}
+		p.pos++
+		value, err := p.readValue()
+		if nil != err {
+			return nil, err
+		}
+		ret[name] = value
+	}
+}
+
+// readValue 读取字段值，支持 {value}、"value"、数字、宏以及使用 # 连接的多个值。
+func (p *bibTeXParser) readValue() (ret string, err error) {
+	for {
+		p.skipSpace()
+		if p.eof() {
+			return "", p.error("unexpected end of input")
+		}
+
+		switch r := p.peek(); {
+		case '{' == r:
+			p.pos++
+			start := p.pos
+			if err = p.skipBalanced('}'); nil != err {
+				return
+			}
+			ret += string(p.data[start : p.pos-1])
+		case '"' == r:
+			p.pos++
+			start := p.pos
+			for depth := 0; !p.eof() && ('"' != p.peek() || 0 < depth); p.pos++ {
+				if '{' == p.peek() {
+					depth++
+				} else if '}' == p.peek() {
+					depth--
+				}
+			}
+			if p.eof() {
+				return "", p.error("unexpected end of input")
+			}
+			ret += string(p.data[start:p.pos])
+			p.pos++
+		default:
+			word := p.readWord()
+			if "" == word {
+				return "", p.error("expected field value")
+			}
+			if macro, ok := p.macros[strings.ToLower(word)]; ok {
+				word = macro
+			}
+			ret += word
+		}
+
+		p.skipSpace()
+		if p.eof() || '#' != p.peek() {
+			return
+		}
+		p.pos++
+	}
+}
+
+func newBibTeXEntry(key, typ string, fields map[string]string) *BibEntry {
+	ret := &BibEntry{Key: key, Type: typ, Title: bibTeXText(fields["title"]), Year: bibTeXText(fields["year"]),
+		Pages: strings.ReplaceAll(bibTeXText(fields["pages"]), "--", "–"), URL: bibTeXText(fields["url"]), DOI: bibTeXText(fields["doi"])}
+	if "" == ret.Year && 4 <= len(fields["date"]) {
+		ret.Year = fields["date"][:4]
+	}
+	for _, name := range []string{"journal", "journaltitle", "booktitle"} {
+		if "" == ret.Container {
+			ret.Container = bibTeXText(fields[name])
+		}
+	}
+	for _, name := range []string{"publisher", "institution", "school", "organization"} {
+		if "" == ret.Publisher {
+			ret.Publisher = bibTeXText(fields[name])
+		}
+	}
+
+	authors := fields["author"]
+	if "" == authors {
+		authors = fields["editor"]
+	}
+	for _, name := range splitBibTeXNames(authors) {
+		ret.Authors = append(ret.Authors, newBibTeXName(name))
+	}
+	return ret
+}
+
+// splitBibTeXNames 按照不在花括号中的 and 拆分作者列表。
+func splitBibTeXNames(names string) (ret []string) {
+	names = strings.Join(strings.Fields(names), " ")
+	depth, start := 0, 0
+	for i := 0; i < len(names); i++ {
+		switch names[i] {
+		case '{':
+			depth++
+		case '}':
+			depth--
+		default:
+			if 0 == depth && strings.HasPrefix(names[i:], " and ") {
+				ret = append(ret, strings.TrimSpace(names[start:i]))
+				start = i + len(" and ")
+				i = start - 1
+			}
+		}
+	}
+	if last := strings.TrimSpace(names[start:]); "" != last {
+		ret = append(ret, last)
+	}
+	return
+}
+
+// newBibTeXName 解析 Family, Given、Given Family 或者使用花括号包裹的完整名称 {Name}。
+func newBibTeXName(name string) *BibName {
+	if strings.HasPrefix(name, "{") && strings.HasSuffix(name, "}") && -1 == strings.IndexAny(name[1:len(name)-1], "{}") {
+		return &BibName{Literal: bibTeXText(name)}
+	}
+	if i := strings.IndexByte(name, ','); -1 < i {
+		return &BibName{Family: bibTeXText(name[:i]), Given: bibTeXText(name[i+1:])}
+	}
+	name = bibTeXText(name)
+	if i := strings.LastIndexByte(name, ' '); -1 < i {
+		return &BibName{Family: name[i+1:], Given: name[:i]}
+	}
+	return &BibName{Family: name}
+}
+
+// bibTeXText 去掉字段值中的花括号和常用转义，并将连续的空白合并为一个空格。
+func bibTeXText(value string) string {
+	value = strings.NewReplacer("{", "", "}", "", `\&`, "&", `\%`, "%", `\_`, "_", `\$`, "$", `\#`, "#", "~", " ").Replace(value)
+	return strings.Join(strings.Fields(value), " ")
+}
+
+// ParseCSLJSON 解析 CSL-JSON 格式的参考文献库。
+func ParseCSLJSON(data []byte) (ret Bibliography, err error) {
+	var items []struct {
+		ID     string `json:"id"`
+		Type   string `json:"type"`
+		Title  string `json:"title"`
+		Author []struct {
+			Family  string `json:"family"`
+			Given   string `json:"given"`
+			Literal string `json:"literal"`
+		} `json:"author"`
+		Issued struct {
+			DateParts [][]json.RawMessage `json:"date-parts"`
+			Literal   string              `json:"literal"`
+		} `json:"issued"`
+		ContainerTitle string `json:"container-title"`
+		Publisher      string `json:"publisher"`
+		Page           string `json:"page"`
+		URL            string `json:"URL"`
+		DOI            string `json:"DOI"`
+	}
+	if err = json.Unmarshal(data, &items); nil != err {
+		return nil, errors.New("csl-json: " + err.Error())
+	}
+
+	ret = Bibliography{}
+	for _, item := range items {
+		if "" == item.ID {
+			return nil, errors.New("csl-json: item without id")
+		}
+		entry := &BibEntry{Key: item.ID, Type: item.Type, Title: item.Title, Container: item.ContainerTitle,
+			Publisher: item.Publisher, Pages: item.Page, URL: item.URL, DOI: item.DOI, Year: item.Issued.Literal}
+		if parts := item.Issued.DateParts; 0 < len(parts) && 0 < len(parts[0]) {
+			entry.Year = strings.Trim(string(parts[0][0]), `"`)
+		}
+		for _, author := range item.Author {
+			entry.Authors = append(entry.Authors, &BibName{Family: author.Family, Given: author.Given, Literal: author.Literal})
+		}
+		ret[item.ID] = entry
+	}
+	return
+}
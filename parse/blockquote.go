@@ -11,8 +11,12 @@
 package parse
 
 import (
+	"bytes"
+	"strings"
+
 	"lute/ast"
 	"lute/lex"
+	"lute/util"
 )
 
 func BlockquoteContinue(blockquote *ast.Node, context *Context) int {
@@ -27,3 +31,66 @@ func BlockquoteContinue(blockquote *ast.Node, context *Context) int {
 	}
 	return 1
 }
+
+// GitHubAlertTitles 定义了 GitHub 提示类型对应的标题。
+var GitHubAlertTitles = map[string]string{
+	"note":      "Note",
+	"tip":       "Tip",
+	"important": "Important",
+	"warning":   "Warning",
+	"caution":   "Caution",
+}
+
+// blockquoteFinalize 判断块引用首行是否是 GitHub 提示标记符 [!NOTE]，是的话将块引用转换为提示块。
+func (context *Context) blockquoteFinalize(blockquote *ast.Node) {
+	if !context.Option.GitHubAlert {
+		return
+	}
+
+	blockquoteMarker := blockquote.FirstChild
+	if nil == blockquoteMarker || ast.NodeBlockquoteMarker != blockquoteMarker.Type {
+		return
+	}
+	paragraph := blockquoteMarker.Next
+	if nil == paragraph || ast.NodeParagraph != paragraph.Type {
+		return
+	}
+
+	line, remains := paragraph.Tokens, []byte(nil)
+	if i := bytes.IndexByte(line, lex.ItemNewline); -1 < i {
+		line, remains = line[:i], line[i+1:]
+	}
+	line = lex.TrimWhitespace(line)
+	alertType := gitHubAlertType(line)
+	if "" == alertType {
+		return
+	}
+
+	blockquote.Type = ast.NodeGitHubAlert
+	blockquote.GitHubAlertType = alertType
+	marker := &ast.Node{Type: ast.NodeGitHubAlertMarker, Tokens: line, GitHubAlertType: alertType, Close: true}
+	col := context.startCol(paragraph)
+	context.setStart(marker, paragraph.StartLn, col)
+	context.setEnd(marker, paragraph.StartLn, col+len(line))
+	blockquoteMarker.InsertAfter(marker)
+
+	if 1 > len(lex.TrimWhitespace(remains)) {
+		paragraph.Unlink()
+		return
+	}
+	paragraph.Tokens = remains
+	context.skipLines(paragraph)
+}
+
+// gitHubAlertType 返回 GitHub 提示标记符 [!NOTE] 的类型（小写），不是提示标记符的话返回 ""。
+func gitHubAlertType(marker []byte) string {
+	if 4 > len(marker) || !bytes.HasPrefix(marker, []byte("[!")) || lex.ItemCloseBracket != marker[len(marker)-1] {
+		return ""
+	}
+
+	alertType := strings.ToLower(util.BytesToStr(marker[2 : len(marker)-1]))
+	if _, ok := GitHubAlertTitles[alertType]; !ok {
+		return ""
+	}
+	return alertType
+}
@@ -24,6 +24,7 @@ func (t *Tree) parseBlocks() {
 	t.Context.Tip = t.Root
 	t.Context.LinkRefDefs = map[string]*ast.Node{}
 	t.Context.FootnotesDefs = []*ast.Node{}
+	t.Context.Abbreviations = map[string]string{}
 	lines := 0
 	for line := t.lexer.NextLine(); nil != line; line = t.lexer.NextLine() {
 		if t.Context.Option.VditorWYSIWYG {
@@ -34,9 +35,11 @@ func (t *Tree) parseBlocks() {
 			}
 		}
 
+		t.addSourceLine(line)
 		t.incorporateLine(line)
 		lines++
 	}
+	t.Context.setStart(t.Root, 1, 0)
 	for nil != t.Context.Tip {
 		t.Context.finalize(t.Context.Tip, lines)
 	}
@@ -100,6 +103,7 @@ func (t *Tree) incorporateLine(line []byte) {
 			lex.ItemUnderscore != maybeMarker && lex.ItemEqual != maybeMarker && // Setext 标题
 			lex.ItemDollar != maybeMarker && // 数学公式
 			lex.ItemOpenBracket != maybeMarker && // 脚注
+			lex.ItemColon != maybeMarker && // 定义列表、自定义容器
 			226 != maybeMarker { // Vditor 所见即所得
 			t.Context.advanceNextNonspace()
 			break
@@ -149,6 +153,7 @@ func (t *Tree) incorporateLine(line []byte) {
 				typ == ast.NodeBlockquote || // 块引用行肯定不会是空行因为至少有一个 >
 				(typ == ast.NodeCodeBlock && isFenced) || // 围栏代码块不计入空行判断
 				(typ == ast.NodeMathBlock) || // 数学公式块不计入空行判断
+				(typ == ast.NodeFrontMatter) || // 前置元数据不计入空行判断
 				(typ == ast.NodeListItem && nil == container.FirstChild)) // 内容为空的列表项也不计入空行判断
 		// 因为列表是块级容器（可进行嵌套），所以需要在父节点方向上传播 LastLineBlank
 		// LastLineBlank 目前仅在判断列表紧凑模式上使用
@@ -170,7 +175,7 @@ func (t *Tree) incorporateLine(line []byte) {
 			}
 		} else if t.Context.offset < t.Context.currentLineLen && !t.Context.blank {
 			// 普通段落开始
-			t.Context.addChild(ast.NodeParagraph, t.Context.offset)
+			t.Context.addChild(ast.NodeParagraph, t.Context.nextNonspace)
 			t.Context.advanceNextNonspace()
 			t.addLine()
 		}
@@ -186,6 +191,22 @@ type blockStartFunc func(t *Tree, container *ast.Node) int
 // 2：匹配到叶子块
 var blockStarts = []blockStartFunc{
 
+	// 判断前置元数据（--- 或者 +++）是否开始
+	func(t *Tree, container *ast.Node) int {
+		if ast.NodeDocument != container.Type {
+			return 0
+		}
+
+		if marker := t.parseFrontMatter(); nil != marker {
+			t.Context.closeUnmatchedBlocks()
+			block := t.Context.addChild(ast.NodeFrontMatter, t.Context.nextNonspace)
+			block.FrontMatterMarker = marker
+			t.Context.offset = t.Context.currentLineLen // 标记符行不计入内容
+			return 2
+		}
+		return 0
+	},
+
 	// 判断脚注定义（[^label]）是否开始
 	func(t *Tree, container *ast.Node) int {
 		if !t.Context.Option.Footnotes {
@@ -271,7 +292,7 @@ var blockStarts = []blockStartFunc{
 	// 判断 ATX 标题（#）是否开始
 	func(t *Tree, container *ast.Node) int {
 		if !t.Context.indented {
-			if ok, markers, content, level, id := t.parseATXHeading(); ok {
+			if ok, markers, content, level, id, attrs := t.parseATXHeading(); ok {
 				t.Context.advanceNextNonspace()
 				t.Context.advanceOffset(len(content), false)
 				t.Context.closeUnmatchedBlocks()
@@ -279,6 +300,7 @@ var blockStarts = []blockStartFunc{
 				heading.HeadingLevel = level
 				heading.Tokens = content
 				heading.HeadingID = id
+				heading.Attributes = attrs
 				crosshatchMarker := &ast.Node{Type: ast.NodeHeadingC8hMarker, Tokens: markers}
 				heading.AppendChild(crosshatchMarker)
 				t.Context.advanceOffset(t.Context.currentLineLen-t.Context.offset, false)
@@ -300,6 +322,9 @@ var blockStarts = []blockStartFunc{
 				container.CodeBlockFenceOffset = codeBlockFenceOffset
 				container.CodeBlockOpenFence = codeBlockOpenFence
 				container.CodeBlockInfo = codeBlockInfo
+				if t.Context.Option.AttributeList {
+					container.Attributes, container.CodeBlockInfo = parseTrailingAttributeList(codeBlockInfo)
+				}
 				t.Context.advanceNextNonspace()
 				t.Context.advanceOffset(codeBlockFenceLen, false)
 				return 2
@@ -330,6 +355,9 @@ var blockStarts = []blockStartFunc{
 						// 将该段落节点转成表节点
 						container.Type = ast.NodeTable
 						container.TableAligns = table.TableAligns
+						if nil != table.Attributes {
+							container.Attributes = table.Attributes
+						}
 						for tr := table.FirstChild; nil != tr; {
 							nextTr := tr.Next
 							container.AppendChild(tr)
@@ -353,6 +381,13 @@ var blockStarts = []blockStartFunc{
 				if value := container.Tokens; 0 < len(value) {
 					child := &ast.Node{Type: ast.NodeHeading, HeadingLevel: level, HeadingSetext: true}
 					child.Tokens = lex.TrimWhitespace(value)
+					copyStart(child, container)
+					t.Context.moveConsumedLines(child, container)
+					if t.Context.Option.AttributeList {
+						if child.Attributes, child.Tokens = parseTrailingAttributeList(child.Tokens); "" != child.Attributes["id"] {
+							child.HeadingID = []byte(child.Attributes["id"])
+						}
+					}
 					container.InsertAfter(child)
 					container.Unlink()
 					t.Context.Tip = child
@@ -440,6 +475,63 @@ var blockStarts = []blockStartFunc{
 		return 0
 	},
 
+	// 判断自定义容器（:::）是否开始
+	func(t *Tree, container *ast.Node) int {
+		if !t.Context.Option.CustomContainer || t.Context.indented {
+			return 0
+		}
+
+		if ok, fenceLen, name, args := t.parseCustomContainer(); ok {
+			t.Context.closeUnmatchedBlocks()
+			customContainer := t.Context.addChild(ast.NodeCustomContainer, t.Context.nextNonspace)
+			customContainer.CustomContainerFenceLen = fenceLen
+			customContainer.CustomContainerName = string(name)
+			customContainer.CustomContainerArgs = string(args)
+			t.Context.advanceOffset(t.Context.currentLineLen-t.Context.offset, false)
+			return 2
+		}
+		return 0
+	},
+
+	// 判断定义描述（: ）是否开始
+	func(t *Tree, container *ast.Node) int {
+		if !t.Context.Option.DefinitionList || t.Context.indented {
+			return 0
+		}
+
+		var list, paragraph *ast.Node
+		loose := false // 描述前面是空行的话为松散模式
+		if ast.NodeParagraph == container.Type {
+			paragraph = container
+		} else if ast.NodeDefinitionList == container.Type {
+			list = container
+			loose = nil != list.LastChild && list.LastChild.LastLineBlank
+		} else if last := container.LastChild; nil != last && ast.NodeParagraph == last.Type && last.LastLineBlank {
+			paragraph = last
+			loose = true
+		} else {
+			return 0
+		}
+		if nil != paragraph && !t.Context.isDefinitionTerms(paragraph) {
+			return 0
+		}
+
+		data := t.parseDefinitionMarker()
+		if nil == data {
+			return 0
+		}
+		data.Tight = !loose
+
+		t.Context.closeUnmatchedBlocks()
+		if nil != paragraph {
+			t.Context.definitionTerms(paragraph)
+		}
+		description := t.Context.addChild(ast.NodeDefinitionDescription, t.Context.nextNonspace)
+		description.ListData = data
+		description.Tokens = data.Marker
+		return 1
+	},
+
 	// 判断缩进代码块（    code）是否开始
 	func(t *Tree, container *ast.Node) int {
 		if t.Context.indented && t.Context.Tip.Type != ast.NodeParagraph && !t.Context.blank {
@@ -461,6 +553,7 @@ func (t *Tree) addLine() {
 		charsToTab := 4 - (t.Context.column % 4)
 		t.Context.Tip.AppendTokens(bytes.Repeat(util.StrToBytes(" "), charsToTab))
 	}
+	t.Context.addConsumedLine(t.Context.Tip, t.Context.currentLine[t.Context.offset:], t.Context.lineNum, t.Context.offset)
 	t.Context.Tip.AppendTokens(t.Context.currentLine[t.Context.offset:])
 }
 
@@ -480,8 +573,14 @@ func _continue(n *ast.Node, context *Context) int {
 		return BlockquoteContinue(n, context)
 	case ast.NodeMathBlock:
 		return MathBlockContinue(n, context)
+	case ast.NodeFrontMatter:
+		return FrontMatterContinue(n, context)
 	case ast.NodeFootnotesDef:
 		return FootnotesContinue(n, context)
+	case ast.NodeDefinitionDescription:
+		return DefinitionDescriptionContinue(n, context)
+	case ast.NodeCustomContainer:
+		return CustomContainerContinue(n, context)
 	case ast.NodeHeading, ast.NodeThematicBreak:
 		return 1
 	}
@@ -0,0 +1,178 @@
+// Lute - 一款对中文语境优化的 Markdown 引擎，支持 Go 和 JavaScript
+// Copyright (c) 2019-present, b3log.org
+//
+// Lute is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//         http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND, EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT, MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+package parse
+
+import (
+	"bytes"
+	"strconv"
+	"strings"
+
+	"lute/ast"
+	"lute/lex"
+	"lute/util"
+)
+
+// 自动编号项类型
+const (
+	NumberedTable  = "table"  // 表
+	NumberedFigure = "figure" // 图
+)
+
+// NumberedItem 描述了文档中一个自动编号的表或者图。
+type NumberedItem struct {
+	Type    string    // 类型，NumberedTable 或者 NumberedFigure
+	Number  int       // 编号，从 1 开始
+	Label   string    // 编号标签，比如 "Table 3" 或者 "图 2"
+	Caption string    // 标题文本
+	ID      string    // 锚点 id
+	Node    *ast.Node // 表节点或者图片节点
+}
+
+// tableCaptionPrefixes 定义了表标题行的前缀。
+var tableCaptionPrefixes = [][]byte{[]byte("Table:"), []byte("table:")}
+
+// tableCaption 判断 tokens 是否是表标题，是的话返回去掉 Table: 前缀后的标题内容，否则返回 nil。
+func tableCaption(tokens []byte) []byte {
+	tokens = lex.TrimWhitespace(tokens)
+	for _, prefix := range tableCaptionPrefixes {
+		if bytes.HasPrefix(tokens, prefix) {
+			if caption := lex.TrimWhitespace(tokens[len(prefix):]); 0 < len(caption) {
+				return caption
+			}
+		}
+	}
+	return nil
+}
+
+// tableCaptions 将紧挨着表的 Table: 段落转换为表标题。优先作为前一个表的标题，前一个表已经有标题时作为后一个表的标题。
+func (t *Tree) tableCaptions() {
+	var paragraphs []*ast.Node
+	ast.Walk(t.Root, func(n *ast.Node, entering bool) ast.WalkStatus {
+		if entering && ast.NodeParagraph == n.Type && nil != tableCaption(n.Tokens) {
+			paragraphs = append(paragraphs, n)
+		}
+		return ast.WalkContinue
+	})
+
+	for _, p := range paragraphs {
+		table, before := p.Previous, false
+		if nil == table || ast.NodeTable != table.Type || nil != table.ChildByType(ast.NodeTableCaption) {
+			table, before = p.Next, true
+			if nil == table || ast.NodeTable != table.Type || nil != table.ChildByType(ast.NodeTableCaption) {
+				continue
+			}
+		}
+
+		p.Unlink()
+		p.Type = ast.NodeTableCaption
+		p.Tokens = tableCaption(p.Tokens)
+		p.TableCaptionBefore = before
+		table.PrependChild(p)
+		t.Context.tableCaptionLabel(table, p)
+	}
+}
+
+// numberCaptions 按照在文档中出现的顺序为带标题的表和带标题的独立图片编号，打开交叉引用时带标签的表和图片也会编号。
+func (t *Tree) numberCaptions() {
+	option := t.Context.Option
+	tables, figures := 0, 0
+	ast.Walk(t.Root, func(n *ast.Node, entering bool) ast.WalkStatus {
+		if !entering {
+			return ast.WalkContinue
+		}
+
+		switch n.Type {
+		case ast.NodeTable:
+			if nil != n.ChildByType(ast.NodeTableCaption) && (option.CaptionNumbering || t.isCrossRefTarget(n)) {
+				tables++
+				n.CaptionNumber = tables
+			}
+		case ast.NodeImage:
+			if title := n.ChildByType(ast.NodeLinkTitle); (option.CaptionNumbering && nil != title && 0 < len(title.Tokens) && isFigure(n)) || t.isCrossRefTarget(n) {
+				figures++
+				n.CaptionNumber = figures
+			}
+		}
+		return ast.WalkContinue
+	})
+}
+
+// isFigure 判断图片 image 是否独立成段，即所在段落中除了空白以外只有这张图片，行内图片不作为图编号。
+func isFigure(image *ast.Node) bool {
+	paragraph := image.Parent
+	if nil == paragraph || ast.NodeParagraph != paragraph.Type {
+		return false
+	}
+	for n := paragraph.FirstChild; nil != n; n = n.Next {
+		if n != image && (ast.NodeText != n.Type || 0 < len(lex.TrimWhitespace(n.Tokens))) {
+			return false
+		}
+	}
+	return true
+}
+
+// isCrossRefTarget 判断节点 n 是否定义了可以被交叉引用的标签。
+func (t *Tree) isCrossRefTarget(n *ast.Node) bool {
+	if !t.Context.Option.CrossRef {
+		return false
+	}
+	prefix := crossRefPrefix(crossRefLabel(n))
+	return "" != prefix && crossRefTypes[prefix] == n.Type
+}
+
+// CaptionLabel 返回已编号的表或者图片节点 node 的编号标签，比如 "Table 3"。
+func (context *Context) CaptionLabel(node *ast.Node) string {
+	prefix := context.Option.FigureNumberPrefix
+	if ast.NodeTable == node.Type {
+		prefix = context.Option.TableNumberPrefix
+	}
+	return prefix + strconv.Itoa(node.CaptionNumber)
+}
+
+// CaptionID 返回已编号的表或者图片节点 node 的锚点 id，比如 "table-3"，定义了标签时使用标签。
+func (context *Context) CaptionID(node *ast.Node) string {
+	if id := node.Attributes["id"]; "" != id {
+		return id
+	}
+	if ast.NodeTable == node.Type {
+		return NumberedTable + "-" + strconv.Itoa(node.CaptionNumber)
+	}
+	return NumberedFigure + "-" + strconv.Itoa(node.CaptionNumber)
+}
+
+// NumberedTables 返回树上所有已编号的表，按照编号排列。
+func (t *Tree) NumberedTables() []*NumberedItem {
+	return t.numberedItems(ast.NodeTable)
+}
+
+// NumberedFigures 返回树上所有已编号的图，按照编号排列。
+func (t *Tree) NumberedFigures() []*NumberedItem {
+	return t.numberedItems(ast.NodeImage)
+}
+
+func (t *Tree) numberedItems(nodeType ast.NodeType) (ret []*NumberedItem) {
+	ast.Walk(t.Root, func(n *ast.Node, entering bool) ast.WalkStatus {
+		if !entering || nodeType != n.Type || 1 > n.CaptionNumber {
+			return ast.WalkContinue
+		}
+
+		item := &NumberedItem{Type: NumberedFigure, Number: n.CaptionNumber, Label: t.Context.CaptionLabel(n), ID: t.Context.CaptionID(n), Node: n}
+		if ast.NodeTable == n.Type {
+			item.Type = NumberedTable
+			item.Caption = strings.TrimSpace(n.ChildByType(ast.NodeTableCaption).Text())
+		} else if title := n.ChildByType(ast.NodeLinkTitle); nil != title {
+			item.Caption = util.BytesToStr(title.Tokens)
+		}
+		ret = append(ret, item)
+		return ast.WalkContinue
+	})
+	return
+}
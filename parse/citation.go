@@ -0,0 +1,221 @@
+// Lute - 一款对中文语境优化的 Markdown 引擎，支持 Go 和 JavaScript
+// Copyright (c) 2019-present, b3log.org
+//
+// Lute is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//         http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND, EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT, MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+package parse
+
+import (
+	"bytes"
+	"sort"
+	"strings"
+
+	"lute/ast"
+	"lute/lex"
+)
+
+// 文献引用样式
+const (
+	CitationStyleAuthorYear = "author-year" // 作者-年份，比如 (Smith 2020, p. 33)
+	CitationStyleNumeric    = "numeric"     // 编号，比如 [1, p. 33]
+)
+
+// parseCitations 将 node 下文本节点中的 [@smith2020, p. 33] 和 @smith2020 替换为文献引用节点。
+func (t *Tree) parseCitations(node *ast.Node) {
+	for child := node.FirstChild; nil != child; {
+		next := child.Next
+		if ast.NodeText == child.Type {
+			previous := child.Previous
+			t.parseCitations0(child)
+			t.Context.repositionSplit(child, node, previous, next)
+			if 1 > len(child.Tokens) {
+				child.Unlink()
+			}
+		} else if ast.NodeCitation != child.Type && ast.NodeCrossRef != child.Type {
+			t.parseCitations(child) // 递归处理子节点
+		}
+		child = next
+	}
+}
+
+func (t *Tree) parseCitations0(node *ast.Node) {
+	tokens := node.Tokens
+	length := len(tokens)
+	current := node
+	pos := 0
+	for i := 0; i < length; {
+		var citation *ast.Node
+		n := 0
+		if lex.ItemOpenBracket == tokens[i] {
+			var items []*ast.CitationItem
+			if items, n = matchCitationGroup(tokens[i:]); 0 < n {
+				citation = &ast.Node{Type: ast.NodeCitation, CitationItems: items}
+			}
+		} else if '@' == tokens[i] && (0 == i || isReferenceBoundaryBefore(tokens[i-1])) {
+			key := matchCitationKey(tokens[i+1:])
+			// 参考文献库中不存在的 @key 在打开提及时作为提及处理
+			if _, ok := t.Context.Option.Bibliography[key]; "" != key && (ok || !t.Context.Option.Mention) {
+				n = 1 + len(key)
+				citation = &ast.Node{Type: ast.NodeCitation, CitationItems: []*ast.CitationItem{{Key: key}}, CitationInText: true}
+			}
+		}
+		if nil == citation {
+			i++
+			continue
+		}
+
+		if current == node {
+			node.Tokens = tokens[pos:i]
+		} else if pos < i {
+			text := &ast.Node{Type: ast.NodeText, Tokens: tokens[pos:i]}
+			current.InsertAfter(text)
+			current = text
+		}
+		citation.Tokens = tokens[i : i+n]
+		current.InsertAfter(citation)
+		current = citation
+		i += n
+		pos = i
+	}
+	if current != node && pos < length {
+		current.InsertAfter(&ast.Node{Type: ast.NodeText, Tokens: tokens[pos:]})
+	}
+}
+
+// matchCitationGroup 匹配 tokens 开头的 [see @smith2020, p. 33; -@doe2019]，多个条目使用 ; 分隔，每个条目都需要包含文献键。
+// 返回引用条目和匹配的长度，不是文献引用时长度为 0。
+func matchCitationGroup(tokens []byte) (items []*ast.CitationItem, n int) {
+	end := bytes.IndexByte(tokens, lex.ItemCloseBracket)
+	if 2 > end || -1 < bytes.IndexByte(tokens[1:end], lex.ItemOpenBracket) {
+		return nil, 0
+	}
+
+	for _, part := range bytes.Split(tokens[1:end], []byte{';'}) {
+		at := -1
+		for i, token := range part {
+			if '@' == token && (0 == i || lex.IsWhitespace(part[i-1]) || ('-' == part[i-1] && (1 == i || lex.IsWhitespace(part[i-2])))) {
+				at = i
+				break
+			}
+		}
+		if 0 > at {
+			return nil, 0
+		}
+		key := matchCitationKey(part[at+1:])
+		if "" == key {
+			return nil, 0
+		}
+
+		item := &ast.CitationItem{Key: key, Suffix: string(lex.TrimWhitespace(part[at+1+len(key):]))}
+		prefix := part[:at]
+		if 0 < at && '-' == part[at-1] {
+			item.SuppressAuthor = true
+			prefix = part[:at-1]
+		}
+		item.Prefix = string(lex.TrimWhitespace(prefix))
+		items = append(items, item)
+	}
+	return items, end + 1
+}
+
+// matchCitationKey 匹配 tokens 开头的文献键。文献键以字母、数字或者 _ 开头，可以包含字母、数字、_ 以及夹在其中的 :.#$%&-+?<>~/ 标点。
+func matchCitationKey(tokens []byte) string {
+	isKeyChar := func(token byte) bool {
+		return lex.IsASCIILetterNum(token) || '_' == token
+	}
+	if 1 > len(tokens) || !isKeyChar(tokens[0]) {
+		return ""
+	}
+
+	i := 1
+	for i < len(tokens) {
+		if isKeyChar(tokens[i]) {
+			i++
+		} else if -1 < strings.IndexByte(":.#$%&-+?<>~/", tokens[i]) && i+1 < len(tokens) && isKeyChar(tokens[i+1]) {
+			i += 2
+		} else {
+			break
+		}
+	}
+	return string(tokens[:i])
+}
+
+// resolveCitations 按照首次引用的顺序收集引用过的文献，引用了参考文献库中不存在的文献时记录诊断信息。
+func (t *Tree) resolveCitations() {
+	context := t.Context
+	context.Citations = nil
+	cited := map[string]bool{}
+	t.walkWithInlineFootnotes(func(n *ast.Node, entering bool) ast.WalkStatus {
+		if !entering || ast.NodeCitation != n.Type {
+			return ast.WalkContinue
+		}
+		for _, item := range n.CitationItems {
+			if _, ok := context.Option.Bibliography[item.Key]; !ok {
+				context.diagnose(n, "undefined citation key ["+item.Key+"]")
+				continue
+			}
+			if !cited[item.Key] {
+				cited[item.Key] = true
+				context.Citations = append(context.Citations, item.Key)
+			}
+		}
+		return ast.WalkContinue
+	})
+}
+
+// CitationNumber 返回文献键 key 按照首次引用的顺序的编号，从 1 开始，没有引用过时返回 0。
+func (context *Context) CitationNumber(key string) int {
+	for i, cited := range context.Citations {
+		if key == cited {
+			return i + 1
+		}
+	}
+	return 0
+}
+
+// CitedEntries 返回引用过的文献，作者-年份样式按照作者、年份和标题排序，编号样式按照编号排序。
+func (context *Context) CitedEntries() (ret []*BibEntry) {
+	for _, key := range context.Citations {
+		ret = append(ret, context.Option.Bibliography[key])
+	}
+	if CitationStyleNumeric != context.Option.CitationStyle {
+		sort.SliceStable(ret, func(i, j int) bool {
+			return ret[i].sortKey() < ret[j].sortKey()
+		})
+	}
+	return
+}
+
+func (entry *BibEntry) sortKey() string {
+	var names []string
+	for _, author := range entry.Authors {
+		names = append(names, strings.ToLower(author.FamilyName()+" "+author.Given))
+	}
+	return strings.Join(names, ";") + "\x00" + entry.Year + "\x00" + strings.ToLower(entry.Title)
+}
+
+// CitationAuthor 返回文献在引用中显示的作者，比如 Smith、Smith and Doe 或者 Smith et al.，没有作者时使用标题。
+func (entry *BibEntry) CitationAuthor() string {
+	switch len(entry.Authors) {
+	case 0:
+		return entry.Title
+	case 1:
+		return entry.Authors[0].FamilyName()
+	case 2:
+		return entry.Authors[0].FamilyName() + " and " + entry.Authors[1].FamilyName()
+	}
+	return entry.Authors[0].FamilyName() + " et al."
+}
+
+// CitationYear 返回文献在引用中显示的年份，没有年份时返回 n.d.。
+func (entry *BibEntry) CitationYear() string {
+	if "" == entry.Year {
+		return "n.d."
+	}
+	return entry.Year
+}
@@ -0,0 +1,276 @@
+// Lute - 一款对中文语境优化的 Markdown 引擎，支持 Go 和 JavaScript
+// Copyright (c) 2019-present, b3log.org
+//
+// Lute is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//         http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND, EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT, MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+package parse
+
+import (
+	"strconv"
+	"strings"
+
+	"lute/ast"
+	"lute/lex"
+)
+
+// 交叉引用标签前缀
+const (
+	CrossRefFigure   = "fig" // 图
+	CrossRefTable    = "tbl" // 表
+	CrossRefSection  = "sec" // 章节
+	CrossRefEquation = "eq"  // 公式
+)
+
+// crossRefTypes 定义了标签前缀对应的节点类型。
+var crossRefTypes = map[string]ast.NodeType{
+	CrossRefFigure:   ast.NodeImage,
+	CrossRefTable:    ast.NodeTable,
+	CrossRefSection:  ast.NodeHeading,
+	CrossRefEquation: ast.NodeMathBlock,
+}
+
+// crossRefPrefix 返回标签 label 的前缀，不是交叉引用标签时返回空字符串。
+func crossRefPrefix(label string) string {
+	if i := strings.IndexByte(label, ':'); 0 < i && i < len(label)-1 {
+		if _, ok := crossRefTypes[label[:i]]; ok {
+			return label[:i]
+		}
+	}
+	return ""
+}
+
+// crossRefLabel 返回节点 n 上定义的标签，没有定义时返回空字符串。
+func crossRefLabel(n *ast.Node) string {
+	switch n.Type {
+	case ast.NodeHeading:
+		if id := n.Attributes["id"]; "" != id {
+			return id
+		}
+		return strings.TrimPrefix(string(n.HeadingID), "#")
+	case ast.NodeImage, ast.NodeTable, ast.NodeMathBlock:
+		return n.Attributes["id"]
+	}
+	return ""
+}
+
+// tableCaptionLabel 解析表标题 caption 末尾的 {#tbl:label}，标签作为表 table 的属性。
+func (context *Context) tableCaptionLabel(table, caption *ast.Node) {
+	if !context.Option.CrossRef {
+		return
+	}
+	if attrs, remains := parseTrailingAttributeList(caption.Tokens); nil != attrs && "" != attrs["id"] && 0 < len(remains) {
+		table.Attributes = attrs
+		caption.Tokens = remains
+	}
+}
+
+// parseCrossRefs 将 node 下文本节点中的 @fig:arch 和 [@sec:intro] 替换为交叉引用节点。
+func (t *Tree) parseCrossRefs(node *ast.Node) {
+	for child := node.FirstChild; nil != child; {
+		next := child.Next
+		if ast.NodeText == child.Type {
+			previous := child.Previous
+			t.parseCrossRefs0(child)
+			t.Context.repositionSplit(child, node, previous, next)
+			if 1 > len(child.Tokens) {
+				child.Unlink()
+			}
+		} else if ast.NodeCrossRef != child.Type {
+			t.parseCrossRefs(child) // 递归处理子节点
+		}
+		child = next
+	}
+}
+
+func (t *Tree) parseCrossRefs0(node *ast.Node) {
+	tokens := node.Tokens
+	length := len(tokens)
+	current := node
+	pos := 0
+	for i := 0; i < length; {
+		if (lex.ItemOpenBracket != tokens[i] && '@' != tokens[i]) || ('@' == tokens[i] && 0 < i && !isReferenceBoundaryBefore(tokens[i-1])) {
+			i++
+			continue
+		}
+
+		label, n := matchCrossRef(tokens[i:])
+		if 1 > n {
+			i++
+			continue
+		}
+
+		if current == node {
+			node.Tokens = tokens[pos:i]
+		} else if pos < i {
+			text := &ast.Node{Type: ast.NodeText, Tokens: tokens[pos:i]}
+			current.InsertAfter(text)
+			current = text
+		}
+		ref := &ast.Node{Type: ast.NodeCrossRef, CrossRefLabel: label, Tokens: tokens[i : i+n]}
+		current.InsertAfter(ref)
+		current = ref
+		i += n
+		pos = i
+	}
+	if current != node && pos < length {
+		current.InsertAfter(&ast.Node{Type: ast.NodeText, Tokens: tokens[pos:]})
+	}
+}
+
+// matchCrossRef 匹配 tokens 开头的 @fig:arch 或者 [@fig:arch]，返回标签和匹配的长度，不是交叉引用时长度为 0。
+func matchCrossRef(tokens []byte) (label string, n int) {
+	length := len(tokens)
+	bracket := lex.ItemOpenBracket == tokens[0]
+	i := 0
+	if bracket {
+		i++
+	}
+	if i >= length || '@' != tokens[i] {
+		return "", 0
+	}
+	i++
+	start := i
+	for ; i < length && isAttributeNameChar(tokens[i]); i++ {
+	}
+	end := i
+	if bracket {
+		if end >= length || lex.ItemCloseBracket != tokens[end] {
+			return "", 0
+		}
+		n = end + 1
+	} else {
+		// 句末的标点不属于标签
+		for ; start < end && ('.' == tokens[end-1] || ':' == tokens[end-1]); end-- {
+		}
+		n = end
+	}
+
+	label = string(tokens[start:end])
+	if "" == crossRefPrefix(label) {
+		return "", 0
+	}
+	return
+}
+
+// resolveCrossRefs 收集标签并解析交叉引用，引用了未定义的标签时记录诊断信息。
+func (t *Tree) resolveCrossRefs() {
+	context := t.Context
+	context.CrossRefs = map[string]*ast.Node{}
+	equations := 0
+	ast.Walk(t.Root, func(n *ast.Node, entering bool) ast.WalkStatus {
+		if !entering {
+			return ast.WalkContinue
+		}
+		label := crossRefLabel(n)
+		prefix := crossRefPrefix(label)
+		if "" == prefix {
+			return ast.WalkContinue
+		}
+		if crossRefTypes[prefix] != n.Type {
+			context.diagnose(n, "label ["+label+"] can not be used on "+strings.TrimPrefix(n.Type.String(), "Node"))
+			return ast.WalkContinue
+		}
+		if _, ok := context.CrossRefs[label]; ok {
+			context.diagnose(n, "duplicate label ["+label+"]")
+			return ast.WalkContinue
+		}
+		context.CrossRefs[label] = n
+		if ast.NodeMathBlock == n.Type {
+			equations++
+			n.CaptionNumber = equations
+		}
+		return ast.WalkContinue
+	})
+
+	resolve := func(n *ast.Node, entering bool) ast.WalkStatus {
+		if !entering || ast.NodeCrossRef != n.Type {
+			return ast.WalkContinue
+		}
+		if target := context.CrossRefs[n.CrossRefLabel]; nil != target {
+			n.CrossRefText = context.CrossRefText(target)
+		} else {
+			context.diagnose(n, "undefined label ["+n.CrossRefLabel+"]")
+		}
+		return ast.WalkContinue
+	}
+	t.walkWithInlineFootnotes(resolve)
+}
+
+// CrossRefText 返回引用节点 target 时显示的文本，比如 "Figure 3" 或者 "Section 2.1"，引用没有编号的标题时返回标题文本。
+func (context *Context) CrossRefText(target *ast.Node) string {
+	switch target.Type {
+	case ast.NodeHeading:
+		if "" == target.HeadingNumber {
+			return strings.TrimSpace(target.Text())
+		}
+		return context.Option.SectionNumberPrefix + target.HeadingNumber
+	case ast.NodeMathBlock:
+		return context.Option.EquationNumberPrefix + strconv.Itoa(target.CaptionNumber)
+	}
+	return context.CaptionLabel(target)
+}
+
+// numberHeadings 按照标题层级为文档中的标题编号，比如 1、1.1、2。
+//
+// 打开 HeadingNumbering 时按照起始层级、最大层数和分隔符编号，否则从文档中最高的标题层级开始使用 . 分隔编号所有层级。
+// 不编号的标题 HeadingNumber 为空，也不计入同级标题的编号。上一级没有编号的标题按上一级计数，比如一级标题 1 下直接出现的三级标题编号为 1.1，之后的二级标题编号为 1.2。
+func (t *Tree) numberHeadings() {
+	option := t.Context.Option
+	startLevel, maxDepth, separator := 0, 6, "."
+	if option.HeadingNumbering {
+		startLevel, maxDepth, separator = option.HeadingNumberingStartLevel, option.HeadingNumberingMaxDepth, option.HeadingNumberingSeparator
+	}
+
+	var headings []*ast.Node
+	minLevel := 6
+	ast.Walk(t.Root, func(n *ast.Node, entering bool) ast.WalkStatus {
+		if entering && ast.NodeHeading == n.Type {
+			headings = append(headings, n)
+			if minLevel > n.HeadingLevel {
+				minLevel = n.HeadingLevel
+			}
+		}
+		return ast.WalkContinue
+	})
+	if 1 > startLevel {
+		startLevel = minLevel
+	}
+
+	var counters [7]int
+	for _, heading := range headings {
+		heading.HeadingNumber = ""
+		level := heading.HeadingLevel
+		if level < startLevel || level >= startLevel+maxDepth || IsUnnumberedHeading(heading) {
+			continue
+		}
+		for level > startLevel && 0 == counters[level-1] {
+			// 上一级没有编号（跳过了层级或者上级标题不编号）时按上一级计数，避免和之后的上一级标题编号重复
+			level--
+		}
+		counters[level]++
+		for l := level + 1; l < len(counters); l++ {
+			counters[l] = 0
+		}
+
+		var numbers []string
+		for l := startLevel; l <= level; l++ {
+			numbers = append(numbers, strconv.Itoa(counters[l]))
+		}
+		heading.HeadingNumber = strings.Join(numbers, separator)
+	}
+}
+
+// IsUnnumberedHeading 判断标题 heading 是否通过属性列表 {.unnumbered} 或者 {-} 设置了不编号。
+func IsUnnumberedHeading(heading *ast.Node) bool {
+	for _, class := range strings.Fields(heading.Attributes["class"]) {
+		if unnumberedClass == class {
+			return true
+		}
+	}
+	return false
+}
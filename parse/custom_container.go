@@ -0,0 +1,67 @@
+// Lute - 一款对中文语境优化的 Markdown 引擎，支持 Go 和 JavaScript
+// Copyright (c) 2019-present, b3log.org
+//
+// Lute is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//         http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND, EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT, MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+package parse
+
+import (
+	"lute/ast"
+	"lute/lex"
+)
+
+func CustomContainerContinue(customContainer *ast.Node, context *Context) int {
+	if tip := context.Tip; (ast.NodeCodeBlock == tip.Type && tip.IsFencedCodeBlock) || ast.NodeMathBlock == tip.Type || ast.NodeHTMLBlock == tip.Type {
+		// 围栏代码块、公式块和 HTML 块内的 ::: 不能闭合容器
+		return 0
+	}
+	if context.indent <= 3 && context.isCustomContainerClose(context.currentLine[context.nextNonspace:], customContainer.CustomContainerFenceLen) {
+		// 先最终化容器内还未闭合的子块
+		for context.Tip != customContainer {
+			context.finalize(context.Tip, context.lineNum-1)
+		}
+		context.finalize(customContainer, context.lineNum)
+		return 2
+	}
+	return 0
+}
+
+// parseCustomContainer 解析自定义容器开始围栏 ::: name args，至少需要 3 个 : 并且必须指定容器名称。
+func (t *Tree) parseCustomContainer() (ok bool, fenceLen int, name, args []byte) {
+	tokens := t.Context.currentLine[t.Context.nextNonspace:]
+	fenceLen = lex.Accept(tokens, lex.ItemColon)
+	if 3 > fenceLen {
+		return
+	}
+
+	info := lex.TrimWhitespace(tokens[fenceLen:])
+	if 1 > len(info) {
+		return
+	}
+	name = info
+	for i, token := range info {
+		if lex.IsWhitespace(token) {
+			name, args = info[:i], lex.TrimWhitespace(info[i:])
+			break
+		}
+	}
+	return true, fenceLen, name, args
+}
+
+// isCustomContainerClose 判断 tokens 是否是长度不小于 fenceLen 的自定义容器结束围栏。
+func (context *Context) isCustomContainerClose(tokens []byte, fenceLen int) bool {
+	if fenceLen > lex.Accept(tokens, lex.ItemColon) {
+		return false
+	}
+	for _, token := range lex.TrimWhitespace(tokens) {
+		if lex.ItemColon != token {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,157 @@
+// Lute - 一款对中文语境优化的 Markdown 引擎，支持 Go 和 JavaScript
+// Copyright (c) 2019-present, b3log.org
+//
+// Lute is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//         http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND, EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT, MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+package parse
+
+import (
+	"bytes"
+
+	"lute/ast"
+	"lute/lex"
+)
+
+func DefinitionDescriptionContinue(description *ast.Node, context *Context) int {
+	if context.blank {
+		if nil == description.FirstChild { // 描述后面是空的
+			return 1
+		}
+
+		context.advanceNextNonspace()
+	} else if context.indent >= description.MarkerOffset+description.Padding {
+		context.advanceOffset(description.MarkerOffset+description.Padding, true)
+	} else {
+		return 1
+	}
+	return 0
+}
+
+// definitionDescriptionFinalize 判断描述的子块之间是否包含空行，包含的话说明该描述是松散的。
+func definitionDescriptionFinalize(description *ast.Node) {
+	for child := description.FirstChild; nil != child && nil != child.Next; child = child.Next {
+		if endsWithBlankLine(child) {
+			description.Tight = false
+			return
+		}
+	}
+}
+
+// parseDefinitionMarker 用于解析定义描述标记符 :，标记符后必须是空白字符并且内容不能为空。
+func (t *Tree) parseDefinitionMarker() *ast.ListData {
+	ln := t.Context.currentLine
+	if lex.ItemColon != lex.Peek(ln, t.Context.nextNonspace) {
+		return nil
+	}
+	if token := lex.Peek(ln, t.Context.nextNonspace+1); lex.ItemSpace != token && lex.ItemTab != token {
+		return nil
+	}
+	if 0 == len(lex.TrimWhitespace(ln[t.Context.nextNonspace+1:])) {
+		return nil
+	}
+
+	data := &ast.ListData{
+		Tight:        true,
+		Marker:       []byte{lex.ItemColon},
+		MarkerOffset: t.Context.indent,
+	}
+
+	t.Context.advanceNextNonspace()
+	t.Context.advanceOffset(1, true)
+	spacesStartCol := t.Context.column
+	spacesStartOffset := t.Context.offset
+	for {
+		t.Context.advanceOffset(1, true)
+		token := lex.Peek(ln, t.Context.offset)
+		if t.Context.column-spacesStartCol >= 5 || (lex.ItemSpace != token && lex.ItemTab != token) {
+			break
+		}
+	}
+	if spacesAfterMarker := t.Context.column - spacesStartCol; spacesAfterMarker >= 5 {
+		data.Padding = 2
+		t.Context.column = spacesStartCol
+		t.Context.offset = spacesStartOffset
+		t.Context.advanceOffset(1, true)
+	} else {
+		data.Padding = 1 + spacesAfterMarker
+	}
+	return data
+}
+
+// isDefinitionTerms 判断段落 paragraph 能否转换为定义术语，只有去掉链接引用定义后不为空的普通段落（不能是表或者目录）才可以。
+func (context *Context) isDefinitionTerms(paragraph *ast.Node) bool {
+	tokens := paragraph.Tokens
+	for 0 < len(tokens) && lex.ItemOpenBracket == tokens[0] {
+		remains := context.parseLinkRefDef(tokens)
+		if nil == remains {
+			break
+		}
+		tokens = remains
+	}
+	if 1 > len(lex.TrimWhitespace(tokens)) {
+		return false
+	}
+
+	plain := &ast.Node{Type: ast.NodeParagraph, Tokens: lex.TrimWhitespace(tokens)}
+	if context.Option.GFMTable {
+		if _, table := context.parseTable(plain); nil != table {
+			return false
+		}
+	}
+	if context.Option.ToC && nil != context.parseToC(plain) {
+		return false
+	}
+	return true
+}
+
+// definitionTerms 将段落 paragraph 的每一行转换为定义术语，并挂到定义列表上返回。
+// 如果段落前面紧接着一个定义列表则将术语合并到该列表中，否则新建一个定义列表替换段落。
+func (context *Context) definitionTerms(paragraph *ast.Node) (list *ast.Node) {
+	if !paragraph.Close {
+		// 解析链接引用定义
+		for tokens := paragraph.Tokens; 0 < len(tokens) && lex.ItemOpenBracket == tokens[0]; tokens = paragraph.Tokens {
+			if remains := context.parseLinkRefDef(tokens); nil != remains {
+				paragraph.Tokens = remains
+			} else {
+				break
+			}
+		}
+	}
+
+	tokens := lex.TrimWhitespace(paragraph.Tokens)
+	if 1 > len(tokens) {
+		return nil
+	}
+
+	if previous := paragraph.Previous; nil != previous && ast.NodeDefinitionList == previous.Type {
+		list = previous
+		list.Close = false
+		paragraph.Unlink()
+	} else {
+		list = &ast.Node{Type: ast.NodeDefinitionList, ListData: &ast.ListData{Tight: true}}
+		copyStart(list, paragraph)
+		paragraph.InsertAfter(list)
+		paragraph.Unlink()
+	}
+
+	lines := bytes.Split(tokens, []byte{lex.ItemNewline})
+	for i, line := range lines {
+		if line = lex.TrimWhitespace(line); 1 > len(line) {
+			continue
+		}
+
+		term := &ast.Node{Type: ast.NodeDefinitionTerm, Tokens: line, Close: true}
+		if ln, col := context.consumedLinePos(paragraph, line, i, len(lines)); 0 < ln {
+			context.setStart(term, ln, col)
+			context.setEnd(term, ln, col+len(line))
+		}
+		list.AppendChild(term)
+	}
+	context.Tip = list
+	return
+}
@@ -11,16 +11,18 @@
 package parse
 
 import (
+	"bytes"
 	"lute/ast"
 	"lute/lex"
+	"strings"
 	"unicode"
 	"unicode/utf8"
 )
 
-// delimiter 描述了强调、链接和图片解析过程中用到的分隔符（[, ![, *, _, ~）相关信息。
+// delimiter 描述了强调、链接和图片解析过程中用到的分隔符（[, ![, *, _, ~, =, ^）相关信息。
 type delimiter struct {
 	node           *ast.Node  // 分隔符对应的文本节点
-	typ            byte       // 分隔符字节 [*_~
+	typ            byte       // 分隔符字节 [*_~=^
 	num            int        // 分隔符字节数
 	originalNum    int        // 原始分隔符字节数
 	canOpen        bool       // 是否是开始分隔符
@@ -36,13 +38,14 @@ type delimiter struct {
 
 // 嵌套强调和链接的解析算法的中文解读可参考这里 https://hacpai.com/article/1566893557720
 
-// handleDelim 将分隔符 *_~ 入栈。
+// handleDelim 将分隔符 *_~=^ 入栈。
 func (t *Tree) handleDelim(block *ast.Node, ctx *InlineContext) {
 	startPos := ctx.pos
 	delim := t.scanDelims(ctx)
 
 	text := ctx.tokens[startPos:ctx.pos]
 	node := &ast.Node{Type: ast.NodeText, Tokens: text}
+	ctx.setPos(node, startPos, ctx.pos)
 	block.AppendChild(node)
 
 	// 将这个分隔符入栈
@@ -63,7 +66,7 @@ func (t *Tree) handleDelim(block *ast.Node, ctx *InlineContext) {
 	}
 }
 
-// processEmphasis 处理强调、加粗以及删除线。
+// processEmphasis 处理强调、加粗、删除线、高亮、上标以及下标。
 func (t *Tree) processEmphasis(stackBottom *delimiter, ctx *InlineContext) {
 	if nil == ctx.delimiters {
 		return
@@ -80,6 +83,8 @@ func (t *Tree) processEmphasis(stackBottom *delimiter, ctx *InlineContext) {
 	openersBottom[lex.ItemUnderscore] = stackBottom
 	openersBottom[lex.ItemAsterisk] = stackBottom
 	openersBottom[lex.ItemTilde] = stackBottom
+	openersBottom[lex.ItemEqual] = stackBottom
+	openersBottom[lex.ItemCaret] = stackBottom
 
 	// find first closer above stack_bottom:
 	closer = ctx.delimiters
@@ -106,6 +111,10 @@ func (t *Tree) processEmphasis(stackBottom *delimiter, ctx *InlineContext) {
 			}
 			opener = opener.previous
 		}
+		if openerFound && t.scriptSpansWhitespace(opener, closer) {
+			// 单个 ^ 或者 ~ 形成的上标、下标中不能包含空白，更早的开始分隔符也会包含这段空白
+			openerFound = false
+		}
 		oldCloser = closer
 
 		if !openerFound {
@@ -121,7 +130,7 @@ func (t *Tree) processEmphasis(stackBottom *delimiter, ctx *InlineContext) {
 			openerInl = opener.node
 			closerInl = closer.node
 
-			if t.Context.Option.GFMStrikethrough && lex.ItemTilde == closercc && opener.num != closer.num {
+			if (t.Context.Option.GFMStrikethrough || t.Context.Option.Sub) && lex.ItemTilde == closercc && opener.num != closer.num {
 				break
 			}
 
@@ -139,6 +148,18 @@ func (t *Tree) processEmphasis(stackBottom *delimiter, ctx *InlineContext) {
 			openMarker := &ast.Node{Tokens: openerTokens, Close: true}
 			emStrongDel := &ast.Node{Close: true}
 			closeMarker := &ast.Node{Tokens: closerTokens, Close: true}
+
+			// 标记符从开始分隔符的结尾和结束分隔符的开头处切分
+			copyStart(openMarker, openerInl)
+			copyEnd(openMarker, openerInl)
+			moveStart(openMarker, len(openerInl.Tokens))
+			moveEnd(openerInl, -useDelims)
+			copyStart(closeMarker, closerInl)
+			copyEnd(closeMarker, closerInl)
+			moveEnd(closeMarker, -len(closerInl.Tokens))
+			moveStart(closerInl, useDelims)
+			copyStart(emStrongDel, openMarker)
+			copyEnd(emStrongDel, closeMarker)
 			if 1 == useDelims {
 				if lex.ItemAsterisk == closercc {
 					emStrongDel.Type = ast.NodeEmphasis
@@ -149,11 +170,19 @@ func (t *Tree) processEmphasis(stackBottom *delimiter, ctx *InlineContext) {
 					openMarker.Type = ast.NodeEmU8eOpenMarker
 					closeMarker.Type = ast.NodeEmU8eCloseMarker
 				} else if lex.ItemTilde == closercc {
-					if t.Context.Option.GFMStrikethrough {
+					if t.Context.Option.Sub {
+						emStrongDel.Type = ast.NodeSub
+						openMarker.Type = ast.NodeSubOpenMarker
+						closeMarker.Type = ast.NodeSubCloseMarker
+					} else if t.Context.Option.GFMStrikethrough {
 						emStrongDel.Type = ast.NodeStrikethrough
 						openMarker.Type = ast.NodeStrikethrough1OpenMarker
 						closeMarker.Type = ast.NodeStrikethrough1CloseMarker
 					}
+				} else if lex.ItemCaret == closercc {
+					emStrongDel.Type = ast.NodeSup
+					openMarker.Type = ast.NodeSupOpenMarker
+					closeMarker.Type = ast.NodeSupCloseMarker
 				}
 			} else {
 				if lex.ItemAsterisk == closercc {
@@ -170,6 +199,10 @@ func (t *Tree) processEmphasis(stackBottom *delimiter, ctx *InlineContext) {
 						openMarker.Type = ast.NodeStrikethrough2OpenMarker
 						closeMarker.Type = ast.NodeStrikethrough2CloseMarker
 					}
+				} else if lex.ItemEqual == closercc {
+					emStrongDel.Type = ast.NodeMark
+					openMarker.Type = ast.NodeMarkOpenMarker
+					closeMarker.Type = ast.NodeMarkCloseMarker
 				}
 			}
 
@@ -265,8 +298,20 @@ func (t *Tree) scanDelims(ctx *InlineContext) *delimiter {
 		}
 	}
 
-	isLeftFlanking := !afterIsWhitespace && (!afterIsPunct || beforeIsWhitespace || beforeIsPunct)
-	isRightFlanking := !beforeIsWhitespace && (!beforeIsPunct || afterIsWhitespace || afterIsPunct)
+	var beforeIsCJK, afterIsCJK bool
+	if t.Context.Option.CJKFriendlyEmphasis && (lex.ItemAsterisk == token || lex.ItemUnderscore == token || lex.ItemTilde == token) {
+		if isVariationSelector(tokenBefore) && 0 < startPos {
+			// 异体字选择符跟在前一个字符后面，需要使用前一个字符判断
+			before := ctx.tokens[:startPos]
+			_, size := utf8.DecodeLastRune(before)
+			tokenBefore, _ = utf8.DecodeLastRune(before[:len(before)-size])
+		}
+		beforeIsCJK = lex.IsCJK(tokenBefore)
+		afterIsCJK = lex.IsCJK(tokenAfter)
+	}
+
+	isLeftFlanking := !afterIsWhitespace && (!afterIsPunct || beforeIsWhitespace || beforeIsPunct || beforeIsCJK)
+	isRightFlanking := !beforeIsWhitespace && (!beforeIsPunct || afterIsWhitespace || afterIsPunct || afterIsCJK)
 	var canOpen, canClose bool
 	if lex.ItemUnderscore == token {
 		canOpen = isLeftFlanking && (!isRightFlanking || beforeIsPunct)
@@ -275,10 +320,68 @@ func (t *Tree) scanDelims(ctx *InlineContext) *delimiter {
 		canOpen = isLeftFlanking
 		canClose = isRightFlanking
 	}
+	if (lex.ItemEqual == token && 2 != delimitersCount) || (lex.ItemCaret == token && 1 != delimitersCount) {
+		// 高亮只能使用 ==，上标只能使用 ^
+		canOpen, canClose = false, false
+	}
+	if lex.ItemEqual == token && t.Context.Option.GFMAutoLink && !t.Context.Option.VditorWYSIWYG && t.inGFMAutoLink(ctx.tokens, startPos) {
+		// 链接中的 == 不作为高亮分隔符，避免把自动链接拆开
+		canOpen, canClose = false, false
+	}
 
 	return &delimiter{typ: token, num: delimitersCount, active: true, canOpen: canOpen, canClose: canClose}
 }
 
+// inGFMAutoLink 判断 tokens 中下标 pos 处是否位于 GFM 自动链接中，按照 parseGFMAutoLink0 的规则识别 pos 所在单词（前后直到空白）中的自动链接。
+func (t *Tree) inGFMAutoLink(tokens []byte, pos int) bool {
+	start := bytes.LastIndexAny(tokens[:pos], " \t\n") + 1
+	end := bytes.IndexAny(tokens[pos:], " \t\n")
+	if 0 > end {
+		end = len(tokens)
+	} else {
+		end += pos
+	}
+
+	// 识别时会修改文本节点及其前一个文本节点，所以复制一份单词，并在前面放一个空文本节点承接链接前面的文本
+	parent := &ast.Node{Type: ast.NodeParagraph}
+	parent.AppendChild(&ast.Node{Type: ast.NodeText})
+	word := &ast.Node{Type: ast.NodeText, Tokens: append([]byte{}, tokens[start:end]...)}
+	parent.AppendChild(word)
+	t.parseGFMAutoLink0(word)
+
+	offset := start
+	for n := parent.FirstChild; nil != n; n = n.Next {
+		width := len(n.Tokens)
+		if ast.NodeLink == n.Type {
+			width = len(n.ChildByType(ast.NodeLinkText).Tokens)
+			if offset <= pos && pos < offset+width {
+				return true
+			}
+		}
+		offset += width
+	}
+	return false
+}
+
+// scriptSpansWhitespace 判断单个 ^ 或者 ~ 构成的上标、下标在开始分隔符 opener 和结束分隔符 closer 之间是否包含空白。
+func (t *Tree) scriptSpansWhitespace(opener, closer *delimiter) bool {
+	if lex.ItemCaret != closer.typ && (lex.ItemTilde != closer.typ || !t.Context.Option.Sub || 1 != opener.num || 1 != closer.num) {
+		return false
+	}
+
+	for n := opener.node.Next; nil != n && n != closer.node; n = n.Next {
+		if ast.NodeSoftBreak == n.Type || ast.NodeHardBreak == n.Type || strings.ContainsAny(n.Text(), " \t\n") {
+			return true
+		}
+	}
+	return false
+}
+
+// isVariationSelector 判断 r 是否是异体字选择符。
+func isVariationSelector(r rune) bool {
+	return (0xFE00 <= r && 0xFE0F >= r) || (0xE0100 <= r && 0xE01EF >= r)
+}
+
 func (t *Tree) removeDelimiter(delim *delimiter, ctx *InlineContext) (ret *delimiter) {
 	if nil != delim.previous {
 		delim.previous.next = delim.next
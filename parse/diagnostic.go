@@ -0,0 +1,39 @@
+// Lute - 一款对中文语境优化的 Markdown 引擎，支持 Go 和 JavaScript
+// Copyright (c) 2019-present, b3log.org
+//
+// Lute is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//         http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND, EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT, MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+package parse
+
+import (
+	"strconv"
+
+	"lute/ast"
+)
+
+// Diagnostic 描述了解析过程中发现的一个问题，比如引用了未定义的标签。
+type Diagnostic struct {
+	Path    string // 问题所在的嵌入文档路径，位于主文档时为空
+	Ln      int    // 问题所在的行号，从 1 开始，未知时为 0
+	Col     int    // 问题所在的列号，从 1 开始，未知时为 0
+	Message string // 问题描述
+}
+
+// String 返回 路径:行:列: 描述 形式的诊断信息，位于主文档时省略路径。
+func (d *Diagnostic) String() string {
+	ret := strconv.Itoa(d.Ln) + ":" + strconv.Itoa(d.Col) + ": " + d.Message
+	if "" != d.Path {
+		ret = d.Path + ":" + ret
+	}
+	return ret
+}
+
+// diagnose 记录一条关于节点 node 的诊断信息。
+func (context *Context) diagnose(node *ast.Node, message string) {
+	context.Diagnostics = append(context.Diagnostics, &Diagnostic{Ln: node.StartLn, Col: node.StartCol, Message: message})
+}
@@ -22,7 +22,9 @@ func (t *Tree) emoji(node *ast.Node) {
 	for child := node.FirstChild; nil != child; {
 		next := child.Next
 		if ast.NodeText == child.Type {
+			previous := child.Previous
 			t.emoji0(child)
+			t.Context.repositionSplit(child, node, previous, next)
 		} else {
 			t.emoji(child) // 递归处理子节点
 		}
@@ -12,7 +12,10 @@ package parse
 
 import (
 	"bytes"
+	"strconv"
+
 	"lute/ast"
+	"lute/lex"
 )
 
 func FootnotesContinue(footnotesDef *ast.Node, context *Context) int {
@@ -28,6 +31,16 @@ func FootnotesContinue(footnotesDef *ast.Node, context *Context) int {
 	return 0
 }
 
+// walkWithInlineFootnotes 遍历语法树以及行内脚注定义，行内脚注定义不在语法树上。
+func (t *Tree) walkWithInlineFootnotes(walker ast.Walker) {
+	ast.Walk(t.Root, walker)
+	for _, def := range t.Context.FootnotesDefs {
+		if nil == def.Parent {
+			ast.Walk(def, walker)
+		}
+	}
+}
+
 func (context *Context) FindFootnotesDef(label []byte) (int, *ast.Node) {
 	for i, n := range context.FootnotesDefs {
 		if bytes.EqualFold(label, n.Tokens) {
@@ -36,3 +49,79 @@ func (context *Context) FindFootnotesDef(label []byte) (int, *ast.Node) {
 	}
 	return -1, nil
 }
+
+// parseInlineFootnote 解析行内脚注 ^[note]，生成自动编号的脚注定义并返回指向它的脚注引用节点。
+func (t *Tree) parseInlineFootnote(ctx *InlineContext) *ast.Node {
+	tokens := ctx.tokens[ctx.pos:]
+	if 3 > len(tokens) || lex.ItemOpenBracket != tokens[1] {
+		return nil
+	}
+
+	end := -1
+	for i, depth := 2, 1; i < len(tokens); i++ {
+		if token := tokens[i]; lex.ItemBackslash == token {
+			i++
+		} else if lex.ItemBacktick == token {
+			// 代码中的方括号不参与匹配
+			i = codeSpanEnd(tokens, i)
+		} else if lex.ItemOpenBracket == token {
+			depth++
+		} else if lex.ItemCloseBracket == token {
+			if depth--; 0 == depth {
+				end = i
+				break
+			}
+		}
+	}
+	if 0 > end {
+		return nil
+	}
+	content := tokens[2:end]
+	if lex.IsBlankLine(content) {
+		return nil
+	}
+
+	// 自动编号，跳过已经被脚注定义占用的标签
+	var label []byte
+	for i := len(t.Context.FootnotesDefs) + 1; ; i++ {
+		label = []byte("^" + strconv.Itoa(i))
+		if _, def := t.Context.FindFootnotesDef(label); nil == def {
+			break
+		}
+	}
+
+	footnotesDef := &ast.Node{Type: ast.NodeFootnotesDef, Tokens: label, FootnotesInline: content}
+	paragraph := &ast.Node{Type: ast.NodeParagraph, Tokens: lex.TrimWhitespace(content)}
+	footnotesDef.AppendChild(paragraph)
+	t.walkParseInline(paragraph)
+	t.Context.FootnotesDefs = append(t.Context.FootnotesDefs, footnotesDef)
+
+	ref := &ast.Node{Type: ast.NodeFootnotesRef, Tokens: label, FootnotesRefId: strconv.Itoa(len(t.Context.FootnotesDefs)), FootnotesRefLabel: label, FootnotesInline: content}
+	footnotesDef.FootnotesRefs = append(footnotesDef.FootnotesRefs, ref)
+	ctx.pos += end + 1
+	return ref
+}
+
+// codeSpanEnd 返回 tokens 中从下标 start 开始的代码的结束反引号串的最后一个下标，没有匹配的结束反引号串时返回开始反引号串的最后一个下标。
+func codeSpanEnd(tokens []byte, start int) int {
+	length := len(tokens)
+	i := start
+	for i < length && lex.ItemBacktick == tokens[i] {
+		i++
+	}
+	openLen := i - start
+	for i < length {
+		if lex.ItemBacktick != tokens[i] {
+			i++
+			continue
+		}
+		closeStart := i
+		for i < length && lex.ItemBacktick == tokens[i] {
+			i++
+		}
+		if openLen == i-closeStart {
+			return i - 1
+		}
+	}
+	return start + openLen - 1
+}
@@ -0,0 +1,248 @@
+// Lute - 一款对中文语境优化的 Markdown 引擎，支持 Go 和 JavaScript
+// Copyright (c) 2019-present, b3log.org
+//
+// Lute is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//         http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND, EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT, MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+package parse
+
+import (
+	"bytes"
+	"strconv"
+	"strings"
+
+	"lute/ast"
+	"lute/lex"
+	"lute/util"
+)
+
+var (
+	YamlFrontMatterMarker = util.StrToBytes("---") // YAML 前置元数据标记符
+	TomlFrontMatterMarker = util.StrToBytes("+++") // TOML 前置元数据标记符
+)
+
+func FrontMatterContinue(frontMatter *ast.Node, context *Context) int {
+	if marker := frontMatterMarker(context.currentLine); bytes.Equal(marker, frontMatter.FrontMatterMarker) {
+		context.finalize(frontMatter, context.lineNum)
+		return 2
+	}
+	return 0
+}
+
+func (context *Context) frontMatterFinalize(frontMatter *ast.Node) {
+	tokens := frontMatter.Tokens
+	if length := len(tokens); 0 < length && lex.ItemNewline == tokens[length-1] {
+		tokens = tokens[:length-1]
+	}
+	frontMatter.Tokens = nil
+
+	openMarker := &ast.Node{Type: ast.NodeFrontMatterOpenMarker, Tokens: frontMatter.FrontMatterMarker}
+	content := &ast.Node{Type: ast.NodeFrontMatterContent, Tokens: tokens}
+	closeMarker := &ast.Node{Type: ast.NodeFrontMatterCloseMarker, Tokens: frontMatter.FrontMatterMarker}
+	frontMatter.AppendChild(openMarker)
+	frontMatter.AppendChild(content)
+	frontMatter.AppendChild(closeMarker)
+	if 0 < frontMatter.StartLn {
+		markerLen := len(frontMatter.FrontMatterMarker)
+		context.setStart(openMarker, frontMatter.StartLn, 0)
+		context.setEnd(openMarker, frontMatter.StartLn, markerLen)
+		context.setStart(closeMarker, frontMatter.EndLn, 0)
+		context.setEnd(closeMarker, frontMatter.EndLn, markerLen)
+		if first, last := frontMatter.StartLn+1, frontMatter.EndLn-1; first <= last {
+			context.setStart(content, first, 0)
+			context.setEnd(content, last, len(context.sourceLines[last-1].tokens))
+		}
+	}
+
+	context.Tree.FrontMatter = ParseFrontMatter(frontMatter.FrontMatterMarker, tokens)
+}
+
+func (t *Tree) parseFrontMatter() (marker []byte) {
+	if !t.Context.frontMatter || 1 != t.Context.lineNum || 0 != t.Context.nextNonspace {
+		return nil
+	}
+	return frontMatterMarker(t.Context.currentLine)
+}
+
+// hasFrontMatter 判断 markdown 是否以前置元数据开头，需要满足首行是 --- 或者 +++ 并且后续存在相同的结束标记符行。
+func hasFrontMatter(markdown []byte) bool {
+	lines := bytes.Split(markdown, []byte{lex.ItemNewline})
+	if 2 > len(lines) {
+		return false
+	}
+	marker := frontMatterMarker(lines[0])
+	if nil == marker {
+		return false
+	}
+	for _, line := range lines[1:] {
+		if bytes.Equal(marker, frontMatterMarker(line)) {
+			return true
+		}
+	}
+	return false
+}
+
+// frontMatterMarker 判断 line 是否是前置元数据标记符行，是的话返回标记符，否则返回 nil。
+func frontMatterMarker(line []byte) []byte {
+	_, line = lex.TrimRight(line)
+	if bytes.Equal(YamlFrontMatterMarker, line) {
+		return YamlFrontMatterMarker
+	}
+	if bytes.Equal(TomlFrontMatterMarker, line) {
+		return TomlFrontMatterMarker
+	}
+	return nil
+}
+
+// ParseFrontMatter 将前置元数据内容 content 解析为键值对，marker 用于区分 YAML（---）和 TOML（+++）。
+//
+// 这里仅实现了常用的子集：
+//   - 标量值：字符串（可带引号）、布尔值、整数、浮点数
+//   - 行内数组：[a, b, "c"]
+//   - YAML 块数组：key 后换行使用 - item 列举
+//   - YAML 缩进映射和 TOML 表 [table]：解析为嵌套的 map[string]interface{}
+func ParseFrontMatter(marker, content []byte) (ret map[string]interface{}) {
+	ret = map[string]interface{}{}
+	lines := strings.Split(util.BytesToStr(content), "\n")
+	if bytes.Equal(TomlFrontMatterMarker, marker) {
+		parseToml(lines, ret)
+	} else {
+		parseYaml(lines, 0, ret)
+	}
+	return
+}
+
+func parseToml(lines []string, ret map[string]interface{}) {
+	table := ret
+	for _, line := range lines {
+		line = strings.TrimSpace(line)
+		if "" == line || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]") && !strings.Contains(line, "=") {
+			table = ret
+			for _, name := range strings.Split(strings.Trim(line, "[]"), ".") {
+				name = unquoteFrontMatter(strings.TrimSpace(name))
+				sub, ok := table[name].(map[string]interface{})
+				if !ok {
+					sub = map[string]interface{}{}
+					table[name] = sub
+				}
+				table = sub
+			}
+			continue
+		}
+		eq := strings.Index(line, "=")
+		if 1 > eq {
+			continue
+		}
+		key := unquoteFrontMatter(strings.TrimSpace(line[:eq]))
+		table[key] = frontMatterValue(strings.TrimSpace(line[eq+1:]))
+	}
+}
+
+// parseYaml 解析缩进为 indent 的 YAML 映射行，返回已经处理的行数。
+func parseYaml(lines []string, indent int, ret map[string]interface{}) (i int) {
+	for i < len(lines) {
+		line := lines[i]
+		trimmed := strings.TrimSpace(line)
+		if "" == trimmed || strings.HasPrefix(trimmed, "#") {
+			i++
+			continue
+		}
+		lineIndent := len(line) - len(strings.TrimLeft(line, " "))
+		if lineIndent < indent {
+			return
+		}
+
+		colon := strings.Index(trimmed, ":")
+		if 1 > colon {
+			i++
+			continue
+		}
+		key := unquoteFrontMatter(strings.TrimSpace(trimmed[:colon]))
+		value := strings.TrimSpace(trimmed[colon+1:])
+		i++
+		if "" != value {
+			ret[key] = frontMatterValue(value)
+			continue
+		}
+
+		// 值为空的话看下一行是块数组还是嵌套映射
+		next := i
+		for next < len(lines) && "" == strings.TrimSpace(lines[next]) {
+			next++
+		}
+		if next >= len(lines) {
+			ret[key] = nil
+			continue
+		}
+		nextLine := lines[next]
+		nextIndent := len(nextLine) - len(strings.TrimLeft(nextLine, " "))
+		if strings.HasPrefix(strings.TrimSpace(nextLine), "- ") || "-" == strings.TrimSpace(nextLine) {
+			var items []interface{}
+			for i = next; i < len(lines); i++ {
+				item := strings.TrimSpace(lines[i])
+				if "" == item {
+					continue
+				}
+				if !strings.HasPrefix(item, "-") {
+					break
+				}
+				items = append(items, frontMatterValue(strings.TrimSpace(item[1:])))
+			}
+			ret[key] = items
+		} else if nextIndent > lineIndent {
+			sub := map[string]interface{}{}
+			i = next + parseYaml(lines[next:], nextIndent, sub)
+			ret[key] = sub
+		} else {
+			ret[key] = nil
+		}
+	}
+	return
+}
+
+// frontMatterValue 将标量或者行内数组文本 value 转换为对应的值。
+func frontMatterValue(value string) interface{} {
+	if strings.HasPrefix(value, "[") && strings.HasSuffix(value, "]") {
+		ret := []interface{}{}
+		for _, item := range strings.Split(value[1:len(value)-1], ",") {
+			if item = strings.TrimSpace(item); "" != item {
+				ret = append(ret, frontMatterValue(item))
+			}
+		}
+		return ret
+	}
+
+	if unquoted := unquoteFrontMatter(value); unquoted != value {
+		return unquoted
+	}
+	switch value {
+	case "true":
+		return true
+	case "false":
+		return false
+	}
+	if i, err := strconv.ParseInt(value, 10, 64); nil == err {
+		return i
+	}
+	if f, err := strconv.ParseFloat(value, 64); nil == err {
+		return f
+	}
+	return value
+}
+
+func unquoteFrontMatter(value string) string {
+	if 2 > len(value) {
+		return value
+	}
+	if first, last := value[0], value[len(value)-1]; first == last && ('"' == first || '\'' == first) {
+		return value[1 : len(value)-1]
+	}
+	return value
+}
@@ -17,7 +17,7 @@ import (
 	"lute/util"
 )
 
-func (t *Tree) parseATXHeading() (ok bool, markers, content []byte, level int, id []byte) {
+func (t *Tree) parseATXHeading() (ok bool, markers, content []byte, level int, id []byte, attrs map[string]string) {
 	tokens := t.Context.currentLine[t.Context.nextNonspace:]
 	var startCaret bool
 	if t.Context.Option.VditorWYSIWYG && bytes.HasPrefix(tokens, []byte(Caret)) {
@@ -93,11 +93,25 @@ func (t *Tree) parseATXHeading() (ok bool, markers, content []byte, level int, i
 		}
 	}
 
+	if t.Context.Option.AttributeList {
+		if attrs, content = parseTrailingAttributeList(content); nil != attrs {
+			if "" != attrs["id"] {
+				id = []byte(attrs["id"])
+			}
+			ok = true
+			return
+		}
+	}
+
 	if t.Context.Option.HeadingID {
 		id = t.parseHeadingID(content)
 		if nil != id {
 			content = bytes.ReplaceAll(content, []byte("{"+util.BytesToStr(id)+"}"), nil)
 			_, content = lex.TrimRight(content)
+			if "-" == string(id) && t.Context.Option.HeadingNumbering {
+				// 打开标题编号时 {-} 不是标题 ID，而是不编号标记
+				id, attrs = nil, map[string]string{"class": unnumberedClass}
+			}
 		}
 	}
 	ok = true
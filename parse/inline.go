@@ -23,6 +23,7 @@ import (
 // parseInline 解析并生成块节点 block 的行级子节点。
 func (t *Tree) parseInline(block *ast.Node, ctx *InlineContext) {
 	for ctx.pos < ctx.tokensLen {
+		start := ctx.pos
 		token := ctx.tokens[ctx.pos]
 		var n *ast.Node
 		switch token {
@@ -32,6 +33,39 @@ func (t *Tree) parseInline(block *ast.Node, ctx *InlineContext) {
 			n = t.parseCodeSpan(block, ctx)
 		case lex.ItemAsterisk, lex.ItemUnderscore, lex.ItemTilde:
 			t.handleDelim(block, ctx)
+		case lex.ItemEqual, lex.ItemCaret:
+			if lex.ItemCaret == token && t.Context.Option.Footnotes && t.Context.Option.InlineFootnotes {
+				if n = t.parseInlineFootnote(ctx); nil != n {
+					break
+				}
+				if !t.Context.Option.Sup {
+					// 不是行内脚注的 ^ 作为普通文本
+					ctx.pos++
+					n = &ast.Node{Type: ast.NodeText, Tokens: ctx.tokens[start:ctx.pos]}
+					break
+				}
+			}
+			if (lex.ItemEqual == token && t.Context.Option.Mark) || (lex.ItemCaret == token && t.Context.Option.Sup) {
+				t.handleDelim(block, ctx)
+			} else {
+				n = t.parseText(ctx)
+			}
+		case lex.ItemCrosshatch:
+			if t.Context.Option.Tag {
+				n = t.parseTag(ctx)
+			}
+			if nil == n {
+				ctx.pos++
+				n = &ast.Node{Type: ast.NodeText, Tokens: ctx.tokens[start:ctx.pos]}
+			}
+		case lex.ItemOpenBrace:
+			if t.Context.Option.Ruby {
+				n = t.parseRuby(block, ctx)
+			}
+			if nil == n {
+				ctx.pos++
+				n = &ast.Node{Type: ast.NodeText, Tokens: ctx.tokens[start:ctx.pos]}
+			}
 		case lex.ItemNewline:
 			n = t.parseNewline(block, ctx)
 		case lex.ItemLess:
@@ -43,7 +77,12 @@ func (t *Tree) parseInline(block *ast.Node, ctx *InlineContext) {
 				}
 			}
 		case lex.ItemOpenBracket:
-			n = t.parseOpenBracket(ctx)
+			if t.Context.Option.WikiLink {
+				n = t.parseWikiLink(ctx)
+			}
+			if nil == n {
+				n = t.parseOpenBracket(ctx)
+			}
 		case lex.ItemCloseBracket:
 			n = t.parseCloseBracket(ctx)
 		case lex.ItemAmpersand:
@@ -57,6 +96,9 @@ func (t *Tree) parseInline(block *ast.Node, ctx *InlineContext) {
 		}
 
 		if nil != n {
+			if 1 > n.StartLn {
+				ctx.setPos(n, start, ctx.pos)
+			}
 			block.AppendChild(n)
 		}
 	}
@@ -256,8 +298,13 @@ func (t *Tree) parseCloseBracket(ctx *InlineContext) *ast.Node {
 				// 查找脚注
 				if idx, footnotesDef := t.Context.FindFootnotesDef(reflabel); nil != footnotesDef {
 					t.removeBracket(ctx)
-					opener.node.Next.Unlink() // ^label
-					opener.node.Unlink()      // [
+					for nil != opener.node.Next { // ^label，打开上标时 ^ 会被切分为单独的文本节点
+						opener.node.Next.Unlink()
+					}
+					opener.node.Unlink() // [
+					for nil != ctx.delimiters && ctx.delimiters != opener.previousDelimiter {
+						t.removeDelimiter(ctx.delimiters, ctx)
+					}
 
 					refId := strconv.Itoa(idx)
 					refsLen := len(footnotesDef.FootnotesRefs)
@@ -265,6 +312,8 @@ func (t *Tree) parseCloseBracket(ctx *InlineContext) *ast.Node {
 						refId += ":" + strconv.Itoa(refsLen+1)
 					}
 					ref := &ast.Node{Type: ast.NodeFootnotesRef, Tokens: bytes.ToLower(reflabel), FootnotesRefId: refId, FootnotesRefLabel: reflabel}
+					copyStart(ref, opener.node)
+					ctx.setEnd(ref, ctx.pos)
 					footnotesDef.FootnotesRefs = append(footnotesDef.FootnotesRefs, ref)
 					return ref
 				}
@@ -285,12 +334,22 @@ func (t *Tree) parseCloseBracket(ctx *InlineContext) *ast.Node {
 
 	if matched {
 		node := &ast.Node{Type: ast.NodeLink, LinkType: linkType, LinkRefLabel: reflabel}
+		copyStart(node, opener.node)
+		ctx.setEnd(node, ctx.pos)
 		if isImage {
 			node.Type = ast.NodeImage
-			node.AppendChild(&ast.Node{Type: ast.NodeBang, Tokens: opener.node.Tokens[:1]})
+			bang := &ast.Node{Type: ast.NodeBang, Tokens: opener.node.Tokens[:1]}
+			copyStart(bang, opener.node)
+			copyEnd(bang, opener.node)
+			bang.EndCol, bang.EndOffset = bang.StartCol+1, bang.StartOffset+1
+			node.AppendChild(bang)
 			opener.node.Tokens = opener.node.Tokens[1:]
+			moveStart(opener.node, 1)
 		}
-		node.AppendChild(&ast.Node{Type: ast.NodeOpenBracket, Tokens: opener.node.Tokens})
+		openBracket := &ast.Node{Type: ast.NodeOpenBracket, Tokens: opener.node.Tokens}
+		copyStart(openBracket, opener.node)
+		copyEnd(openBracket, opener.node)
+		node.AppendChild(openBracket)
 
 		var tmp, next *ast.Node
 		tmp = opener.node.Next
@@ -303,7 +362,9 @@ func (t *Tree) parseCloseBracket(ctx *InlineContext) *ast.Node {
 			node.AppendChild(tmp)
 			tmp = next
 		}
-		node.AppendChild(&ast.Node{Type: ast.NodeCloseBracket, Tokens: closeBracket})
+		closeBracketNode := &ast.Node{Type: ast.NodeCloseBracket, Tokens: closeBracket}
+		ctx.setPos(closeBracketNode, startPos-1, startPos)
+		node.AppendChild(closeBracketNode)
 		node.AppendChild(&ast.Node{Type: ast.NodeOpenParen, Tokens: openParen})
 		node.AppendChild(&ast.Node{Type: ast.NodeLinkDest, Tokens: dest})
 		if nil != space {
@@ -16,7 +16,33 @@ import (
 
 // parseInlines 解析并生成行级节点。
 func (t *Tree) parseInlines() {
+	var includes []*include
+	if t.Context.Option.Transclusion {
+		includes = t.includes()
+	}
+	if t.Context.Option.TableCaption {
+		t.tableCaptions()
+	}
 	t.walkParseInline(t.Root)
+	if 0 < len(includes) {
+		t.transclude(includes)
+	}
+	if 0 < len(t.Context.includes) {
+		// 嵌入的文档由主文档统一编号和解析交叉引用
+		return
+	}
+	if t.Context.Option.HeadingNumbering || t.Context.Option.CrossRef {
+		t.numberHeadings()
+	}
+	if t.Context.Option.CaptionNumbering || t.Context.Option.CrossRef {
+		t.numberCaptions()
+	}
+	if t.Context.Option.CrossRef {
+		t.resolveCrossRefs()
+	}
+	if t.Context.Option.Citation {
+		t.resolveCitations()
+	}
 }
 
 // walkParseInline 解析生成节点 node 的行级子节点。
@@ -26,7 +52,7 @@ func (t *Tree) walkParseInline(node *ast.Node) {
 	}
 
 	// 只有如下几种类型的块节点需要生成行级子节点
-	if typ := node.Type; ast.NodeParagraph == typ || ast.NodeHeading == typ || ast.NodeTableCell == typ {
+	if typ := node.Type; ast.NodeParagraph == typ || ast.NodeHeading == typ || ast.NodeTableCell == typ || ast.NodeDefinitionTerm == typ || ast.NodeTableCaption == typ {
 		tokens := node.Tokens
 		if ast.NodeParagraph == typ && nil == tokens {
 			// 解析 GFM 表节点后段落内容 Tokens 可能会被置换为空，具体可参看函数 Paragraph.Finalize()
@@ -43,7 +69,7 @@ func (t *Tree) walkParseInline(node *ast.Node) {
 			return
 		}
 
-		ctx := &InlineContext{tokens: tokens, tokensLen: length}
+		ctx := &InlineContext{tokens: tokens, tokensLen: length, lines: t.Context.inlineLines(node)}
 
 		// 生成该块节点的行级子节点
 		t.parseInline(node, ctx)
@@ -58,6 +84,10 @@ func (t *Tree) walkParseInline(node *ast.Node) {
 		// 2. 方便后续功能方面的处理，比如 GFM 自动链接解析
 		t.mergeText(node)
 
+		if t.Context.Option.AttributeList {
+			t.inlineAttributeList(node)
+		}
+
 		if t.Context.Option.GFMAutoLink && !t.Context.Option.VditorWYSIWYG {
 			t.parseGFMAutoEmailLink(node)
 			t.parseGFMAutoLink(node)
@@ -66,6 +96,23 @@ func (t *Tree) walkParseInline(node *ast.Node) {
 		if t.Context.Option.Emoji {
 			t.emoji(node)
 		}
+
+		if t.Context.Option.CrossRef {
+			// 需要在提及之前解析，否则 @fig:arch 中的 @fig 会被识别为提及
+			t.parseCrossRefs(node)
+		}
+
+		if t.Context.Option.Citation {
+			t.parseCitations(node)
+		}
+
+		if option := t.Context.Option; option.Mention || option.IssueRef || option.RepoIssueRef || option.CommitRef {
+			t.parseReferences(node)
+		}
+
+		if t.Context.Option.Abbreviation && 0 < len(t.Context.Abbreviations) {
+			t.abbreviation(node)
+		}
 		return
 	} else if ast.NodeCodeBlock == typ {
 		if node.IsFencedCodeBlock {
@@ -76,11 +123,13 @@ func (t *Tree) walkParseInline(node *ast.Node) {
 			node.AppendChild(info)
 			code := &ast.Node{Type: ast.NodeCodeBlockCode, Tokens: node.Tokens}
 			node.AppendChild(code)
-			if nil == node.CodeBlockCloseFence {
+			closed := nil != node.CodeBlockCloseFence
+			if !closed {
 				node.CodeBlockCloseFence = node.CodeBlockOpenFence
 			}
 			closeMarker := &ast.Node{Type: ast.NodeCodeBlockFenceCloseMarker, Tokens: node.CodeBlockCloseFence, CodeBlockFenceLen: node.CodeBlockFenceLen}
 			node.AppendChild(closeMarker)
+			t.Context.fencedCodeBlockPos(node, openMarker, info, code, closeMarker, closed)
 		} else {
 			// 细化缩进代码块子节点
 			code := &ast.Node{Type: ast.NodeCodeBlockCode, Tokens: node.Tokens}
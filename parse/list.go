@@ -124,7 +124,7 @@ func (t *Tree) parseListMarker(container *ast.Node) *ast.ListData {
 		}
 
 		if 3 <= len(content) { // 至少需要 [ ] 或者 [x] 3 个字符
-			if lex.ItemOpenBracket == content[0] && ('x' == content[1] || 'X' == content[1] || lex.ItemSpace == content[1]) && lex.ItemCloseBracket == content[2] {
+			if lex.ItemOpenBracket == content[0] && t.isTaskListItemState(content[1]) && lex.ItemCloseBracket == content[2] {
 				data.Typ = 3
 				data.Checked = 'x' == content[1] || 'X' == content[1]
 			}
@@ -171,3 +171,20 @@ func endsWithBlankLine(block *ast.Node) bool {
 
 	return false
 }
+
+// isTaskListItemState 判断 token 是否是可识别的任务列表项状态字符。
+func (t *Tree) isTaskListItemState(token byte) bool {
+	if 'x' == token || 'X' == token || lex.ItemSpace == token {
+		return true
+	}
+	_, ok := t.Context.Option.TaskListItemStates[token]
+	return ok
+}
+
+// ExtendedTaskListItemStates 是常用的扩展任务状态：[-] 取消、[/] 进行中、[>] 推迟和 [?] 疑问。
+var ExtendedTaskListItemStates = map[byte]string{
+	'-': "cancelled",
+	'/': "in-progress",
+	'>': "deferred",
+	'?': "question",
+}
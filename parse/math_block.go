@@ -21,6 +21,14 @@ func MathBlockContinue(mathBlock *ast.Node, context *Context) int {
 	var ln = context.currentLine
 	var indent = context.indent
 
+	if indent <= 3 && context.Option.CrossRef {
+		if attrs := mathBlockCloseLabel(ln[context.nextNonspace:]); nil != attrs {
+			mathBlock.Attributes = attrs
+			context.finalize(mathBlock, context.lineNum)
+			return 2
+		}
+	}
+
 	if indent <= 3 && isMathBlockClose(ln[context.nextNonspace:]) {
 		context.finalize(mathBlock, context.lineNum)
 		return 2
@@ -45,6 +53,9 @@ var MathBlockMarker = util.StrToBytes("$$")
 func mathBlockFinalize(mathBlock *ast.Node) {
 	tokens := mathBlock.Tokens[2:] // 剔除开头的两个 $$
 	tokens = lex.TrimWhitespace(tokens)
+	if nil != mathBlock.Attributes {
+		_, tokens = parseTrailingAttributeList(tokens) // 剔除结尾的交叉引用标签
+	}
 	if bytes.HasSuffix(tokens, MathBlockMarker) {
 		tokens = tokens[:len(tokens)-2] // 剔除结尾的两个 $$
 	}
@@ -89,3 +100,12 @@ func isMathBlockClose(tokens []byte) bool {
 	}
 	return true
 }
+
+// mathBlockCloseLabel 判断 tokens 是否是带有交叉引用标签的结束标记 $$ {#eq:label}，是的话返回标签所在的属性列表。
+func mathBlockCloseLabel(tokens []byte) map[string]string {
+	attrs, remains := parseTrailingAttributeList(tokens)
+	if nil == attrs || "" == attrs["id"] || 1 > len(remains) || !isMathBlockClose(remains) {
+		return nil
+	}
+	return attrs
+}
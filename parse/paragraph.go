@@ -29,17 +29,38 @@ func paragraphFinalize(p *ast.Node, context *Context) (insertTable bool) {
 
 	// 尝试解析链接引用定义
 	hasReferenceDefs := false
-	for tokens := p.Tokens; 0 < len(tokens) && lex.ItemOpenBracket == tokens[0]; tokens = p.Tokens {
-		if tokens = context.parseLinkRefDef(tokens); nil != tokens {
-			p.Tokens = tokens
-			hasReferenceDefs = true
-			continue
+	for tokens := p.Tokens; 0 < len(tokens); tokens = p.Tokens {
+		if lex.ItemOpenBracket == tokens[0] {
+			if tokens = context.parseLinkRefDef(tokens); nil != tokens {
+				p.Tokens = tokens
+				hasReferenceDefs = true
+				continue
+			}
+		} else if context.Option.Abbreviation && lex.ItemAsterisk == tokens[0] {
+			// 尝试解析缩写定义
+			if tokens = context.parseAbbreviationDef(tokens); nil != tokens {
+				p.Tokens = tokens
+				hasReferenceDefs = true
+				continue
+			}
 		}
 		break
 	}
 	if hasReferenceDefs && lex.IsBlankLine(p.Tokens) {
 		p.Unlink()
 	}
+	if hasReferenceDefs {
+		context.skipLines(p)
+	}
+
+	if context.Option.Abbreviation {
+		// 尝试解析段落末尾的缩写定义
+		context.paragraphAbbreviationDefs(p)
+	}
+
+	if context.Option.AttributeList {
+		paragraphAttributeList(p)
+	}
 
 	if context.Option.GFMTaskListItem {
 		// 尝试解析任务列表项
@@ -69,7 +90,10 @@ func paragraphFinalize(p *ast.Node, context *Context) (insertTable bool) {
 							caretInBracket = true
 						}
 					}
-					taskListItemMarker := &ast.Node{Type: ast.NodeTaskListItemMarker, Tokens: tokens[:3], TaskListItemChecked: listItem.ListData.Checked}
+					taskListItemMarker := &ast.Node{Type: ast.NodeTaskListItemMarker, Tokens: tokens[:3], TaskListItemChecked: listItem.ListData.Checked, TaskListItemState: tokens[1], TaskListItemIndex: context.taskListItems}
+					context.taskListItems++
+					copyStart(taskListItemMarker, p)
+					taskListItemMarker.EndLn, taskListItemMarker.EndCol, taskListItemMarker.EndOffset = p.StartLn, p.StartCol+3, p.StartOffset+3
 					p.PrependChild(taskListItemMarker)
 					p.Tokens = tokens[3:] // 剔除开头的 [ ]、[x] 或者 [X]
 					if context.Option.VditorWYSIWYG {
@@ -87,7 +111,16 @@ func paragraphFinalize(p *ast.Node, context *Context) (insertTable bool) {
 
 	if context.Option.GFMTable {
 		if paragraph, table := context.parseTable(p); nil != table {
+			tableTokens, tableLn := p.Tokens, p.StartLn
 			if nil != paragraph {
+				tableTokens = p.Tokens[len(paragraph.Tokens)+1:]
+				tableLn += bytes.Count(paragraph.Tokens, []byte{lex.ItemNewline}) + 1
+			}
+			context.tablePos(table, lex.Split(tableTokens, lex.ItemNewline), tableLn)
+			if nil != paragraph {
+				copyStart(paragraph, p)
+				context.setBlockEnd(paragraph, tableLn-1)
+				copyEnd(p, paragraph)
 				p.Tokens = paragraph.Tokens
 				p.InsertAfter(table)
 				// 设置末梢及其状态
@@ -98,6 +131,9 @@ func paragraphFinalize(p *ast.Node, context *Context) (insertTable bool) {
 				// 将该段落节点转成表节点
 				p.Type = ast.NodeTable
 				p.TableAligns = table.TableAligns
+				if nil != table.Attributes {
+					p.Attributes = table.Attributes
+				}
 				for tr := table.FirstChild; nil != tr; {
 					nextTr := tr.Next
 					p.AppendChild(tr)
@@ -114,6 +150,7 @@ func paragraphFinalize(p *ast.Node, context *Context) (insertTable bool) {
 			// 将该段落节点转换成目录节点
 			p.Type = ast.NodeToC
 			p.Tokens = toc.Tokens
+			p.ToCType = toc.ToCType
 			return
 		}
 	}
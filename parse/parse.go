@@ -23,13 +23,22 @@ const Zwsp = "\u200b"
 
 // Parse 会将 markdown 原始文本字节数组解析为一颗语法树。
 func Parse(name string, markdown []byte, options *Options) (tree *Tree) {
-	tree = &Tree{Name: name, Context: &Context{Option: options}}
+	return parse(name, markdown, options, nil)
+}
+
+// parse 解析 markdown，includes 为嵌入该文档的文档路径链，解析主文档时为空。
+func parse(name string, markdown []byte, options *Options, includes []string) (tree *Tree) {
+	tree = &Tree{Name: name, Context: &Context{Option: options, includes: includes}}
 	tree.Context.Tree = tree
+	tree.Context.frontMatter = options.FrontMatter && hasFrontMatter(markdown)
 	tree.lexer = lex.NewLexer(markdown)
 	tree.Root = &ast.Node{Type: ast.NodeDocument}
 	tree.parseBlocks()
 	tree.parseInlines()
+	fixPos(tree.Root)
 	tree.lexer = nil
+	tree.Context.sourceLines = nil
+	tree.Context.consumedLines = nil
 	return
 }
 
@@ -40,25 +49,33 @@ type Context struct {
 
 	LinkRefDefs   map[string]*ast.Node // 链接引用定义集
 	FootnotesDefs []*ast.Node          // 脚注定义集
+	Abbreviations map[string]string    // 缩写定义集，键为缩写，值为全称
+	CrossRefs     map[string]*ast.Node // 交叉引用标签集，键为标签，值为标签所在的标题、图片、表或者公式块节点
+	Diagnostics   []*Diagnostic        // 诊断信息，比如引用了未定义的标签
+	Citations     []string             // 引用过的文献键，按照首次引用的顺序排列，不包含参考文献库中不存在的文献
 
-	Tip                                                               *ast.Node // 末梢节点
-	oldtip                                                            *ast.Node // 老的末梢节点
-	currentLine                                                       []byte    // 当前行
-	currentLineLen                                                    int       // 当前行长
-	lineNum, offset, column, nextNonspace, nextNonspaceColumn, indent int       // 解析时用到的行号、下标、缩进空格数等
-	indented, blank, partiallyConsumedTab, allClosed                  bool      // 是否是缩进行、空行等标识
-	lastMatchedContainer                                              *ast.Node // 最后一个匹配的块节点
+	Tip                                                               *ast.Node                    // 末梢节点
+	oldtip                                                            *ast.Node                    // 老的末梢节点
+	currentLine                                                       []byte                       // 当前行
+	currentLineLen                                                    int                          // 当前行长
+	lineNum, offset, column, nextNonspace, nextNonspaceColumn, indent int                          // 解析时用到的行号、下标、缩进空格数等
+	indented, blank, partiallyConsumedTab, allClosed                  bool                         // 是否是缩进行、空行等标识
+	lastMatchedContainer                                              *ast.Node                    // 最后一个匹配的块节点
+	sourceLines                                                       []sourceLine                 // 原始输入行，用于计算节点源码位置
+	consumedLines                                                     map[*ast.Node][]consumedLine // 块节点解析时追加的行，用于计算行级节点源码位置
+	frontMatter                                                       bool                         // 是否以闭合的前置元数据开头
+	taskListItems                                                     int                          // 已经解析的任务列表项个数
+	includes                                                          []string                     // 嵌入该文档的文档路径链，用于检测循环嵌入
 }
 
 // InlineContext 描述了行级元素解析上下文。
 type InlineContext struct {
-	tokens     []byte     // 当前解析的 Tokens
-	tokensLen  int        // 当前解析的 Tokens 长度
-	pos        int        // 当前解析到的 token 位置
-	lineNum    int        // 当前解析的起始行号
-	columnNum  int        // 当前解析的起始列号
-	delimiters *delimiter // 分隔符栈，用于强调解析
-	brackets   *delimiter // 括号栈，用于图片和链接解析
+	tokens     []byte       // 当前解析的 Tokens
+	tokensLen  int          // 当前解析的 Tokens 长度
+	pos        int          // 当前解析到的 token 位置
+	lines      []inlineLine // Tokens 中每一行在原始输入中的位置
+	delimiters *delimiter   // 分隔符栈，用于强调解析
+	brackets   *delimiter   // 括号栈，用于图片和链接解析
 }
 
 // advanceOffset 用于移动 count 个字符位置，columns 指定了遇到 tab 时是否需要空格进行补偿偏移。
@@ -144,6 +161,7 @@ func (context *Context) closeUnmatchedBlocks() {
 func (context *Context) finalize(block *ast.Node, lineNum int) {
 	parent := block.Parent
 	block.Close = true
+	context.setBlockEnd(block, lineNum)
 
 	// 节点最终化处理。比如围栏代码块提取 info 部分；HTML 代码块剔除结尾空格；段落需要解析链接引用定义等。
 	switch block.Type {
@@ -158,8 +176,14 @@ func (context *Context) finalize(block *ast.Node, lineNum int) {
 		}
 	case ast.NodeMathBlock:
 		mathBlockFinalize(block)
+	case ast.NodeFrontMatter:
+		context.frontMatterFinalize(block)
+	case ast.NodeBlockquote:
+		context.blockquoteFinalize(block)
 	case ast.NodeList:
 		listFinalize(block)
+	case ast.NodeDefinitionDescription:
+		definitionDescriptionFinalize(block)
 	}
 
 	context.Tip = parent
@@ -180,6 +204,7 @@ func (context *Context) addChild(nodeType ast.NodeType, offset int) (ret *ast.No
 	}
 
 	ret = &ast.Node{Type: nodeType}
+	context.setStart(ret, context.lineNum, offset)
 	context.Tip.AppendChild(ret)
 	context.Tip = ret
 	return ret
@@ -199,12 +224,42 @@ type Tree struct {
 	Context       *Context       // 块级解析上下文
 	lexer         *lex.Lexer     // 词法分析器
 	inlineContext *InlineContext // 行级解析上下文
+
+	FrontMatter map[string]interface{} // 前置元数据解析得到的键值对，没有前置元数据时为 nil
 }
 
 // Options 描述了一些列解析和渲染选项。
 type Options struct {
 	// GFMTable 设置是否打开“GFM 表”支持。
 	GFMTable bool
+	// TableSpan 设置是否打开表的合并单元格和多行单元格支持：|| 表示和左侧单元格合并，^^ 表示和上方单元格合并，
+	// 表行以 \ 结尾时下一行继续作为该表行的内容。
+	TableSpan bool
+	// TableCaption 设置是否打开表标题支持，表之前或者之后以 Table: 开头的段落会作为表标题。
+	TableCaption bool
+	// CaptionNumbering 设置是否对带标题的表和带标题的图片自动编号。
+	CaptionNumbering bool
+	// TableNumberPrefix 设置表编号的前缀，默认为 "Table "，比如可以设置为 "表 "。
+	TableNumberPrefix string
+	// FigureNumberPrefix 设置图编号的前缀，默认为 "Figure "，比如可以设置为 "图 "。
+	FigureNumberPrefix string
+	// CrossRef 设置是否打开交叉引用支持。标题、图片使用属性列表 {#sec:intro}、{#fig:arch} 定义标签（需要打开 AttributeList 或者 HeadingID），
+	// 表在表标题末尾使用 {#tbl:label}，公式块在结束 $$ 后使用 {#eq:label}。使用 @fig:arch 或者 [@sec:intro] 引用。
+	CrossRef bool
+	// SectionNumberPrefix 设置交叉引用章节时编号的前缀，默认为 "Section "，比如可以设置为 "第 "。
+	SectionNumberPrefix string
+	// EquationNumberPrefix 设置交叉引用公式时编号的前缀，默认为 "Equation "，比如可以设置为 "公式 "。
+	EquationNumberPrefix string
+	// HeadingNumbering 设置是否为标题自动编号，比如 1、1.1、1.1.2。属性列表中带有 .unnumbered 类或者 {-} 的标题不编号。
+	HeadingNumbering bool
+	// HeadingNumberingStartLevel 设置从哪一级标题开始编号，更高级的标题不编号，默认为 0，即从文档中最高的标题层级开始编号。
+	HeadingNumberingStartLevel int
+	// HeadingNumberingMaxDepth 设置标题编号的最大层数，默认为 6，比如设置为 2 时只对起始层级和下一级标题编号。
+	HeadingNumberingMaxDepth int
+	// HeadingNumberingSeparator 设置标题编号各层之间的分隔符，默认为 "."。
+	HeadingNumberingSeparator string
+	// FormatHeadingNumber 设置格式化时是否将标题编号写入 Markdown 文本，标题文本开头之前写入的编号会被替换为当前编号。
+	FormatHeadingNumber bool
 	// GFMTaskListItem 设置是否打开“GFM 任务列表项”支持。
 	GFMTaskListItem bool
 	// GFMTaskListItemClass 作为 GFM 任务列表项类名，默认为 "vditor-task"。
@@ -213,8 +268,14 @@ type Options struct {
 	GFMStrikethrough bool
 	// GFMAutoLink 设置是否打开“GFM 自动链接”支持。
 	GFMAutoLink bool
+	// CJKFriendlyEmphasis 设置是否对 *、_ 和 ~ 分隔符使用对中日韩文字友好的左右侧判定规则：分隔符另一侧是中日韩文字时等同于空白或者标点，
+	// 这样 **“引号”**文字、**中文：**后面 也能解析为强调。_ 仍然不能在单词内部开始或者结束强调。
+	CJKFriendlyEmphasis bool
 	// SoftBreak2HardBreak 设置是否将软换行（\n）渲染为硬换行（<br />）。
 	SoftBreak2HardBreak bool
+	// CJKSoftBreak 设置关闭软换行转硬换行时是否去掉两侧都是东亚宽字符的软换行，避免浏览器将源码中折行的中日文渲染出多余的空格。
+	// Vditor 编辑时 DOM 中保留软换行，这样回写的 Markdown 不会丢失源码中的折行，通过 VditorDOM2HTML 和 VditorIRDOM2HTML 导出预览 HTML 时去掉软换行。
+	CJKSoftBreak bool
 	// CodeSyntaxHighlight 设置是否对代码块进行语法高亮。
 	CodeSyntaxHighlight bool
 	// CodeSyntaxHighlightDetectLang bool
@@ -225,8 +286,16 @@ type Options struct {
 	CodeSyntaxHighlightLineNum bool
 	// CodeSyntaxHighlightStyleName 指定语法高亮样式名，默认为 "github"。
 	CodeSyntaxHighlightStyleName string
+	// TaskListItemStates 设置除了 [ ] 和 [x] 以外可识别的任务列表项状态，键为状态字符，值为状态名称。
+	TaskListItemStates map[byte]string
+	// TaskListItemIndex 设置渲染任务列表项复选框时是否输出 data-task-index 属性，用于将点击的复选框对应到任务列表项。
+	TaskListItemIndex bool
 	// Footnotes 设置是否打开“脚注”支持。
 	Footnotes bool
+	// InlineFootnotes 设置是否打开“行内脚注”（^[note]）支持，需要同时打开脚注支持。
+	InlineFootnotes bool
+	// HoistInlineFootnotes 设置格式化时是否将行内脚注提升为带标签的脚注定义，默认保持行内写法。
+	HoistInlineFootnotes bool
 	// ToC 设置是否打开“目录”支持。
 	ToC bool
 	// HeadingID 设置是否打开“自定义标题 ID”支持。
@@ -240,6 +309,8 @@ type Options struct {
 	FixTermTypo bool
 	// ChinesePunct 设置是否对普通文本中出现中文后跟英文逗号句号等标点替换为中文对应标点。
 	ChinesePunct bool
+	// ChineseConvert 设置对普通文本进行简繁转换的方向，s2t：简体转繁体，t2s：繁体转简体，为空时不转换。
+	ChineseConvert string
 	// Emoji 设置是否对 Emoji 别名替换为原生 Unicode 字符。
 	Emoji bool
 	// AliasEmoji 存储 ASCII 别名到表情 Unicode 映射。
@@ -274,6 +345,58 @@ type Options struct {
 	ImageLazyLoading string
 	// ChineseParagraphBeginningSpace 设置是否使用传统中文排版“段落开头空两格”
 	ChineseParagraphBeginningSpace bool
+	// RenderSourceLine 设置是否在块级元素上渲染 data-source-line 和 data-source-end 属性，用于预览和 Markdown 源码之间的滚动同步
+	RenderSourceLine bool
+	// FrontMatter 设置是否解析文档开头的 YAML（---）和 TOML（+++）前置元数据
+	FrontMatter bool
+	// DefinitionList 设置是否打开“定义列表”支持
+	DefinitionList bool
+	// GitHubAlert 设置是否打开“GitHub 提示块”（> [!NOTE]）支持
+	GitHubAlert bool
+	// CustomContainer 设置是否打开“自定义容器”（::: name）支持
+	CustomContainer bool
+	// Mark 设置是否打开“高亮”（==mark==）支持
+	Mark bool
+	// Sup 设置是否打开“上标”（^sup^）支持
+	Sup bool
+	// Sub 设置是否打开“下标”（~sub~）支持，打开后单个 ~ 不再解析为删除线
+	Sub bool
+	// AttributeList 设置是否打开“属性列表”（{#id .class key=value}）支持，可用于标题、段落、围栏代码块、图片、链接和强调
+	AttributeList bool
+	// WikiLink 设置是否打开“维基链接”（[[Page#Heading|label]]）支持
+	WikiLink bool
+	// WikiLinkResolver 设置维基链接解析器，为空时直接使用页面名称作为链接地址
+	WikiLinkResolver WikiLinkResolver
+	// Abbreviation 设置是否打开“缩写”（*[HTML]: Hyper Text Markup Language）支持
+	Abbreviation bool
+	// Tag 设置是否打开“标签”（#tag、#多个词的标签#）支持
+	Tag bool
+	// TagURLTemplate 设置标签链接地址模板，模板中的 ${tag} 会被替换为标签名，为空时标签不渲染为链接
+	TagURLTemplate string
+	// Mention 设置是否打开“提及”（@username）支持
+	Mention bool
+	// IssueRef 设置是否打开“问题引用”（#123）支持
+	IssueRef bool
+	// RepoIssueRef 设置是否打开“跨仓库问题引用”（owner/repo#123）支持
+	RepoIssueRef bool
+	// CommitRef 设置是否打开“提交引用”（7 到 40 位十六进制 SHA）支持
+	CommitRef bool
+	// ReferenceResolver 设置引用解析器，未设置或者解析为不存在的引用渲染为普通文本
+	ReferenceResolver ReferenceResolver
+	// Transclusion 设置是否打开“嵌入文档”（!include path.md、![[page#heading]]）支持，需要设置 ContentLoader，格式化时保留嵌入指令
+	Transclusion bool
+	// ContentLoader 设置嵌入文档的内容加载器
+	ContentLoader ContentLoader
+	// MaxIncludeDepth 设置嵌入文档的最大嵌套层数，默认为 8
+	MaxIncludeDepth int
+	// Citation 设置是否打开“文献引用”（[@smith2020, p. 33]、@smith2020）支持
+	Citation bool
+	// Bibliography 设置参考文献库，可以使用 ParseBibTeX 或者 ParseCSLJSON 加载
+	Bibliography Bibliography
+	// CitationStyle 设置文献引用样式，author-year：作者-年份（默认），numeric：按照首次引用的顺序编号
+	CitationStyle string
+	// Ruby 设置是否打开“注音”（{漢字|かんじ}、{汉字|hàn|zì}）支持
+	Ruby bool
 }
 
 func (context *Context) ParentTip() {
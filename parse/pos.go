@@ -0,0 +1,523 @@
+// Lute - 一款对中文语境优化的 Markdown 引擎，支持 Go 和 JavaScript
+// Copyright (c) 2019-present, b3log.org
+//
+// Lute is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//         http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND, EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT, MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+package parse
+
+import (
+	"bytes"
+	"sort"
+
+	"lute/ast"
+	"lute/lex"
+)
+
+// sourceLine 描述了原始输入中的一行。
+type sourceLine struct {
+	tokens []byte // 该行内容（不含换行符）
+	offset int    // 该行在原始输入中的起始字节位置
+	nuls   []int  // 该行中由 \u0000 替换而来的 \uFFFD 在 tokens 中的起始下标
+}
+
+// sourceCol 将 tokens 中的第 col 个字节（从 0 开始）换算为原始输入中该行的第几个字节。
+func (l *sourceLine) sourceCol(col int) (ret int) {
+	ret = col
+	for _, i := range l.nuls {
+		if i >= col {
+			break
+		}
+		if i+3 <= col {
+			ret -= 2 // \uFFFD 占用三个字节，原始输入中的 \u0000 只占用一个字节
+		} else {
+			ret -= col - i - 1
+		}
+	}
+	return
+}
+
+// tokensCol 将原始输入中该行的第 col 个字节（从 0 开始）换算为 tokens 中的第几个字节，是 sourceCol 的逆运算。
+func (l *sourceLine) tokensCol(col int) (ret int) {
+	ret = col
+	for _, i := range l.nuls {
+		if i >= ret {
+			break
+		}
+		ret += 2
+	}
+	return
+}
+
+// inlineLine 描述了行级解析 Tokens 中的一行与原始输入的对应关系。
+type inlineLine struct {
+	pos    int         // 该行在 Tokens 中的起始下标
+	ln     int         // 所在行号
+	col    int         // 在 source.tokens 中的起始下标
+	source *sourceLine // 所在的原始输入行
+}
+
+// consumedLine 描述了块节点解析时通过 addLine 追加的一行。
+type consumedLine struct {
+	tokens []byte // 追加的内容
+	ln     int    // 所在行号
+	col    int    // 起始列（从 0 开始）
+}
+
+// addSourceLine 记录词法分析器刚读取的一行。
+func (t *Tree) addSourceLine(line []byte) {
+	start, _ := t.lexer.LinePos()
+	if length := len(line); 0 < length && lex.ItemNewline == line[length-1] {
+		line = line[:length-1]
+	}
+	t.Context.sourceLines = append(t.Context.sourceLines, sourceLine{tokens: line, offset: start, nuls: t.lexer.LineNuls()})
+}
+
+// addConsumedLine 记录块节点 block 追加了第 ln 行第 col 个字节（从 0 开始）开始的内容 tokens。
+func (context *Context) addConsumedLine(block *ast.Node, tokens []byte, ln, col int) {
+	if nil == context.consumedLines {
+		context.consumedLines = map[*ast.Node][]consumedLine{}
+	}
+	context.consumedLines[block] = append(context.consumedLines[block], consumedLine{tokens: tokens, ln: ln, col: col})
+}
+
+// moveConsumedLines 将块节点 from 追加过的行转给替换它的块节点 to。
+func (context *Context) moveConsumedLines(to, from *ast.Node) {
+	if lines, ok := context.consumedLines[from]; ok {
+		context.consumedLines[to] = lines
+		delete(context.consumedLines, from)
+	}
+}
+
+// consumedLinePos 返回块节点 block 的 Tokens 中第 i 行（从 0 开始，共 count 行）line 的行号和起始列（从 0 开始），没有记录的话返回的行号为 0。
+// 块节点追加行以后只会被去掉开头若干行以及行首尾的空白，所以从末尾开始 Tokens 行和追加行一一对应。
+func (context *Context) consumedLinePos(block *ast.Node, line []byte, i, count int) (ln, col int) {
+	lines := context.consumedLines[block]
+	if i += len(lines) - count; 0 > i || len(lines) <= i {
+		return
+	}
+
+	consumed := lines[i]
+	_, line = lex.TrimRight(line)
+	_, tokens := lex.TrimRight(consumed.tokens)
+	col = consumed.col
+	if bytes.HasSuffix(tokens, line) {
+		col += len(tokens) - len(line)
+	}
+	return consumed.ln, col
+}
+
+// startCol 返回节点 n 的起始位置在所在行 tokens 中的下标。
+func (context *Context) startCol(n *ast.Node) int {
+	if 1 > n.StartLn || len(context.sourceLines) < n.StartLn {
+		return n.StartCol - 1
+	}
+	return context.sourceLines[n.StartLn-1].tokensCol(n.StartCol - 1)
+}
+
+// setStart 将节点 n 的起始位置设置为第 ln 行的第 col 个字节（从 0 开始，按 sourceLines 中的 tokens 计算）。
+func (context *Context) setStart(n *ast.Node, ln, col int) {
+	if 1 > ln || len(context.sourceLines) < ln {
+		return
+	}
+	line := &context.sourceLines[ln-1]
+	col = line.sourceCol(col)
+	n.StartLn, n.StartCol, n.StartOffset = ln, col+1, line.offset+col
+}
+
+// setEnd 将节点 n 的结束位置设置为第 ln 行的第 col 个字节（从 0 开始，不包含）。
+func (context *Context) setEnd(n *ast.Node, ln, col int) {
+	if 1 > ln || len(context.sourceLines) < ln {
+		return
+	}
+	line := &context.sourceLines[ln-1]
+	col = line.sourceCol(col)
+	n.EndLn, n.EndCol, n.EndOffset = ln, col+1, line.offset+col
+}
+
+// setBlockEnd 将块节点 n 的结束位置设置为第 ln 行行尾，结尾的空行不计入块节点范围。
+func (context *Context) setBlockEnd(n *ast.Node, ln int) {
+	if 1 > n.StartLn {
+		return
+	}
+	if len(context.sourceLines) < ln {
+		ln = len(context.sourceLines)
+	}
+	for ln > n.StartLn && lex.IsBlankLine(context.sourceLines[ln-1].tokens) {
+		ln--
+	}
+	if ln < n.StartLn {
+		ln = n.StartLn
+	}
+	_, tokens := lex.TrimRight(context.sourceLines[ln-1].tokens)
+	context.setEnd(n, ln, len(tokens))
+}
+
+// findInLine 在第 ln 行第 col 个字节之后查找 tokens，返回其起始列（从 0 开始），找不到的话返回 -1。
+func (context *Context) findInLine(tokens []byte, ln, col int) int {
+	src := context.sourceLines[ln-1].tokens
+	if col > len(src) {
+		return -1
+	}
+	_, tokens = lex.TrimRight(tokens)
+	if 1 > len(tokens) {
+		return col
+	}
+
+	// 块级容器标记符都在行首，所以大部分情况下 tokens 就是该行的后缀
+	if _, trimmed := lex.TrimRight(src); bytes.HasSuffix(trimmed, tokens) && len(trimmed)-len(tokens) >= col {
+		return len(trimmed) - len(tokens)
+	}
+	if i := bytes.Index(src[col:], tokens); -1 < i {
+		return col + i
+	}
+	// 制表符被展开为空格的情况
+	spaces, content := lex.TrimLeft(tokens)
+	if 1 > len(content) {
+		return -1
+	}
+	if i := bytes.Index(src[col:], content); -1 < i {
+		if ret := col + i - len(spaces); ret >= col {
+			return ret
+		}
+		return col
+	}
+	return -1
+}
+
+// inlineLines 计算块节点 block 的 Tokens 中每一行在原始输入中的位置。
+func (context *Context) inlineLines(block *ast.Node) (ret []inlineLine) {
+	if 1 > block.StartLn {
+		return
+	}
+
+	if ast.NodeTableCell == block.Type {
+		// 单元格在解析表时已经定位好了
+		return []inlineLine{{pos: 0, ln: block.StartLn, col: context.startCol(block), source: &context.sourceLines[block.StartLn-1]}}
+	}
+
+	if _, ok := context.consumedLines[block]; ok {
+		return context.consumedInlineLines(block)
+	}
+
+	ln, col := block.StartLn, context.startCol(block)
+	tokens := block.Tokens
+	length := len(tokens)
+	linesLen := len(context.sourceLines)
+	for pos := 0; pos < length; {
+		end := bytes.IndexByte(tokens[pos:], lex.ItemNewline)
+		if 0 > end {
+			end = length
+		} else {
+			end += pos
+		}
+
+		for ; ln <= linesLen; ln++ {
+			if c := context.findInLine(tokens[pos:end], ln, col); -1 < c {
+				ret = append(ret, inlineLine{pos: pos, ln: ln, col: c, source: &context.sourceLines[ln-1]})
+				break
+			}
+			col = 0
+		}
+		if ln > linesLen {
+			break
+		}
+		ln++
+		col = 0
+		pos = end + 1
+	}
+	return
+}
+
+// consumedInlineLines 根据块节点 block 解析时追加的行计算其 Tokens 中每一行在原始输入中的位置。
+func (context *Context) consumedInlineLines(block *ast.Node) (ret []inlineLine) {
+	tokens := block.Tokens
+	count := bytes.Count(tokens, []byte{lex.ItemNewline}) + 1
+	for i, pos := 0, 0; i < count; i++ {
+		end := bytes.IndexByte(tokens[pos:], lex.ItemNewline)
+		if 0 > end {
+			end = len(tokens)
+		} else {
+			end += pos
+		}
+
+		if ln, col := context.consumedLinePos(block, tokens[pos:end], i, count); 0 < ln {
+			ret = append(ret, inlineLine{pos: pos, ln: ln, col: col, source: &context.sourceLines[ln-1]})
+		}
+		pos = end + 1
+	}
+	return
+}
+
+// line 返回 Tokens 下标 pos 所在的行。
+func (ctx *InlineContext) line(pos int) *inlineLine {
+	i := sort.Search(len(ctx.lines), func(i int) bool { return ctx.lines[i].pos > pos })
+	if 0 < i {
+		i--
+	}
+	return &ctx.lines[i]
+}
+
+// setStart 将行级节点 n 的起始位置设置为 Tokens 下标 pos 处。
+func (ctx *InlineContext) setStart(n *ast.Node, pos int) {
+	if 1 > len(ctx.lines) {
+		return
+	}
+	l := ctx.line(pos)
+	col := l.source.sourceCol(l.col + pos - l.pos)
+	n.StartLn, n.StartCol, n.StartOffset = l.ln, col+1, l.source.offset+col
+}
+
+// setEnd 将行级节点 n 的结束位置设置为 Tokens 下标 pos 处（不包含）。
+func (ctx *InlineContext) setEnd(n *ast.Node, pos int) {
+	if 1 > len(ctx.lines) {
+		return
+	}
+	l := ctx.line(pos)
+	if 0 < pos && l.pos == pos {
+		// 结束位置在行首的话归属到上一行行尾
+		l = ctx.line(pos - 1)
+	}
+	col := l.source.sourceCol(l.col + pos - l.pos)
+	n.EndLn, n.EndCol, n.EndOffset = l.ln, col+1, l.source.offset+col
+}
+
+// setPos 将行级节点 n 的位置设置为 Tokens 下标区间 [start, end)。
+func (ctx *InlineContext) setPos(n *ast.Node, start, end int) {
+	ctx.setStart(n, start)
+	ctx.setEnd(n, end)
+}
+
+// copyStart 将节点 src 的起始位置复制给节点 dest。
+func copyStart(dest, src *ast.Node) {
+	dest.StartLn, dest.StartCol, dest.StartOffset = src.StartLn, src.StartCol, src.StartOffset
+}
+
+// copyEnd 将节点 src 的结束位置复制给节点 dest。
+func copyEnd(dest, src *ast.Node) {
+	dest.EndLn, dest.EndCol, dest.EndOffset = src.EndLn, src.EndCol, src.EndOffset
+}
+
+// moveStart 将节点 n 的起始位置在同一行内移动 d 个字节。
+func moveStart(n *ast.Node, d int) {
+	if 0 < n.StartLn {
+		n.StartCol += d
+		n.StartOffset += d
+	}
+}
+
+// moveEnd 将节点 n 的结束位置在同一行内移动 d 个字节。
+func moveEnd(n *ast.Node, d int) {
+	if 0 < n.EndLn {
+		n.EndCol += d
+		n.EndOffset += d
+	}
+}
+
+// sourceWidth 返回拆分文本节点后生成的行级节点 n 在原始输入中占用的字节数。
+func sourceWidth(n *ast.Node) (ret int) {
+	switch n.Type {
+	case ast.NodeText, ast.NodeLinkText, ast.NodeEmojiAlias, ast.NodeCrossRef, ast.NodeReference, ast.NodeCitation:
+		return len(n.Tokens)
+	}
+	for c := n.FirstChild; nil != c; c = c.Next {
+		ret += sourceWidth(c)
+	}
+	return
+}
+
+// repositionSplit 在文本节点 text 被拆分为多个兄弟节点后重新计算这些节点的位置。
+// 拆分结果位于 previous（不包含）和 next（不包含）之间，previous 为空时从 parent 的第一个子节点开始。
+func (context *Context) repositionSplit(text, parent, previous, next *ast.Node) {
+	if 1 > text.StartLn || len(context.sourceLines) < text.StartLn {
+		return
+	}
+
+	first := parent.FirstChild
+	if nil != previous {
+		first = previous.Next
+	}
+	ln, col := text.StartLn, context.startCol(text)
+	for n := first; nil != n && n != next; n = n.Next {
+		width := sourceWidth(n)
+		context.setStart(n, ln, col)
+		context.setEnd(n, ln, col+width)
+		if ast.NodeLink == n.Type {
+			if linkText := n.ChildByType(ast.NodeLinkText); nil != linkText {
+				copyStart(linkText, n)
+				copyEnd(linkText, n)
+			}
+		}
+		col += width
+	}
+}
+
+// fixPos 补全 node 及其子节点的位置：没有位置的节点优先使用子节点位置的并集，否则使用父节点的位置。
+func fixPos(node *ast.Node) {
+	unionPos(node)
+	inheritPos(node)
+}
+
+func unionPos(node *ast.Node) {
+	for c := node.FirstChild; nil != c; c = c.Next {
+		unionPos(c)
+	}
+	if 0 < node.StartLn {
+		if 1 > node.EndLn {
+			node.EndLn, node.EndCol, node.EndOffset = node.StartLn, node.StartCol, node.StartOffset
+		}
+		return
+	}
+
+	var first, last *ast.Node
+	for c := node.FirstChild; nil != c; c = c.Next {
+		if 0 < c.StartLn {
+			if nil == first {
+				first = c
+			}
+			last = c
+		}
+	}
+	if nil != first {
+		copyStart(node, first)
+		copyEnd(node, last)
+	}
+}
+
+func inheritPos(node *ast.Node) {
+	for c := node.FirstChild; nil != c; c = c.Next {
+		if 1 > c.StartLn {
+			copyStart(c, node)
+			copyEnd(c, node)
+		}
+		inheritPos(c)
+	}
+}
+
+// skipLines 将块节点 n 的起始位置定位到 n 的 Tokens 首行，用于块节点开头若干行被剔除的情况（比如链接引用定义）。
+func (context *Context) skipLines(n *ast.Node) {
+	if 1 > n.StartLn {
+		return
+	}
+	firstLine := n.Tokens
+	if i := bytes.IndexByte(firstLine, lex.ItemNewline); -1 < i {
+		firstLine = firstLine[:i]
+	}
+	if ln, col := context.consumedLinePos(n, firstLine, 0, bytes.Count(n.Tokens, []byte{lex.ItemNewline})+1); 0 < ln {
+		context.setStart(n, ln, col)
+	}
+}
+
+// tablePos 设置表 table 中各行、各单元格的位置，lines 为表的 Tokens 行，表头位于第 ln 行。
+func (context *Context) tablePos(table *ast.Node, lines [][]byte, ln int) {
+	if 1 > ln {
+		return
+	}
+
+	var rows []*ast.Node
+	var caption *ast.Node
+	for n := table.FirstChild; nil != n; n = n.Next {
+		if ast.NodeTableCaption == n.Type {
+			caption = n
+			continue
+		}
+		if ast.NodeTableHead == n.Type {
+			rows = append(rows, n.FirstChild)
+			continue
+		}
+		rows = append(rows, n)
+	}
+
+	if nil != caption {
+		// 表标题位于表的最后一行
+		captionLn := ln + len(lines) - 1
+		if captionLn <= len(context.sourceLines) {
+			if col := context.findInLine(caption.Tokens, captionLn, 0); -1 < col {
+				context.setStart(caption, captionLn, col)
+				context.setEnd(caption, captionLn, col+len(caption.Tokens))
+			}
+		}
+	}
+
+	linesLen := len(context.sourceLines)
+	lineIndex := 0
+	for i, row := range rows {
+		rowLn, line := ln+lineIndex, lines[lineIndex]
+		lineIndex++
+		if 0 == i {
+			lineIndex++ // 跳过分隔符行
+		}
+		endLn := rowLn
+		if 1 < row.TableRowLines {
+			endLn += row.TableRowLines - 1
+			lineIndex += row.TableRowLines - 1
+		}
+		if linesLen < endLn {
+			break
+		}
+
+		start := context.findInLine(lex.TrimWhitespace(line), rowLn, 0)
+		if 0 > start {
+			start = 0
+		}
+		src := context.sourceLines[rowLn-1].tokens
+		_, trimmed := lex.TrimRight(context.sourceLines[endLn-1].tokens)
+		context.setStart(row, rowLn, start)
+		context.setEnd(row, endLn, len(trimmed))
+		if 0 == i {
+			// 表头的起始位置也是表的起始位置
+			context.setStart(table, rowLn, start)
+			copyStart(row.Parent, row)
+			copyEnd(row.Parent, row)
+		}
+
+		cursor := start
+		for cell := row.FirstChild; nil != cell; cell = cell.Next {
+			if 1 > len(cell.Tokens) {
+				continue
+			}
+			col := bytes.Index(src[cursor:], cell.Tokens)
+			if 0 > col {
+				continue
+			}
+			col += cursor
+			context.setStart(cell, rowLn, col)
+			context.setEnd(cell, rowLn, col+len(cell.Tokens))
+			cursor = col + len(cell.Tokens)
+		}
+	}
+}
+
+// fencedCodeBlockPos 设置围栏代码块 block 的各个子节点的位置。
+func (context *Context) fencedCodeBlockPos(block, openMarker, info, code, closeMarker *ast.Node, closed bool) {
+	if 1 > block.StartLn || 1 > block.EndLn {
+		return
+	}
+
+	ln, col := block.StartLn, context.startCol(block)
+	context.setStart(openMarker, ln, col)
+	context.setEnd(openMarker, ln, col+len(openMarker.Tokens))
+	if 0 < len(info.CodeBlockInfo) {
+		if c := context.findInLine(info.CodeBlockInfo, ln, col+len(openMarker.Tokens)); -1 < c {
+			context.setStart(info, ln, c)
+			context.setEnd(info, ln, c+len(info.CodeBlockInfo))
+		}
+	}
+
+	last := block.EndLn
+	if closed && last > ln {
+		if c := context.findInLine(closeMarker.Tokens, last, 0); -1 < c {
+			context.setStart(closeMarker, last, c)
+			context.setEnd(closeMarker, last, c+len(closeMarker.Tokens))
+		}
+		last--
+	}
+	if first := ln + 1; first <= last {
+		context.setStart(code, first, 0)
+		context.setEnd(code, last, len(context.sourceLines[last-1].tokens))
+	}
+}
@@ -0,0 +1,203 @@
+// Lute - 一款对中文语境优化的 Markdown 引擎，支持 Go 和 JavaScript
+// Copyright (c) 2019-present, b3log.org
+//
+// Lute is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//         http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND, EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT, MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+package parse
+
+import (
+	"strings"
+
+	"lute/ast"
+	"lute/lex"
+	"lute/util"
+)
+
+// 引用类型。
+const (
+	ReferenceMention = "mention" // 提及 @username
+	ReferenceIssue   = "issue"   // 问题引用 #123 或者 owner/repo#123
+	ReferenceCommit  = "commit"  // 提交引用 SHA
+)
+
+// ReferenceResolver 描述了引用解析器，用于判断提及、问题引用和提交引用是否存在以及映射为链接地址。
+type ReferenceResolver interface {
+	// ResolveReference 返回类型为 typ 的引用对应的链接地址，repo 为 owner/repo 形式的仓库（未指定时为空），
+	// id 为用户名、问题编号或者提交 SHA，引用不存在时 exists 返回 false。
+	ResolveReference(typ, repo, id string) (url string, exists bool)
+}
+
+// ReferenceResolverFunc 将普通函数适配为 ReferenceResolver。
+type ReferenceResolverFunc func(typ, repo, id string) (url string, exists bool)
+
+func (f ReferenceResolverFunc) ResolveReference(typ, repo, id string) (url string, exists bool) {
+	return f(typ, repo, id)
+}
+
+// ResolveReference 使用 Option.ReferenceResolver 解析引用节点 node 的链接地址，没有设置解析器时引用均视为不存在。
+func (context *Context) ResolveReference(node *ast.Node) (url string, exists bool) {
+	if nil == context.Option.ReferenceResolver {
+		return "", false
+	}
+	return context.Option.ReferenceResolver.ResolveReference(node.ReferenceType, node.ReferenceRepo, node.ReferenceID)
+}
+
+// Mentions 返回树上所有被提及的用户名，按照在文档中第一次出现的顺序排列，重复的用户名只返回一次。
+// 设置了引用解析器时仅返回解析器判断为存在的用户。
+func (t *Tree) Mentions() (ret []string) {
+	mentions := map[string]bool{}
+	ast.Walk(t.Root, func(n *ast.Node, entering bool) ast.WalkStatus {
+		if !entering || ast.NodeReference != n.Type || ReferenceMention != n.ReferenceType || mentions[n.ReferenceID] {
+			return ast.WalkContinue
+		}
+		if nil != t.Context.Option.ReferenceResolver {
+			if _, exists := t.Context.ResolveReference(n); !exists {
+				return ast.WalkContinue
+			}
+		}
+		mentions[n.ReferenceID] = true
+		ret = append(ret, n.ReferenceID)
+		return ast.WalkContinue
+	})
+	return
+}
+
+// parseReferences 将 node 下文本节点中的提及、问题引用和提交引用替换为引用节点。
+func (t *Tree) parseReferences(node *ast.Node) {
+	for child := node.FirstChild; nil != child; {
+		next := child.Next
+		if ast.NodeText == child.Type {
+			previous := child.Previous
+			t.parseReferences0(child)
+			t.Context.repositionSplit(child, node, previous, next)
+			if 1 > len(child.Tokens) {
+				child.Unlink()
+			}
+		} else if ast.NodeReference != child.Type {
+			t.parseReferences(child) // 递归处理子节点
+		}
+		child = next
+	}
+}
+
+func (t *Tree) parseReferences0(node *ast.Node) {
+	tokens := node.Tokens
+	length := len(tokens)
+	current := node
+	pos := 0
+	for i := 0; i < length; {
+		if 0 < i && !isReferenceBoundaryBefore(tokens[i-1]) {
+			i++
+			continue
+		}
+
+		ref, n := t.matchReference(tokens[i:])
+		if nil == ref {
+			i++
+			continue
+		}
+
+		if current == node {
+			node.Tokens = tokens[pos:i]
+		} else if pos < i {
+			text := &ast.Node{Type: ast.NodeText, Tokens: tokens[pos:i]}
+			current.InsertAfter(text)
+			current = text
+		}
+		ref.Tokens = tokens[i : i+n]
+		current.InsertAfter(ref)
+		current = ref
+		i += n
+		pos = i
+	}
+	if current != node && pos < length {
+		current.InsertAfter(&ast.Node{Type: ast.NodeText, Tokens: tokens[pos:]})
+	}
+}
+
+// matchReference 匹配 tokens 开头的引用，返回引用节点和匹配的长度，不是引用时返回 nil。
+func (t *Tree) matchReference(tokens []byte) (ret *ast.Node, n int) {
+	option := t.Context.Option
+	if option.RepoIssueRef {
+		if repo, number := matchRepoIssueRef(tokens); 0 < len(number) {
+			n = len(repo) + 1 + len(number)
+			return &ast.Node{Type: ast.NodeReference, ReferenceType: ReferenceIssue, ReferenceRepo: util.BytesToStr(repo), ReferenceID: util.BytesToStr(number)}, n
+		}
+	}
+	if option.IssueRef && lex.ItemCrosshatch == tokens[0] {
+		if number := matchRefChars(tokens[1:], lex.IsDigit); 0 < len(number) {
+			return &ast.Node{Type: ast.NodeReference, ReferenceType: ReferenceIssue, ReferenceID: util.BytesToStr(number)}, 1 + len(number)
+		}
+	}
+	if option.Mention && '@' == tokens[0] {
+		if username := matchRefChars(tokens[1:], lex.IsASCIILetterNumHyphen); 0 < len(username) && 40 > len(username) && '-' != username[0] {
+			return &ast.Node{Type: ast.NodeReference, ReferenceType: ReferenceMention, ReferenceID: util.BytesToStr(username)}, 1 + len(username)
+		}
+	}
+	if option.CommitRef {
+		if sha := matchRefChars(tokens, lex.IsHexDigit); 7 <= len(sha) && 40 >= len(sha) && isCommitSHA(sha) {
+			return &ast.Node{Type: ast.NodeReference, ReferenceType: ReferenceCommit, ReferenceID: util.BytesToStr(sha)}, len(sha)
+		}
+	}
+	return nil, 0
+}
+
+// matchRepoIssueRef 匹配 owner/repo#123 形式的跨仓库问题引用。
+func matchRepoIssueRef(tokens []byte) (repo, number []byte) {
+	slash := 0
+	for ; slash < len(tokens) && lex.IsASCIILetterNumHyphen(tokens[slash]); slash++ {
+	}
+	if 1 > slash || len(tokens) <= slash || '/' != tokens[slash] || '-' == tokens[0] {
+		return nil, nil
+	}
+	name := matchRefChars(tokens[slash+1:], isRepoChar)
+	if 1 > len(name) {
+		return nil, nil
+	}
+	end := slash + 1 + len(name)
+	if len(tokens) <= end || lex.ItemCrosshatch != tokens[end] {
+		return nil, nil
+	}
+	if number = matchRefChars(tokens[end+1:], lex.IsDigit); 1 > len(number) {
+		return nil, nil
+	}
+	return tokens[:end], number
+}
+
+// matchRefChars 返回 tokens 开头由 accept 接受的字符组成的部分，后面紧跟字母、数字或者下划线时返回 nil。
+func matchRefChars(tokens []byte, accept func(byte) bool) []byte {
+	i := 0
+	for ; i < len(tokens) && accept(tokens[i]); i++ {
+	}
+	if i < len(tokens) && (lex.IsASCIILetterNum(tokens[i]) || '_' == tokens[i]) {
+		return nil
+	}
+	return tokens[:i]
+}
+
+// isReferenceBoundaryBefore 判断引用前面的字符是否允许，以避免匹配邮箱、URL 和单词中间的内容。
+func isReferenceBoundaryBefore(token byte) bool {
+	return !lex.IsASCIILetterNum(token) && -1 == strings.IndexByte("_-/@#&.:\\", token)
+}
+
+func isRepoChar(token byte) bool {
+	return lex.IsASCIILetterNumHyphen(token) || '_' == token || '.' == token
+}
+
+// isCommitSHA 判断十六进制字符串 sha 是否像是提交 SHA：需要同时包含数字和字母，以避免匹配 deadbeef 这样的单词和纯数字。
+func isCommitSHA(sha []byte) bool {
+	var digit, letter bool
+	for _, token := range sha {
+		if lex.IsDigit(token) {
+			digit = true
+		} else {
+			letter = true
+		}
+	}
+	return digit && letter
+}
@@ -0,0 +1,76 @@
+// Lute - 一款对中文语境优化的 Markdown 引擎，支持 Go 和 JavaScript
+// Copyright (c) 2019-present, b3log.org
+//
+// Lute is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//         http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND, EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT, MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+package parse
+
+import (
+	"bytes"
+	"strings"
+	"unicode/utf8"
+
+	"lute/ast"
+	"lute/lex"
+	"lute/util"
+)
+
+// parseRuby 解析注音 {漢字|かんじ} 和逐字注音 {汉字|hàn|zì}，不是注音时返回 nil。表格中的分隔符需要转义为 \|。
+func (t *Tree) parseRuby(block *ast.Node, ctx *InlineContext) *ast.Node {
+	tokens := ctx.tokens[ctx.pos+1:]
+	end := bytes.IndexByte(tokens, lex.ItemCloseBrace)
+	if 1 > end {
+		return nil
+	}
+	content := tokens[:end]
+	if bytes.ContainsAny(content, "{\n") {
+		return nil
+	}
+
+	parts := content
+	if ast.NodeTableCell == block.Type {
+		parts = bytes.ReplaceAll(parts, []byte("\\|"), []byte("|"))
+	}
+	bases, texts := RubyParts(util.BytesToStr(parts))
+	if nil == bases {
+		return nil
+	}
+
+	ctx.pos += 1 + end + 1
+	return &ast.Node{Type: ast.NodeRuby, Tokens: content, RubyBases: bases, RubyTexts: texts}
+}
+
+// RubyParts 将注音内容 content（比如 汉字|hàn|zì）拆分为一一对应的基文和注音文本。只有一个注音文本时整体注音，
+// 注音文本个数和基文字数相同时逐字注音，其他情况不是注音，返回 nil。
+//
+// 基文和注音文本不能为空，| 两侧也不能有空白，这样 {x | x > 0} 这样的集合写法不会被当作注音。
+func RubyParts(content string) (bases, texts []string) {
+	parts := strings.Split(content, "|")
+	if 2 > len(parts) {
+		return nil, nil
+	}
+	for _, part := range parts {
+		if "" == part || strings.TrimSpace(part) != part {
+			return nil, nil
+		}
+	}
+
+	base := parts[0]
+	texts = parts[1:]
+
+	if 1 == len(texts) {
+		return []string{base}, texts
+	}
+	if utf8.RuneCountInString(base) != len(texts) {
+		return nil, nil
+	}
+	for _, r := range base {
+		bases = append(bases, string(r))
+	}
+	return
+}
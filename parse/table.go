@@ -71,26 +71,63 @@ func (context *Context) parseTable0(tokens []byte) (ret *ast.Node) {
 		cells[0] = append(cells[0], n)
 	}
 
+	var caption *ast.Node
+	if context.Option.TableCaption && 2 < length {
+		if tokens := tableCaption(lines[length-1]); nil != tokens {
+			// 紧跟在表最后一行之后的 Table: 行作为表标题
+			caption = &ast.Node{Type: ast.NodeTableCaption, Tokens: tokens}
+			length--
+		}
+	}
+
 	ret = &ast.Node{Type: ast.NodeTable, TableAligns: aligns}
 	ret.TableAligns = aligns
+	if nil != caption {
+		ret.AppendChild(caption)
+		context.tableCaptionLabel(ret, caption)
+	}
 	ret.AppendChild(context.newTableHead(headRow))
 	for i := 2; i < length; i++ {
-		tableRow := context.parseTableRow(lex.TrimWhitespace(lines[i]), aligns, false)
+		rowLines := [][]byte{lex.TrimWhitespace(lines[i])}
+		if context.Option.TableSpan {
+			// 以 \ 结尾的表行由下一行继续
+			for ; i+1 < length && tableRowContinued(rowLines[len(rowLines)-1]); i++ {
+				last := rowLines[len(rowLines)-1]
+				rowLines[len(rowLines)-1] = lex.TrimWhitespace(last[:len(last)-1])
+				rowLines = append(rowLines, lex.TrimWhitespace(lines[i+1]))
+			}
+		}
+
+		var tableRow *ast.Node
+		if 1 < len(rowLines) {
+			tableRow = context.parseTableMultilineRow(rowLines, aligns)
+		} else {
+			tableRow = context.parseTableRow(rowLines[0], aligns, false)
+		}
 		if nil == tableRow {
 			return
 		}
 		ret.AppendChild(tableRow)
 
-		cells = append(cells, []*ast.Node{})
+		var row []*ast.Node
 		for n := tableRow.FirstChild; nil != n; n = n.Next {
-			cells[i-1] = append(cells[i-1], n)
+			row = append(row, n)
 		}
+		cells = append(cells, row)
+	}
+
+	if context.Option.TableSpan {
+		tableSpan(cells)
 	}
 
 	var maxWidth int
 	var maxWidthContent []byte
 	for col := 0; col < len(cells[0]); col++ {
 		for row := 0; row < len(cells); row++ {
+			if 1 < cells[row][col].TableCellColspan {
+				// 跨列单元格的宽度在下面单独分摊
+				continue
+			}
 			if maxWidth < cells[row][col].TableCellContentWidth {
 				maxWidth = cells[row][col].TableCellContentWidth
 				maxWidthContent = cells[row][col].Tokens
@@ -103,6 +140,96 @@ func (context *Context) parseTable0(tokens []byte) (ret *ast.Node) {
 		maxWidth = 0
 		maxWidthContent = nil
 	}
+
+	// 跨列单元格的内容超出所跨列的总宽度时加宽其最后一列
+	for row := 0; row < len(cells); row++ {
+		for col := 0; col < len(cells[row]); col++ {
+			cell := cells[row][col]
+			if 2 > cell.TableCellColspan || 0 != cell.TableCellSpanned {
+				continue
+			}
+			last := col + cell.TableCellColspan - 1
+			if diff := cell.TableCellContentWidth - TableCellSpanWidth(cell); 0 < diff {
+				for r := 0; r < len(cells); r++ {
+					cells[r][last].TableCellContentMaxWidth += diff
+				}
+			}
+		}
+	}
+	return
+}
+
+// tableRowContinued 判断表行 line 是否以未转义的 \ 结尾。
+func tableRowContinued(line []byte) bool {
+	backslashes := 0
+	for i := len(line) - 1; 0 <= i && lex.ItemBackslash == line[i]; i-- {
+		backslashes++
+	}
+	return 1 == backslashes%2
+}
+
+// tableSpan 根据单元格矩阵 cells（第一行为表头）计算合并单元格。
+func tableSpan(cells [][]*ast.Node) {
+	for row := 0; row < len(cells); row++ {
+		for col := 0; col < len(cells[row]); col++ {
+			cell := cells[row][col]
+			if 0 < row-1 && 0 == cell.TableCellSpanned && "^^" == string(cell.Tokens) {
+				// 表头不参与跨行，所以从表体的第二行开始处理 ^^
+				owner := cells[row-1][col]
+				for r := row - 2; 0 <= r && lex.ItemCaret == owner.TableCellSpanned; r-- {
+					owner = cells[r][col]
+				}
+				if 0 == owner.TableCellSpanned && tableRowSpanCovered(cells[row], col, owner.TableCellColspan) {
+					cell.TableCellSpanned = lex.ItemCaret
+					cell.Tokens = nil
+					cell.TableCellContent = nil
+					cell.TableCellContentWidth = 2
+					if 2 > owner.TableCellRowspan {
+						owner.TableCellRowspan = 1
+					}
+					owner.TableCellRowspan++
+				}
+			}
+
+			if lex.ItemPipe != cell.TableCellSpanned {
+				continue
+			}
+			owner := (*ast.Node)(nil)
+			for c := col - 1; 0 <= c; c-- {
+				if lex.ItemPipe != cells[row][c].TableCellSpanned {
+					owner = cells[row][c]
+					break
+				}
+			}
+			if nil == owner {
+				// 第一列没有可以合并的单元格，作为空单元格
+				cell.TableCellSpanned = 0
+				continue
+			}
+			if 2 > owner.TableCellColspan {
+				owner.TableCellColspan = 1
+			}
+			owner.TableCellColspan++
+		}
+	}
+}
+
+// tableRowSpanCovered 判断表行 row 中从 col 列开始的 ^^ 是否覆盖了上方跨 colspan 列的单元格：跨列时 ^^ 右侧的单元格必须都是 ||。
+func tableRowSpanCovered(row []*ast.Node, col, colspan int) bool {
+	for c := col + 1; c < col+colspan; c++ {
+		if len(row) <= c || lex.ItemPipe != row[c].TableCellSpanned {
+			return false
+		}
+	}
+	return true
+}
+
+// TableCellSpanWidth 返回跨列单元格 cell 在格式化时可用的内容宽度，包括被它合并的单元格的宽度和列之间的分隔空白。
+func TableCellSpanWidth(cell *ast.Node) (ret int) {
+	ret = cell.TableCellContentMaxWidth
+	for next := cell.Next; nil != next && lex.ItemPipe == next.TableCellSpanned; next = next.Next {
+		ret += next.TableCellContentMaxWidth + 2
+	}
 	return
 }
 
@@ -127,7 +254,8 @@ func (context *Context) parseTableRow(line []byte, aligns []int, isHead bool) (r
 	if lex.IsBlank(cols[0]) {
 		cols = cols[1:]
 	}
-	if len(cols) > 0 && lex.IsBlank(cols[len(cols)-1]) {
+	if len(cols) > 0 && lex.IsBlank(cols[len(cols)-1]) && !(context.Option.TableSpan && 1 < len(cols) && 0 == len(cols[len(cols)-1])) {
+		// 开启合并单元格时结尾的 || 表示最后一列和左侧单元格合并，不能作为结尾的空白去掉
 		cols = cols[:len(cols)-1]
 	}
 
@@ -145,6 +273,10 @@ func (context *Context) parseTableRow(line []byte, aligns []int, isHead bool) (r
 		cell := &ast.Node{Type: ast.NodeTableCell, TableCellAlign: aligns[i], TableCellContentWidth: width}
 		cell.Tokens = col
 		cell.TableCellContent = col
+		if context.Option.TableSpan && 0 == len(cols[i]) {
+			// 两个 | 之间没有任何字符时和左侧单元格合并
+			cell.TableCellSpanned = lex.ItemPipe
+		}
 		ret.AppendChild(cell)
 	}
 
@@ -156,6 +288,59 @@ func (context *Context) parseTableRow(line []byte, aligns []int, isHead bool) (r
 	return
 }
 
+// parseTableMultilineRow 解析由多行 lines 组成的表行，每一行中对应位置的单元格内容使用硬换行连接起来。
+func (context *Context) parseTableMultilineRow(lines [][]byte, aligns []int) (ret *ast.Node) {
+	var rows []*ast.Node
+	for _, line := range lines {
+		row := context.parseTableRow(line, aligns, false)
+		if nil == row {
+			return nil
+		}
+		rows = append(rows, row)
+	}
+
+	var cells [][]*ast.Node
+	for _, row := range rows {
+		var rowCells []*ast.Node
+		for c := row.FirstChild; nil != c; c = c.Next {
+			rowCells = append(rowCells, c)
+		}
+		cells = append(cells, rowCells)
+	}
+
+	ret = rows[0]
+	ret.TableRowLines = len(lines)
+	for col, cell := range cells[0] {
+		var contents [][]byte
+		spanned := true
+		for _, rowCells := range cells {
+			contents = append(contents, rowCells[col].Tokens)
+			spanned = spanned && lex.ItemPipe == rowCells[col].TableCellSpanned
+		}
+		if spanned {
+			continue
+		}
+		cell.TableCellSpanned = 0
+
+		for 0 < len(contents) && 1 > len(contents[0]) {
+			contents = contents[1:]
+		}
+		for 0 < len(contents) && 1 > len(contents[len(contents)-1]) {
+			contents = contents[:len(contents)-1]
+		}
+		width := 0
+		for _, content := range contents {
+			if width < len(content) {
+				width = len(content)
+			}
+		}
+		cell.Tokens = bytes.Join(contents, []byte("\\\n"))
+		cell.TableCellContent = cell.Tokens
+		cell.TableCellContentWidth = width
+	}
+	return
+}
+
 func (context *Context) parseTableDelimRow(line []byte) (aligns []int) {
 	length := len(line)
 	if 1 > length {
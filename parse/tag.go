@@ -0,0 +1,109 @@
+// Lute - 一款对中文语境优化的 Markdown 引擎，支持 Go 和 JavaScript
+// Copyright (c) 2019-present, b3log.org
+//
+// Lute is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//         http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND, EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT, MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+package parse
+
+import (
+	"bytes"
+	"net/url"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"lute/ast"
+	"lute/lex"
+	"lute/util"
+)
+
+// TagPlaceholder 是标签链接地址模板中的标签名占位符。
+const TagPlaceholder = "${tag}"
+
+// TagURL 使用 Option.TagURLTemplate 生成标签节点 node 的链接地址，标签名会进行路径转义，没有设置模板时返回空字符串。
+func (context *Context) TagURL(node *ast.Node) string {
+	if "" == context.Option.TagURLTemplate {
+		return ""
+	}
+	return strings.ReplaceAll(context.Option.TagURLTemplate, TagPlaceholder, url.PathEscape(util.BytesToStr(node.Tokens)))
+}
+
+// Tags 返回树上所有的标签名，按照在文档中第一次出现的顺序排列，重复的标签只返回一次。
+func (t *Tree) Tags() (ret []string) {
+	tags := map[string]bool{}
+	ast.Walk(t.Root, func(n *ast.Node, entering bool) ast.WalkStatus {
+		if entering && ast.NodeTag == n.Type {
+			if tag := util.BytesToStr(n.Tokens); !tags[tag] {
+				tags[tag] = true
+				ret = append(ret, tag)
+			}
+		}
+		return ast.WalkContinue
+	})
+	return
+}
+
+// parseTag 解析标签 #tag 和使用 # 闭合的标签 #multi word tag#，不是标签时返回 nil。
+func (t *Tree) parseTag(ctx *InlineContext) *ast.Node {
+	if 0 < ctx.pos {
+		// # 前面只能是空白、非 ASCII 字符或者左括号、引号，以避免和 C#、URL 锚点、{#id} 等冲突
+		before, _ := utf8.DecodeLastRune(ctx.tokens[:ctx.pos])
+		if utf8.RuneSelf > before && !unicode.IsSpace(before) && '(' != before && '"' != before && '\'' != before {
+			return nil
+		}
+	}
+
+	tokens := ctx.tokens[ctx.pos+1:]
+	if end := bytes.IndexByte(tokens, lex.ItemNewline); -1 < end {
+		tokens = tokens[:end]
+	}
+
+	// 优先尝试闭合写法，闭合写法中可以包含空格，但是开头的 # 之后和结尾的 # 之前不能是空白
+	if end := bytes.IndexByte(tokens, lex.ItemCrosshatch); 0 < end {
+		content := tokens[:end]
+		after := utf8.RuneError
+		if end+1 < len(tokens) {
+			after, _ = utf8.DecodeRune(tokens[end+1:])
+		}
+		if !lex.IsWhitespace(content[0]) && !lex.IsWhitespace(content[len(content)-1]) && !isASCIILetterOrDigit(after) && !isDigits(content) {
+			ctx.pos += 1 + end + 1
+			return &ast.Node{Type: ast.NodeTag, Tokens: content, TagClosed: true}
+		}
+	}
+
+	var end int
+	for end < len(tokens) {
+		r, size := utf8.DecodeRune(tokens[end:])
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) && !unicode.Is(unicode.Mark, r) && '_' != r && '-' != r && '/' != r {
+			break
+		}
+		end += size
+	}
+	for 0 < end && ('-' == tokens[end-1] || '/' == tokens[end-1]) {
+		end--
+	}
+	content := tokens[:end]
+	if 1 > len(content) || isDigits(content) {
+		return nil
+	}
+	ctx.pos += 1 + end
+	return &ast.Node{Type: ast.NodeTag, Tokens: content}
+}
+
+func isASCIILetterOrDigit(r rune) bool {
+	return utf8.RuneSelf > r && lex.IsASCIILetterNum(byte(r))
+}
+
+func isDigits(tokens []byte) bool {
+	for _, token := range tokens {
+		if !lex.IsDigit(token) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,57 @@
+// Lute - 一款对中文语境优化的 Markdown 引擎，支持 Go 和 JavaScript
+// Copyright (c) 2019-present, b3log.org
+//
+// Lute is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//         http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND, EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT, MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+package parse
+
+import (
+	"strings"
+
+	"lute/ast"
+)
+
+// TaskListItem 描述了文档中的一个任务列表项。
+type TaskListItem struct {
+	Index   int    // 在文档中的序号，从 0 开始，和渲染的复选框 data-task-index 属性对应
+	Text    string // 任务文本
+	State   string // 方括号中的状态字符，比如 " "、"x" 或者 "-"
+	Checked bool   // 是否勾选
+	Path    []int  // 嵌套路径，从最外层开始每一级列表项在所在列表中的序号（从 0 开始）
+	Offset  int    // 标记符 [ 在原始 Markdown 中的字节偏移
+}
+
+// TaskListItems 返回树上所有的任务列表项，按照在文档中出现的顺序排列，不包括嵌入文档中的任务列表项。
+func (t *Tree) TaskListItems() (ret []*TaskListItem) {
+	ast.Walk(t.Root, func(n *ast.Node, entering bool) ast.WalkStatus {
+		if !entering || ast.NodeTaskListItemMarker != n.Type || 0 > n.TaskListItemIndex {
+			// 跳过嵌入文档中只读的任务列表项
+			return ast.WalkContinue
+		}
+
+		item := &TaskListItem{Index: n.TaskListItemIndex, State: string(n.TaskListItemState), Checked: n.TaskListItemChecked, Offset: n.StartOffset}
+		if paragraph := n.Parent; nil != paragraph {
+			item.Text = strings.TrimSpace(paragraph.Text())
+		}
+		for p := n.Parent; nil != p; p = p.Parent {
+			if ast.NodeListItem != p.Type {
+				continue
+			}
+			i := 0
+			for prev := p.Previous; nil != prev; prev = prev.Previous {
+				if ast.NodeListItem == prev.Type {
+					i++
+				}
+			}
+			item.Path = append([]int{i}, item.Path...)
+		}
+		ret = append(ret, item)
+		return ast.WalkSkipChildren
+	})
+	return
+}
@@ -33,6 +33,14 @@ func (t *Tree) isMarker(token byte) bool {
 	case lex.ItemAsterisk, lex.ItemUnderscore, lex.ItemOpenBracket, lex.ItemBang, lex.ItemNewline, lex.ItemBackslash, lex.ItemBacktick, lex.ItemLess,
 		lex.ItemCloseBracket, lex.ItemAmpersand, lex.ItemTilde, lex.ItemDollar:
 		return true
+	case lex.ItemEqual:
+		return t.Context.Option.Mark
+	case lex.ItemCrosshatch:
+		return t.Context.Option.Tag
+	case lex.ItemOpenBrace:
+		return t.Context.Option.Ruby
+	case lex.ItemCaret:
+		return t.Context.Option.Sup || (t.Context.Option.Footnotes && t.Context.Option.InlineFootnotes)
 	default:
 		return false
 	}
@@ -55,6 +63,7 @@ func (t *Tree) parseBackslash(block *ast.Node, ctx *InlineContext) *ast.Node {
 	if lex.IsASCIIPunct(token) {
 		ctx.pos++
 		n := &ast.Node{Type: ast.NodeBackslash}
+		ctx.setPos(n, ctx.pos-2, ctx.pos)
 		block.AppendChild(n)
 		n.AppendChild(&ast.Node{Type: ast.NodeBackslashContent, Tokens: []byte{token}})
 		return nil
@@ -72,6 +81,7 @@ func (t *Tree) parseNewline(block *ast.Node, ctx *InlineContext) (ret *ast.Node)
 		tokens := lastc.Tokens
 		if valueLen := len(tokens); lex.ItemSpace == tokens[valueLen-1] {
 			_, lastc.Tokens = lex.TrimRight(tokens)
+			moveEnd(lastc, len(lastc.Tokens)-valueLen)
 			if 1 < valueLen {
 				isHardBreak = lex.ItemSpace == tokens[len(tokens)-2]
 			}
@@ -94,6 +104,9 @@ func (t *Tree) mergeText(node *ast.Node) {
 			// 逐个合并后续兄弟节点
 			for nil != next && ast.NodeText == next.Type {
 				child.AppendTokens(next.Tokens)
+				if 0 < next.EndLn {
+					copyEnd(child, next)
+				}
 				next.Unlink()
 				next = child.Next
 			}
@@ -16,6 +16,13 @@ import (
 	"lute/lex"
 )
 
+// 目录类型
+const (
+	ToCHeadings = "toc" // 标题目录 [toc]
+	ToCTables   = "lot" // 表目录 [lot]
+	ToCFigures  = "lof" // 图目录 [lof]
+)
+
 func (context *Context) parseToC(paragraph *ast.Node) *ast.Node {
 	lines := lex.Split(paragraph.Tokens, lex.ItemNewline)
 	if 1 != len(lines) {
@@ -27,8 +34,17 @@ func (context *Context) parseToC(paragraph *ast.Node) *ast.Node {
 	if context.Option.VditorWYSIWYG {
 		content = bytes.ReplaceAll(content, []byte(Caret), nil)
 	}
-	if !bytes.EqualFold(content, []byte("[toc]")) {
-		return nil
+	if bytes.EqualFold(content, []byte("[toc]")) {
+		return &ast.Node{Type: ast.NodeToC, Tokens: tokens, ToCType: ToCHeadings}
+	}
+	if context.Option.CaptionNumbering {
+		// 打开表和图编号时支持 [lot] 表目录和 [lof] 图目录
+		if bytes.EqualFold(content, []byte("[lot]")) {
+			return &ast.Node{Type: ast.NodeToC, Tokens: tokens, ToCType: ToCTables}
+		}
+		if bytes.EqualFold(content, []byte("[lof]")) {
+			return &ast.Node{Type: ast.NodeToC, Tokens: tokens, ToCType: ToCFigures}
+		}
 	}
-	return &ast.Node{Type: ast.NodeToC, Tokens: tokens}
+	return nil
 }
@@ -0,0 +1,282 @@
+// Lute - 一款对中文语境优化的 Markdown 引擎，支持 Go 和 JavaScript
+// Copyright (c) 2019-present, b3log.org
+//
+// Lute is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//         http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND, EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT, MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+package parse
+
+import (
+	"bytes"
+	pathpkg "path"
+	"strconv"
+	"strings"
+
+	"lute/ast"
+	"lute/lex"
+)
+
+// ContentLoader 描述了嵌入文档的内容加载器。
+type ContentLoader interface {
+	// LoadContent 返回路径 path 对应文档的 Markdown 原始文本。
+	LoadContent(path string) ([]byte, error)
+}
+
+// ContentLoaderFunc 将普通函数适配为 ContentLoader。
+type ContentLoaderFunc func(path string) ([]byte, error)
+
+func (f ContentLoaderFunc) LoadContent(path string) ([]byte, error) {
+	return f(path)
+}
+
+var includeMarker = []byte("!include ")
+
+// transclusion 判断段落内容 tokens 是否是嵌入指令 !include path.md#heading 或者 ![[page#heading|label]]，
+// 是的话返回被嵌入文档的路径和章节标题，不嵌入章节时标题为空。![[page]] 中的页面名称没有扩展名时补全为 page.md。
+func transclusion(tokens []byte) (path, heading string, ok bool) {
+	tokens = lex.TrimWhitespace(tokens)
+	if -1 < bytes.IndexByte(tokens, lex.ItemNewline) {
+		return
+	}
+
+	var target string
+	wiki := false
+	if bytes.HasPrefix(tokens, includeMarker) {
+		target = string(tokens[len(includeMarker):])
+	} else if bytes.HasPrefix(tokens, []byte("![[")) && bytes.HasSuffix(tokens, []byte("]]")) && 5 < len(tokens) {
+		target = string(tokens[3 : len(tokens)-2])
+		if i := strings.IndexByte(target, '|'); -1 < i {
+			target = target[:i]
+		}
+		wiki = true
+	} else {
+		return
+	}
+
+	path = target
+	if i := strings.IndexByte(target, '#'); -1 < i {
+		path, heading = target[:i], strings.TrimSpace(target[i+1:])
+	}
+	if path = strings.TrimSpace(path); "" == path {
+		return
+	}
+	if wiki && "" == pathpkg.Ext(path) {
+		path += ".md"
+	}
+	ok = true
+	return
+}
+
+// include 描述了一个嵌入指令。
+type include struct {
+	paragraph *ast.Node // 嵌入指令所在的段落
+	path      string    // 被嵌入文档的路径
+	heading   string    // 被嵌入章节的标题，嵌入整个文档时为空
+}
+
+// includes 返回树上所有独占一段的嵌入指令，需要在解析行级节点之前调用，因为之后段落的 Tokens 会被清空。
+func (t *Tree) includes() (ret []*include) {
+	ast.Walk(t.Root, func(n *ast.Node, entering bool) ast.WalkStatus {
+		if !entering || ast.NodeParagraph != n.Type {
+			return ast.WalkContinue
+		}
+		if path, heading, ok := transclusion(n.Tokens); ok {
+			ret = append(ret, &include{paragraph: n, path: path, heading: heading})
+		}
+		return ast.WalkSkipChildren
+	})
+	return
+}
+
+// transclude 将嵌入指令 includes 所在的段落替换为被嵌入文档或者其中一个章节的内容。
+//
+// 被嵌入的文档使用独立的上下文解析，链接引用定义只在该文档内生效，脚注定义合并到主文档时会重命名和主文档冲突的标签，
+// 前置元数据不会嵌入，任务列表项只读，被嵌入节点的位置都是嵌入指令的位置。
+// 循环嵌入、超过最大嵌套层数或者加载失败时保留嵌入指令并记录诊断信息。
+func (t *Tree) transclude(includes []*include) {
+	for _, directive := range includes {
+		p, path, heading := directive.paragraph, directive.path, directive.heading
+		if 0 < len(t.Context.includes) && !pathpkg.IsAbs(path) {
+			// 嵌入文档中的相对路径相对于该嵌入文档
+			path = pathpkg.Join(pathpkg.Dir(t.Name), path)
+		}
+
+		chain := append(append([]string{}, t.Context.includes...), t.Name)
+		if t.Context.includeCycle(p, chain, path) {
+			continue
+		}
+		if t.Context.Option.MaxIncludeDepth < len(chain) {
+			t.Context.diagnose(p, "maximum include depth ["+strconv.Itoa(t.Context.Option.MaxIncludeDepth)+"] exceeded when including ["+path+"]")
+			continue
+		}
+		if nil == t.Context.Option.ContentLoader {
+			t.Context.diagnose(p, "content loader is not set, can not include ["+path+"]")
+			continue
+		}
+		content, err := t.Context.Option.ContentLoader.LoadContent(path)
+		if nil != err {
+			t.Context.diagnose(p, "load ["+path+"] failed: "+err.Error())
+			continue
+		}
+
+		included := parse(path, content, t.Context.Option, chain)
+		for _, diagnostic := range included.Context.Diagnostics {
+			if "" == diagnostic.Path {
+				diagnostic.Path = path
+			}
+			t.Context.Diagnostics = append(t.Context.Diagnostics, diagnostic)
+		}
+
+		var nodes []*ast.Node
+		if "" == heading {
+			for n := included.Root.FirstChild; nil != n; n = n.Next {
+				if ast.NodeFrontMatter == n.Type {
+					// 被嵌入文档的前置元数据不嵌入
+					continue
+				}
+				nodes = append(nodes, n)
+			}
+		} else if nodes = included.section(heading); nil == nodes {
+			t.Context.diagnose(p, "heading ["+heading+"] not found in ["+path+"]")
+			continue
+		}
+		defs := len(t.Context.FootnotesDefs)
+		nodes = t.mergeFootnotes(included, nodes)
+		for _, def := range t.Context.FootnotesDefs[defs:] {
+			directivePos(def, p)
+		}
+		for _, n := range nodes {
+			inlineLinkRefs(n)
+			readonlyTaskListItems(n)
+			directivePos(n, p)
+			p.InsertBefore(n)
+		}
+		p.Unlink()
+	}
+}
+
+// includeCycle 判断在 includes 路径链上嵌入 path 是否会导致循环嵌入，是的话记录诊断信息。
+func (context *Context) includeCycle(node *ast.Node, includes []string, path string) bool {
+	var chain []string
+	cycle := false
+	for _, include := range includes {
+		if "" == include {
+			continue
+		}
+		chain = append(chain, include)
+		cycle = cycle || include == path
+	}
+	if cycle {
+		context.diagnose(node, "include cycle ["+strings.Join(append(chain, path), " -> ")+"]")
+	}
+	return cycle
+}
+
+// section 返回文档中标题文本或者标题 ID 为 heading 的章节，包括该标题以及之后直到同级或者更高级标题之前的块节点。
+func (t *Tree) section(heading string) (ret []*ast.Node) {
+	level := 0
+	for n := t.Root.FirstChild; nil != n; n = n.Next {
+		if nil == ret {
+			if ast.NodeHeading == n.Type && (strings.EqualFold(strings.TrimSpace(n.Text()), heading) || heading == strings.TrimPrefix(string(n.HeadingID), "#")) {
+				ret = append(ret, n)
+				level = n.HeadingLevel
+			}
+			continue
+		}
+		if ast.NodeHeading == n.Type && n.HeadingLevel <= level {
+			break
+		}
+		ret = append(ret, n)
+	}
+	return
+}
+
+// mergeFootnotes 将被嵌入文档 included 中位于 nodes 内或者被 nodes 引用的脚注定义合并到主文档，返回需要嵌入的节点。
+func (t *Tree) mergeFootnotes(included *Tree, nodes []*ast.Node) []*ast.Node {
+	embedded := map[*ast.Node]bool{}
+	for _, n := range nodes {
+		ast.Walk(n, func(child *ast.Node, entering bool) ast.WalkStatus {
+			if entering && (ast.NodeFootnotesRef == child.Type || ast.NodeFootnotesDef == child.Type) {
+				embedded[child] = true
+			}
+			return ast.WalkContinue
+		})
+	}
+
+	for _, def := range included.Context.FootnotesDefs {
+		var refs []*ast.Node
+		for _, ref := range def.FootnotesRefs {
+			if embedded[ref] {
+				refs = append(refs, ref)
+			}
+		}
+		if !embedded[def] {
+			if 1 > len(refs) {
+				continue
+			}
+			if nil == def.FootnotesInline {
+				nodes = append(nodes, def)
+			}
+		}
+
+		label := def.Tokens
+		for i := 1; ; i++ {
+			if _, exist := t.Context.FindFootnotesDef(label); nil == exist {
+				break
+			}
+			label = []byte(string(def.Tokens) + "-" + strconv.Itoa(i))
+		}
+		def.Tokens = label
+		t.Context.FootnotesDefs = append(t.Context.FootnotesDefs, def)
+		def.FootnotesRefs = refs
+		for i, ref := range refs {
+			ref.Tokens = bytes.ToLower(label)
+			ref.FootnotesRefLabel = label
+			ref.FootnotesRefId = strconv.Itoa(len(t.Context.FootnotesDefs))
+			if 0 < i {
+				ref.FootnotesRefId += ":" + strconv.Itoa(i+1)
+			}
+		}
+	}
+	return nodes
+}
+
+// inlineLinkRefs 将 node 下的引用链接转换为内联链接，因为被嵌入文档的链接引用定义不会合并到主文档。
+func inlineLinkRefs(node *ast.Node) {
+	ast.Walk(node, func(n *ast.Node, entering bool) ast.WalkStatus {
+		if !entering || (ast.NodeLink != n.Type && ast.NodeImage != n.Type) || 3 != n.LinkType {
+			return ast.WalkContinue
+		}
+		n.LinkType = 0
+		if title := n.ChildByType(ast.NodeLinkTitle); nil != title && nil == n.ChildByType(ast.NodeLinkSpace) {
+			title.InsertBefore(&ast.Node{Type: ast.NodeLinkSpace, Tokens: []byte{lex.ItemSpace}})
+		}
+		return ast.WalkContinue
+	})
+}
+
+// directivePos 将被嵌入的节点 node 及其子节点的位置设置为嵌入指令所在段落 directive 的位置，
+// 节点在被嵌入文档中的位置对于主文档没有意义，主文档中针对这些节点的诊断信息和源码行号都指向嵌入指令。
+func directivePos(node, directive *ast.Node) {
+	ast.Walk(node, func(n *ast.Node, entering bool) ast.WalkStatus {
+		if entering {
+			copyStart(n, directive)
+			copyEnd(n, directive)
+		}
+		return ast.WalkContinue
+	})
+}
+
+// readonlyTaskListItems 将 node 下的任务列表项序号置为 -1，被嵌入文档中的任务列表项不在主文档中，不能通过序号和偏移修改。
+func readonlyTaskListItems(node *ast.Node) {
+	ast.Walk(node, func(n *ast.Node, entering bool) ast.WalkStatus {
+		if entering && ast.NodeTaskListItemMarker == n.Type {
+			n.TaskListItemIndex = -1
+		}
+		return ast.WalkContinue
+	})
+}
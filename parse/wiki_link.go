@@ -0,0 +1,96 @@
+// Lute - 一款对中文语境优化的 Markdown 引擎，支持 Go 和 JavaScript
+// Copyright (c) 2019-present, b3log.org
+//
+// Lute is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//         http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND, EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT, MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+package parse
+
+import (
+	"bytes"
+	neturl "net/url"
+	"strings"
+
+	"lute/ast"
+	"lute/lex"
+	"lute/util"
+)
+
+// WikiLinkResolver 描述了维基链接解析器，用于将维基链接的页面名称和锚点映射为链接地址。
+type WikiLinkResolver interface {
+	// ResolveWikiLink 返回页面 page 中锚点 anchor 对应的链接地址，页面不存在时 exists 返回 false。
+	ResolveWikiLink(page, anchor string) (url string, exists bool)
+}
+
+// WikiLinkResolverFunc 将普通函数适配为 WikiLinkResolver。
+type WikiLinkResolverFunc func(page, anchor string) (url string, exists bool)
+
+func (f WikiLinkResolverFunc) ResolveWikiLink(page, anchor string) (url string, exists bool) {
+	return f(page, anchor)
+}
+
+// ResolveWikiLink 使用 Option.WikiLinkResolver 解析维基链接节点 node 的链接地址，没有设置解析器时使用转义后的页面名称和锚点拼接。
+func (context *Context) ResolveWikiLink(node *ast.Node) (url string, exists bool) {
+	if nil != context.Option.WikiLinkResolver {
+		return context.Option.WikiLinkResolver.ResolveWikiLink(node.WikiLinkPage, node.WikiLinkAnchor)
+	}
+
+	segments := strings.Split(node.WikiLinkPage, "/")
+	for i, segment := range segments {
+		segments[i] = neturl.PathEscape(segment)
+	}
+	url = strings.Join(segments, "/")
+	if "" != node.WikiLinkAnchor {
+		url += "#" + neturl.PathEscape(node.WikiLinkAnchor)
+	}
+	return url, true
+}
+
+// WikiLinks 返回树上所有的维基链接节点，按照在文档中出现的顺序排列。
+func (t *Tree) WikiLinks() (ret []*ast.Node) {
+	ast.Walk(t.Root, func(n *ast.Node, entering bool) ast.WalkStatus {
+		if entering && ast.NodeWikiLink == n.Type {
+			ret = append(ret, n)
+		}
+		return ast.WalkContinue
+	})
+	return
+}
+
+// parseWikiLink 解析维基链接 [[Page]]、[[Page|label]] 和 [[Page#Heading]]，不是维基链接时返回 nil。
+func (t *Tree) parseWikiLink(ctx *InlineContext) *ast.Node {
+	tokens := ctx.tokens[ctx.pos:]
+	if 4 > len(tokens) || lex.ItemOpenBracket != tokens[1] {
+		return nil
+	}
+
+	end := bytes.Index(tokens[2:], []byte("]]"))
+	if 1 > end {
+		return nil
+	}
+	content := tokens[2 : 2+end]
+	if bytes.ContainsAny(content, "[]\n") {
+		return nil
+	}
+
+	target, label := content, []byte(nil)
+	if pipe := bytes.IndexByte(content, lex.ItemPipe); 0 <= pipe {
+		target, label = content[:pipe], lex.TrimWhitespace(content[pipe+1:])
+	}
+	target = lex.TrimWhitespace(target)
+	page, anchor := target, []byte(nil)
+	if crosshatch := bytes.IndexByte(target, lex.ItemCrosshatch); 0 <= crosshatch {
+		page, anchor = lex.TrimWhitespace(target[:crosshatch]), lex.TrimWhitespace(target[crosshatch+1:])
+	}
+	if 1 > len(page) && 1 > len(anchor) {
+		return nil
+	}
+
+	ctx.pos += 2 + end + 2
+	return &ast.Node{Type: ast.NodeWikiLink, Tokens: content,
+		WikiLinkPage: util.BytesToStr(page), WikiLinkAnchor: util.BytesToStr(anchor), WikiLinkLabel: util.BytesToStr(label)}
+}
@@ -0,0 +1,138 @@
+// Lute - 一款对中文语境优化的 Markdown 引擎，支持 Go 和 JavaScript
+// Copyright (c) 2019-present, b3log.org
+//
+// Lute is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//         http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND, EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT, MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+package render
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"lute/ast"
+	"lute/util"
+)
+
+// 简繁转换方向
+const (
+	ChineseS2T = "s2t" // 简体转繁体
+	ChineseT2S = "t2s" // 繁体转简体
+)
+
+var t2sChars map[rune]rune
+var s2tMaxPhraseLen, t2sMaxPhraseLen int
+
+func init() {
+	t2sChars = make(map[rune]rune, len(s2tChars)+len(t2sExtraChars))
+	for k, v := range s2tChars {
+		t2sChars[v] = k
+	}
+	for k, v := range t2sExtraChars {
+		t2sChars[k] = v
+	}
+	// 著 在简体中仍然用于著作、显著等，只有 t2sPhrases 中的词语才转换为着
+	delete(t2sChars, '著')
+	s2tMaxPhraseLen = maxPhraseLen(s2tPhrases)
+	t2sMaxPhraseLen = maxPhraseLen(t2sPhrases)
+}
+
+func maxPhraseLen(phrases map[string]string) (ret int) {
+	for k := range phrases {
+		if length := utf8.RuneCountInString(k); ret < length {
+			ret = length
+		}
+	}
+	return
+}
+
+// ConvertChinese 会把文本节点 textNode 中的中文按照 Option.ChineseConvert 指定的方向进行简繁转换。
+func (r *BaseRenderer) ConvertChinese(textNode *ast.Node) {
+	text := util.BytesToStr(textNode.Tokens)
+	text = ConvertChinese0(text, r.Option.ChineseConvert)
+	textNode.Tokens = util.StrToBytes(text)
+}
+
+// ConvertChinese0 将 text 按照 direction（ChineseS2T 或者 ChineseT2S）进行简繁转换。先按照词表进行最长匹配，匹配不到时再逐字转换，
+// 文本中的 URL 保持不变。
+func ConvertChinese0(text, direction string) string {
+	var chars map[rune]rune
+	var phrases map[string]string
+	var maxLen int
+	switch direction {
+	case ChineseS2T:
+		chars, phrases, maxLen = s2tChars, s2tPhrases, s2tMaxPhraseLen
+	case ChineseT2S:
+		chars, phrases, maxLen = t2sChars, t2sPhrases, t2sMaxPhraseLen
+	default:
+		return text
+	}
+
+	runes := []rune(text)
+	length := len(runes)
+	buf := &strings.Builder{}
+	buf.Grow(len(text))
+	for i := 0; i < length; {
+		if urlLen := urlPrefixLen(runes[i:]); 0 < urlLen && (0 == i || !isURLRune(runes[i-1])) {
+			buf.WriteString(string(runes[i : i+urlLen]))
+			i += urlLen
+			continue
+		}
+
+		matched := false
+		for l := maxLen; 1 < l; l-- {
+			if length < i+l {
+				continue
+			}
+			if phrase, ok := phrases[string(runes[i:i+l])]; ok {
+				buf.WriteString(phrase)
+				i += l
+				matched = true
+				break
+			}
+		}
+		if matched {
+			continue
+		}
+
+		if c, ok := chars[runes[i]]; ok {
+			buf.WriteRune(c)
+		} else {
+			buf.WriteRune(runes[i])
+		}
+		i++
+	}
+	return buf.String()
+}
+
+// urlPrefixLen 返回 runes 开头的 URL 长度，URL 到空白或者中文标点为止，不是以 http://、https:// 或者 www. 开头时返回 0。
+func urlPrefixLen(runes []rune) (ret int) {
+	if !hasRunesPrefix(runes, "http://") && !hasRunesPrefix(runes, "https://") && !hasRunesPrefix(runes, "www.") {
+		return 0
+	}
+	for ret < len(runes) && !unicode.IsSpace(runes[ret]) && !isCJKPunct(runes[ret]) {
+		ret++
+	}
+	return
+}
+
+// isCJKPunct 判断 r 是否是中日韩符号或者全角标点。
+func isCJKPunct(r rune) bool {
+	return (0x3000 <= r && 0x303F >= r) || (0xFF01 <= r && 0xFF0F >= r) || (0xFF1A <= r && 0xFF20 >= r) || (0xFF3B <= r && 0xFF40 >= r) || (0xFF5B <= r && 0xFF65 >= r)
+}
+
+func hasRunesPrefix(runes []rune, prefix string) bool {
+	if len(runes) < len(prefix) {
+		return false
+	}
+	return strings.EqualFold(string(runes[:len(prefix)]), prefix)
+}
+
+func isURLRune(r rune) bool {
+	return utf8.RuneSelf > r && (unicode.IsLetter(r) || unicode.IsDigit(r))
+}
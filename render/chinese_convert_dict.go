@@ -0,0 +1,433 @@
+// Lute - 一款对中文语境优化的 Markdown 引擎，支持 Go 和 JavaScript
+// Copyright (c) 2019-present, b3log.org
+//
+// Lute is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//         http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND, EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT, MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+package render
+
+// s2tChars 定义了简体字到繁体字（台湾标准）的单字转换表，一简对多繁时取最常用的写法，其他写法通过 s2tPhrases 中的词语修正。
+var s2tChars = map[rune]rune{
+	'万': '萬', '与': '與', '丑': '醜', '专': '專', '业': '業', '丛': '叢', '东': '東', '丝': '絲', '两': '兩', '严': '嚴',
+	'丧': '喪', '个': '個', '丰': '豐', '临': '臨', '为': '為', '丽': '麗', '举': '舉', '么': '麼', '义': '義', '乌': '烏',
+	'乐': '樂', '乔': '喬', '习': '習', '乡': '鄉', '书': '書', '买': '買', '乱': '亂', '争': '爭', '于': '於', '亏': '虧',
+	'云': '雲', '亚': '亞', '产': '產', '亩': '畝', '亲': '親', '亿': '億', '仅': '僅', '仆': '僕', '从': '從', '仑': '侖',
+	'仓': '倉', '仪': '儀', '们': '們', '价': '價', '众': '眾', '优': '優', '会': '會', '伞': '傘', '伟': '偉', '传': '傳',
+	'伤': '傷', '伦': '倫', '伪': '偽', '伫': '佇', '体': '體', '余': '餘', '佣': '傭', '侠': '俠', '侣': '侶', '侥': '僥',
+	'侦': '偵', '侧': '側', '侨': '僑', '侬': '儂', '俩': '倆', '俭': '儉', '债': '債', '倾': '傾', '偿': '償', '储': '儲',
+	'儿': '兒', '兑': '兌', '党': '黨', '兰': '蘭', '关': '關', '兴': '興', '养': '養', '兽': '獸', '内': '內', '冈': '岡',
+	'册': '冊', '写': '寫', '军': '軍', '农': '農', '冯': '馮', '冲': '衝', '决': '決', '况': '況', '冻': '凍', '净': '淨',
+	'凄': '淒', '准': '準', '凉': '涼', '减': '減', '凑': '湊', '凛': '凜', '几': '幾', '凤': '鳳', '凫': '鳧', '凭': '憑',
+	'凯': '凱', '击': '擊', '凿': '鑿', '划': '劃', '刘': '劉', '则': '則', '刚': '剛', '创': '創', '删': '刪', '别': '別',
+	'刽': '劊', '剂': '劑', '剐': '剮', '剑': '劍', '剥': '剝', '剧': '劇', '劝': '勸', '办': '辦', '务': '務', '动': '動',
+	'励': '勵', '劲': '勁', '劳': '勞', '势': '勢', '勋': '勳', '匀': '勻', '匮': '匱', '区': '區', '医': '醫', '华': '華',
+	'协': '協', '单': '單', '卖': '賣', '卢': '盧', '卤': '滷', '卧': '臥', '卫': '衛', '却': '卻', '厂': '廠', '厅': '廳',
+	'历': '歷', '厉': '厲', '压': '壓', '厌': '厭', '厕': '廁', '厘': '釐', '厢': '廂', '厦': '廈', '厨': '廚', '县': '縣',
+	'参': '參', '双': '雙', '发': '發', '变': '變', '叙': '敘', '叠': '疊', '叶': '葉', '号': '號', '叹': '嘆', '叽': '嘰',
+	'后': '後', '吓': '嚇', '吕': '呂', '吗': '嗎', '吨': '噸', '听': '聽', '启': '啟', '吴': '吳', '呓': '囈', '呕': '嘔',
+	'呗': '唄', '员': '員', '呛': '嗆', '呜': '嗚', '咏': '詠', '咙': '嚨', '咸': '鹹', '响': '響', '哑': '啞', '哗': '嘩',
+	'哟': '喲', '唠': '嘮', '唤': '喚', '啧': '嘖', '啬': '嗇', '啰': '囉', '啸': '嘯', '喷': '噴', '喽': '嘍', '嗳': '噯',
+	'嘘': '噓', '嘱': '囑', '噜': '嚕', '嚣': '囂', '团': '團', '园': '園', '围': '圍', '囵': '圇', '国': '國', '图': '圖',
+	'圆': '圓', '圣': '聖', '场': '場', '坏': '壞', '块': '塊', '坚': '堅', '坛': '壇', '坝': '壩', '坞': '塢', '坟': '墳',
+	'坠': '墜', '垄': '壟', '垒': '壘', '垦': '墾', '垩': '堊', '垫': '墊', '堑': '塹', '堕': '墮', '墙': '牆', '壮': '壯',
+	'声': '聲', '壳': '殼', '壶': '壺', '处': '處', '备': '備', '复': '復', '够': '夠', '头': '頭', '夸': '誇', '夹': '夾',
+	'夺': '奪', '奁': '奩', '奂': '奐', '奋': '奮', '奖': '獎', '妆': '妝', '妇': '婦', '妈': '媽', '妩': '嫵', '妪': '嫗',
+	'娄': '婁', '娅': '婭', '娆': '嬈', '娇': '嬌', '娱': '娛', '娲': '媧', '娴': '嫻', '婴': '嬰', '婵': '嬋', '婶': '嬸',
+	'嫔': '嬪', '孙': '孫', '学': '學', '孪': '孿', '宁': '寧', '宝': '寶', '实': '實', '宠': '寵', '审': '審', '宪': '憲',
+	'宽': '寬', '宾': '賓', '寝': '寢', '对': '對', '寻': '尋', '导': '導', '寿': '壽', '将': '將', '尔': '爾', '尘': '塵',
+	'尝': '嘗', '尧': '堯', '尴': '尷', '尸': '屍', '尽': '盡', '层': '層', '屉': '屜', '届': '屆', '属': '屬', '屡': '屢',
+	'屿': '嶼', '岁': '歲', '岂': '豈', '岖': '嶇', '岗': '崗', '岚': '嵐', '岛': '島', '岭': '嶺', '峡': '峽', '峥': '崢',
+	'峦': '巒', '崭': '嶄', '嵘': '嶸', '巅': '巔', '巩': '鞏', '币': '幣', '帅': '帥', '师': '師', '帏': '幃', '帐': '帳',
+	'帘': '簾', '帜': '幟', '带': '帶', '帧': '幀', '帮': '幫', '帼': '幗', '幂': '冪', '并': '並', '广': '廣', '庄': '莊',
+	'庆': '慶', '庐': '廬', '库': '庫', '应': '應', '庙': '廟', '庞': '龐', '废': '廢', '开': '開', '异': '異', '弃': '棄',
+	'张': '張', '弥': '彌', '弯': '彎', '弹': '彈', '强': '強', '归': '歸', '当': '當', '录': '錄', '彦': '彥', '彻': '徹',
+	'径': '徑', '忆': '憶', '忏': '懺', '忧': '憂', '怀': '懷', '态': '態', '怂': '慫', '怅': '悵', '怜': '憐', '总': '總',
+	'恋': '戀', '恒': '恆', '恳': '懇', '恶': '惡', '恺': '愷', '恻': '惻', '恼': '惱', '悦': '悅', '悬': '懸', '悯': '憫',
+	'惊': '驚', '惧': '懼', '惨': '慘', '惩': '懲', '惫': '憊', '惬': '愜', '惭': '慚', '惮': '憚', '惯': '慣', '愠': '慍',
+	'愤': '憤', '愿': '願', '慑': '懾', '懑': '懣', '懒': '懶', '戏': '戲', '战': '戰', '户': '戶', '扑': '撲', '执': '執',
+	'扩': '擴', '扫': '掃', '扬': '揚', '扰': '擾', '抚': '撫', '抛': '拋', '抠': '摳', '抡': '掄', '抢': '搶', '护': '護',
+	'报': '報', '担': '擔', '拟': '擬', '拢': '攏', '拣': '揀', '拥': '擁', '拦': '攔', '拧': '擰', '拨': '撥', '择': '擇',
+	'挂': '掛', '挚': '摯', '挞': '撻', '挟': '挾', '挠': '撓', '挡': '擋', '挣': '掙', '挤': '擠', '挥': '揮', '捞': '撈',
+	'损': '損', '捡': '撿', '换': '換', '捣': '搗', '据': '據', '掳': '擄', '掴': '摑', '掷': '擲', '掸': '撣', '掺': '摻',
+	'揽': '攬', '搀': '攙', '搁': '擱', '搂': '摟', '搅': '攪', '携': '攜', '摄': '攝', '摆': '擺', '摇': '搖', '摈': '擯',
+	'摊': '攤', '撵': '攆', '撷': '擷', '撸': '擼', '攒': '攢', '敌': '敵', '敛': '斂', '数': '數', '斓': '斕', '斩': '斬',
+	'断': '斷', '无': '無', '旧': '舊', '时': '時', '旷': '曠', '昙': '曇', '昼': '晝', '显': '顯', '晋': '晉', '晒': '曬',
+	'晓': '曉', '晕': '暈', '晖': '暉', '暂': '暫', '暧': '曖', '术': '術', '朴': '樸', '机': '機', '杀': '殺', '杂': '雜',
+	'权': '權', '杠': '槓', '条': '條', '来': '來', '杨': '楊', '杰': '傑', '极': '極', '构': '構', '枢': '樞', '枣': '棗',
+	'枪': '槍', '枫': '楓', '枭': '梟', '柜': '櫃', '柠': '檸', '栅': '柵', '标': '標', '栈': '棧', '栉': '櫛', '栋': '棟',
+	'栏': '欄', '树': '樹', '栖': '棲', '样': '樣', '栾': '欒', '桠': '椏', '桢': '楨', '档': '檔', '桥': '橋', '桦': '樺',
+	'桧': '檜', '桨': '槳', '桩': '樁', '梦': '夢', '检': '檢', '棂': '欞', '椁': '槨', '椭': '橢', '楼': '樓', '榄': '欖',
+	'榇': '櫬', '榈': '櫚', '榉': '櫸', '槛': '檻', '槟': '檳', '横': '橫', '樱': '櫻', '橱': '櫥', '橹': '櫓', '欢': '歡',
+	'欤': '歟', '欧': '歐', '歼': '殲', '殁': '歿', '殇': '殤', '残': '殘', '殒': '殞', '殓': '殮', '殡': '殯', '殴': '毆',
+	'毁': '毀', '毕': '畢', '毙': '斃', '毡': '氈', '气': '氣', '氢': '氫', '汇': '匯', '汉': '漢', '汤': '湯', '汹': '洶',
+	'沟': '溝', '没': '沒', '沥': '瀝', '沦': '淪', '沧': '滄', '沪': '滬', '泞': '濘', '泪': '淚', '泸': '瀘', '泻': '瀉',
+	'泼': '潑', '泽': '澤', '泾': '涇', '洁': '潔', '洒': '灑', '洼': '窪', '浅': '淺', '浆': '漿', '浇': '澆', '浊': '濁',
+	'测': '測', '济': '濟', '浏': '瀏', '浑': '渾', '浒': '滸', '浓': '濃', '浔': '潯', '涂': '塗', '涌': '湧', '涛': '濤',
+	'涝': '澇', '涟': '漣', '涡': '渦', '涣': '渙', '涤': '滌', '润': '潤', '涧': '澗', '涨': '漲', '涩': '澀', '淀': '澱',
+	'渊': '淵', '渍': '漬', '渎': '瀆', '渐': '漸', '渔': '漁', '渗': '滲', '温': '溫', '湾': '灣', '湿': '濕', '溃': '潰',
+	'溅': '濺', '滚': '滾', '滞': '滯', '满': '滿', '滢': '瀅', '滤': '濾', '滥': '濫', '滦': '灤', '滨': '濱', '滩': '灘',
+	'潇': '瀟', '潍': '濰', '潜': '潛', '澜': '瀾', '濑': '瀨', '濒': '瀕', '灭': '滅', '灯': '燈', '灵': '靈', '灾': '災',
+	'灿': '燦', '炉': '爐', '炖': '燉', '炜': '煒', '点': '點', '炼': '煉', '炽': '熾', '烁': '爍', '烂': '爛', '烃': '烴',
+	'烛': '燭', '烟': '煙', '烦': '煩', '烧': '燒', '烨': '燁', '烩': '燴', '烫': '燙', '烬': '燼', '热': '熱', '焕': '煥',
+	'焖': '燜', '爱': '愛', '爷': '爺', '牦': '犛', '牵': '牽', '牺': '犧', '犊': '犢', '状': '狀', '犷': '獷', '犹': '猶',
+	'狈': '狽', '狞': '獰', '独': '獨', '狭': '狹', '狮': '獅', '狰': '猙', '狱': '獄', '猎': '獵', '猕': '獼', '猪': '豬',
+	'猫': '貓', '献': '獻', '獭': '獺', '玑': '璣', '玛': '瑪', '玮': '瑋', '环': '環', '现': '現', '玺': '璽', '珐': '琺',
+	'珑': '瓏', '琐': '瑣', '琼': '瓊', '瑶': '瑤', '瓮': '甕', '瓯': '甌', '电': '電', '画': '畫', '畅': '暢', '疖': '癤',
+	'疗': '療', '疟': '瘧', '疡': '瘍', '疮': '瘡', '疯': '瘋', '疱': '皰', '痈': '癰', '痉': '痙', '痒': '癢', '痨': '癆',
+	'痪': '瘓', '痫': '癇', '痴': '癡', '瘘': '瘻', '瘪': '癟', '瘫': '癱', '瘾': '癮', '癞': '癩', '癣': '癬', '癫': '癲',
+	'皑': '皚', '皱': '皺', '皲': '皸', '盏': '盞', '盐': '鹽', '监': '監', '盖': '蓋', '盗': '盜', '盘': '盤', '眯': '瞇',
+	'着': '著', '睁': '睜', '睐': '睞', '睑': '瞼', '瞒': '瞞', '瞩': '矚', '矫': '矯', '矶': '磯', '矾': '礬', '矿': '礦',
+	'码': '碼', '砖': '磚', '砚': '硯', '砺': '礪', '砾': '礫', '础': '礎', '硕': '碩', '确': '確', '碍': '礙', '碛': '磧',
+	'碱': '鹼', '礼': '禮', '祎': '禕', '祯': '禎', '祷': '禱', '祸': '禍', '禀': '稟', '禄': '祿', '禅': '禪', '离': '離',
+	'秃': '禿', '秆': '稈', '种': '種', '积': '積', '称': '稱', '秽': '穢', '税': '稅', '稣': '穌', '稳': '穩', '穑': '穡',
+	'穷': '窮', '窃': '竊', '窍': '竅', '窑': '窯', '窜': '竄', '窝': '窩', '窥': '窺', '窦': '竇', '竖': '豎', '竞': '競',
+	'笃': '篤', '笋': '筍', '笔': '筆', '笺': '箋', '笼': '籠', '筑': '築', '筚': '篳', '筛': '篩', '筝': '箏', '筹': '籌',
+	'签': '簽', '简': '簡', '箧': '篋', '箩': '籮', '箪': '簞', '箫': '簫', '篓': '簍', '篮': '籃', '篱': '籬', '籁': '籟',
+	'籴': '糴', '类': '類', '籼': '秈', '粜': '糶', '粤': '粵', '粪': '糞', '粮': '糧', '紧': '緊', '絷': '縶', '纠': '糾',
+	'红': '紅', '纤': '纖', '约': '約', '级': '級', '纪': '紀', '纫': '紉', '纬': '緯', '纯': '純', '纱': '紗', '纲': '綱',
+	'纳': '納', '纵': '縱', '纶': '綸', '纷': '紛', '纸': '紙', '纹': '紋', '纺': '紡', '纽': '紐', '线': '線', '练': '練',
+	'组': '組', '绅': '紳', '细': '細', '织': '織', '终': '終', '绊': '絆', '绍': '紹', '绎': '繹', '经': '經', '绑': '綁',
+	'绒': '絨', '结': '結', '绕': '繞', '绘': '繪', '给': '給', '绚': '絢', '络': '絡', '绝': '絕', '绞': '絞', '统': '統',
+	'绢': '絹', '绣': '繡', '继': '繼', '绩': '績', '绪': '緒', '绫': '綾', '续': '續', '绮': '綺', '绯': '緋', '绰': '綽',
+	'绳': '繩', '维': '維', '绵': '綿', '绷': '繃', '绸': '綢', '综': '綜', '绽': '綻', '绿': '綠', '缀': '綴', '缄': '緘',
+	'缅': '緬', '缆': '纜', '缉': '緝', '缎': '緞', '缓': '緩', '缔': '締', '缕': '縷', '编': '編', '缘': '緣', '缙': '縉',
+	'缚': '縛', '缜': '縝', '缝': '縫', '缠': '纏', '缢': '縊', '缤': '繽', '缨': '纓', '缩': '縮', '缪': '繆', '缫': '繅',
+	'缭': '繚', '缮': '繕', '缰': '韁', '缴': '繳', '网': '網', '罗': '羅', '罚': '罰', '罢': '罷', '翘': '翹', '耸': '聳',
+	'聂': '聶', '聋': '聾', '职': '職', '联': '聯', '聪': '聰', '肃': '肅', '肠': '腸', '肤': '膚', '肮': '骯', '肾': '腎',
+	'肿': '腫', '胀': '脹', '胁': '脅', '胆': '膽', '胜': '勝', '胧': '朧', '胶': '膠', '脉': '脈', '脏': '髒', '脑': '腦',
+	'脓': '膿', '脚': '腳', '脱': '脫', '脸': '臉', '腊': '臘', '腻': '膩', '腾': '騰', '舰': '艦', '舱': '艙', '艰': '艱',
+	'艳': '豔', '艺': '藝', '节': '節', '芜': '蕪', '芦': '蘆', '苇': '葦', '苍': '蒼', '苏': '蘇', '苹': '蘋', '范': '範',
+	'茎': '莖', '茧': '繭', '荐': '薦', '荚': '莢', '荟': '薈', '荡': '蕩', '荣': '榮', '荤': '葷', '荧': '熒', '荫': '蔭',
+	'药': '藥', '莱': '萊', '莲': '蓮', '获': '獲', '莹': '瑩', '萤': '螢', '营': '營', '萧': '蕭', '萨': '薩', '葱': '蔥',
+	'蒋': '蔣', '蓝': '藍', '蔷': '薔', '虏': '虜', '虑': '慮', '虫': '蟲', '虽': '雖', '虾': '蝦', '蚀': '蝕', '蚂': '螞',
+	'蚕': '蠶', '蛮': '蠻', '蜕': '蛻', '蜗': '蝸', '蜡': '蠟', '蝇': '蠅', '衅': '釁', '衔': '銜', '补': '補', '衬': '襯',
+	'袄': '襖', '袅': '裊', '袜': '襪', '袭': '襲', '装': '裝', '裤': '褲', '见': '見', '观': '觀', '规': '規', '觅': '覓',
+	'视': '視', '览': '覽', '觉': '覺', '觊': '覬', '觎': '覦', '觐': '覲', '觑': '覷', '触': '觸', '誉': '譽', '誊': '謄',
+	'计': '計', '订': '訂', '讣': '訃', '认': '認', '讥': '譏', '讦': '訐', '讧': '訌', '讨': '討', '让': '讓', '讪': '訕',
+	'讫': '訖', '训': '訓', '议': '議', '讯': '訊', '记': '記', '讲': '講', '讳': '諱', '讴': '謳', '讵': '詎', '讶': '訝',
+	'讷': '訥', '许': '許', '讹': '訛', '论': '論', '讼': '訟', '讽': '諷', '设': '設', '访': '訪', '诀': '訣', '证': '證',
+	'诂': '詁', '诃': '訶', '评': '評', '诅': '詛', '识': '識', '诈': '詐', '诉': '訴', '诊': '診', '诋': '詆', '词': '詞',
+	'诏': '詔', '译': '譯', '诓': '誆', '试': '試', '诗': '詩', '诘': '詰', '诙': '詼', '诚': '誠', '诛': '誅', '话': '話',
+	'诞': '誕', '诟': '詬', '诠': '詮', '诡': '詭', '询': '詢', '诣': '詣', '诤': '諍', '该': '該', '详': '詳', '诧': '詫',
+	'诩': '詡', '诫': '誡', '诬': '誣', '语': '語', '误': '誤', '诰': '誥', '诱': '誘', '诲': '誨', '诳': '誑', '说': '說',
+	'诵': '誦', '请': '請', '诸': '諸', '诺': '諾', '读': '讀', '诽': '誹', '课': '課', '诿': '諉', '谀': '諛', '谁': '誰',
+	'调': '調', '谄': '諂', '谅': '諒', '谆': '諄', '谈': '談', '谊': '誼', '谋': '謀', '谍': '諜', '谎': '謊', '谏': '諫',
+	'谐': '諧', '谑': '謔', '谒': '謁', '谓': '謂', '谕': '諭', '谗': '讒', '谘': '諮', '谙': '諳', '谚': '諺', '谛': '諦',
+	'谜': '謎', '谟': '謨', '谢': '謝', '谣': '謠', '谤': '謗', '谥': '諡', '谦': '謙', '谧': '謐', '谨': '謹', '谩': '謾',
+	'谪': '謫', '谬': '謬', '谭': '譚', '谱': '譜', '谴': '譴', '谵': '譫', '谶': '讖', '贝': '貝', '贞': '貞', '负': '負',
+	'贡': '貢', '财': '財', '责': '責', '贤': '賢', '败': '敗', '账': '賬', '货': '貨', '质': '質', '贩': '販', '贪': '貪',
+	'贫': '貧', '贬': '貶', '购': '購', '贮': '貯', '贯': '貫', '贰': '貳', '贱': '賤', '贴': '貼', '贵': '貴', '贷': '貸',
+	'贸': '貿', '费': '費', '贺': '賀', '贻': '貽', '贼': '賊', '贾': '賈', '贿': '賄', '赁': '賃', '赂': '賂', '赃': '贓',
+	'资': '資', '赈': '賑', '赊': '賒', '赋': '賦', '赌': '賭', '赎': '贖', '赏': '賞', '赐': '賜', '赔': '賠', '赖': '賴',
+	'赘': '贅', '赚': '賺', '赛': '賽', '赞': '贊', '赠': '贈', '赡': '贍', '赢': '贏', '赣': '贛', '赵': '趙', '赶': '趕',
+	'趋': '趨', '跃': '躍', '践': '踐', '踊': '踴', '踪': '蹤', '躯': '軀', '车': '車', '轧': '軋', '轨': '軌', '轩': '軒',
+	'转': '轉', '轮': '輪', '软': '軟', '轰': '轟', '轴': '軸', '轻': '輕', '载': '載', '轿': '轎', '较': '較', '辅': '輔',
+	'辆': '輛', '辈': '輩', '辉': '輝', '辐': '輻', '辑': '輯', '输': '輸', '辖': '轄', '辗': '輾', '辙': '轍', '辞': '辭',
+	'边': '邊', '辽': '遼', '达': '達', '迁': '遷', '过': '過', '迈': '邁', '运': '運', '还': '還', '这': '這', '进': '進',
+	'远': '遠', '违': '違', '连': '連', '迟': '遲', '迹': '跡', '适': '適', '选': '選', '逊': '遜', '递': '遞', '逻': '邏',
+	'遗': '遺', '遥': '遙', '邓': '鄧', '邮': '郵', '邹': '鄒', '邻': '鄰', '郁': '鬱', '郑': '鄭', '酝': '醞', '酱': '醬',
+	'酿': '釀', '释': '釋', '鉴': '鑑', '针': '針', '钉': '釘', '钓': '釣', '钗': '釵', '钙': '鈣', '钛': '鈦', '钝': '鈍',
+	'钞': '鈔', '钟': '鐘', '钠': '鈉', '钢': '鋼', '钥': '鑰', '钦': '欽', '钧': '鈞', '钨': '鎢', '钩': '鉤', '钮': '鈕',
+	'钱': '錢', '钳': '鉗', '钴': '鈷', '钹': '鈸', '钻': '鑽', '钾': '鉀', '铀': '鈾', '铁': '鐵', '铂': '鉑', '铃': '鈴',
+	'铄': '鑠', '铅': '鉛', '铆': '鉚', '铐': '銬', '铛': '鐺', '铜': '銅', '铝': '鋁', '铬': '鉻', '铭': '銘', '铮': '錚',
+	'铰': '鉸', '铲': '鏟', '铵': '銨', '银': '銀', '铸': '鑄', '铺': '鋪', '链': '鏈', '销': '銷', '锁': '鎖', '锂': '鋰',
+	'锄': '鋤', '锅': '鍋', '锈': '鏽', '锋': '鋒', '锌': '鋅', '锐': '銳', '错': '錯', '锚': '錨', '锡': '錫', '锣': '鑼',
+	'锤': '錘', '锥': '錐', '锦': '錦', '锭': '錠', '键': '鍵', '锯': '鋸', '锰': '錳', '锹': '鍬', '锻': '鍛', '镀': '鍍',
+	'镁': '鎂', '镇': '鎮', '镊': '鑷', '镍': '鎳', '镑': '鎊', '镖': '鏢', '镜': '鏡', '镭': '鐳', '镯': '鐲', '镰': '鐮',
+	'镶': '鑲', '长': '長', '门': '門', '闩': '閂', '闪': '閃', '闭': '閉', '问': '問', '闯': '闖', '闰': '閏', '闲': '閒',
+	'间': '間', '闷': '悶', '闸': '閘', '闹': '鬧', '闺': '閨', '闻': '聞', '闽': '閩', '阀': '閥', '阁': '閣', '阂': '閡',
+	'阅': '閱', '阉': '閹', '阎': '閻', '阐': '闡', '阑': '闌', '阔': '闊', '阙': '闕', '队': '隊', '阳': '陽', '阴': '陰',
+	'阵': '陣', '阶': '階', '际': '際', '陆': '陸', '陇': '隴', '陈': '陳', '陕': '陝', '陨': '隕', '险': '險', '随': '隨',
+	'隐': '隱', '隶': '隸', '难': '難', '雾': '霧', '霉': '黴', '静': '靜', '韦': '韋', '韧': '韌', '韩': '韓', '韵': '韻',
+	'页': '頁', '顶': '頂', '顷': '頃', '项': '項', '顺': '順', '须': '須', '顽': '頑', '顾': '顧', '顿': '頓', '颁': '頒',
+	'颂': '頌', '预': '預', '颅': '顱', '领': '領', '颇': '頗', '颈': '頸', '颊': '頰', '颐': '頤', '频': '頻', '颓': '頹',
+	'颖': '穎', '颗': '顆', '题': '題', '颚': '顎', '颜': '顏', '额': '額', '颠': '顛', '颤': '顫', '风': '風', '飒': '颯',
+	'飘': '飄', '飞': '飛', '饥': '飢', '饪': '飪', '饭': '飯', '饮': '飲', '饯': '餞', '饰': '飾', '饱': '飽', '饲': '飼',
+	'饴': '飴', '饵': '餌', '饶': '饒', '饷': '餉', '饺': '餃', '饼': '餅', '饿': '餓', '馁': '餒', '馅': '餡', '馆': '館',
+	'馈': '饋', '馊': '餿', '馋': '饞', '馍': '饃', '馒': '饅', '马': '馬', '驭': '馭', '驮': '馱', '驯': '馴', '驰': '馳',
+	'驱': '驅', '驳': '駁', '驴': '驢', '驶': '駛', '驹': '駒', '驻': '駐', '驼': '駝', '驾': '駕', '驿': '驛', '骁': '驍',
+	'骂': '罵', '骄': '驕', '骆': '駱', '骇': '駭', '验': '驗', '骏': '駿', '骑': '騎', '骗': '騙', '骚': '騷', '骡': '騾',
+	'骤': '驟', '骥': '驥', '鱼': '魚', '鱿': '魷', '鲁': '魯', '鲈': '鱸', '鲍': '鮑', '鲑': '鮭', '鲜': '鮮', '鲢': '鰱',
+	'鲤': '鯉', '鲨': '鯊', '鲫': '鯽', '鲶': '鯰', '鲸': '鯨', '鳄': '鱷', '鳍': '鰭', '鳕': '鱈', '鳗': '鰻', '鳞': '鱗',
+	'鸟': '鳥', '鸠': '鳩', '鸡': '雞', '鸣': '鳴', '鸥': '鷗', '鸦': '鴉', '鸭': '鴨', '鸯': '鴦', '鸳': '鴛', '鸵': '鴕',
+	'鸽': '鴿', '鸿': '鴻', '鹂': '鸝', '鹃': '鵑', '鹅': '鵝', '鹉': '鵡', '鹊': '鵲', '鹌': '鵪', '鹏': '鵬', '鹑': '鶉',
+	'鹤': '鶴', '鹦': '鸚', '鹫': '鷲', '鹭': '鷺', '鹰': '鷹', '麦': '麥', '黄': '黃', '齐': '齊', '齿': '齒', '龄': '齡',
+	'龈': '齦', '龋': '齲', '龙': '龍', '龟': '龜',
+}
+
+// t2sExtraChars 定义了 s2tChars 反转后没有覆盖到的繁体字到简体字的单字转换表，主要是一简对多繁中的非默认写法。
+var t2sExtraChars = map[rune]rune{
+	'乾': '干', '佔': '占', '併': '并', '係': '系', '傢': '家', '儘': '尽', '噁': '恶', '夥': '伙', '嶽': '岳', '幹': '干',
+	'彙': '汇', '徵': '征', '慾': '欲', '捲': '卷', '採': '采', '摺': '折', '曆': '历', '檯': '台', '沖': '冲', '瀰': '弥',
+	'甦': '苏', '癥': '症', '瞭': '了', '祇': '只', '禦': '御', '穀': '谷', '穫': '获', '籤': '签', '籲': '吁', '糰': '团',
+	'紮': '扎', '繫': '系', '纔': '才', '罈': '坛', '臟': '脏', '臺': '台', '衊': '蔑', '裏': '里', '裡': '里', '製': '制',
+	'複': '复', '託': '托', '誌': '志', '讚': '赞', '蹟': '迹', '週': '周', '遊': '游', '錶': '表', '鍾': '钟', '閑': '闲',
+	'隻': '只', '鞦': '秋', '韆': '千', '颱': '台', '颳': '刮', '髮': '发', '鬆': '松', '鬍': '胡', '鬚': '须', '鬥': '斗',
+	'麵': '面',
+}
+
+// s2tPhrases 定义了简体转繁体时需要按词语转换的词表，用于处理一简对多繁。
+var s2tPhrases = map[string]string{
+	"一只":   "一隻",
+	"不准":   "不准",
+	"丑角":   "丑角",
+	"两只":   "兩隻",
+	"主干":   "主幹",
+	"书签":   "書籤",
+	"争斗":   "爭鬥",
+	"事迹":   "事蹟",
+	"五谷":   "五穀",
+	"伙伴":   "夥伴",
+	"倒霉":   "倒楣",
+	"假发":   "假髮",
+	"公历":   "公曆",
+	"关系":   "關係",
+	"内脏":   "內臟",
+	"农历":   "農曆",
+	"冲刷":   "沖刷",
+	"冲水":   "沖水",
+	"冲泡":   "沖泡",
+	"冲洗":   "沖洗",
+	"冲淡":   "沖淡",
+	"冲澡":   "沖澡",
+	"准予":   "准予",
+	"准许":   "准許",
+	"凉面":   "涼麵",
+	"划不来":  "划不來",
+	"划得来":  "划得來",
+	"划桨":   "划槳",
+	"划算":   "划算",
+	"划船":   "划船",
+	"刮风":   "颳風",
+	"制作":   "製作",
+	"制品":   "製品",
+	"制成":   "製成",
+	"制造":   "製造",
+	"前仆后继": "前仆後繼",
+	"包扎":   "包紮",
+	"占据":   "佔據",
+	"占有":   "佔有",
+	"占用":   "佔用",
+	"占领":   "佔領",
+	"卷入":   "捲入",
+	"卷发":   "捲髮",
+	"卷起":   "捲起",
+	"历法":   "曆法",
+	"反复":   "反覆",
+	"发丝":   "髮絲",
+	"发型":   "髮型",
+	"发廊":   "髮廊",
+	"古迹":   "古蹟",
+	"只身":   "隻身",
+	"台灯":   "檯燈",
+	"台风":   "颱風",
+	"合伙":   "合夥",
+	"同伙":   "同夥",
+	"后妃":   "后妃",
+	"周刊":   "週刊",
+	"周年":   "週年",
+	"周末":   "週末",
+	"呼吁":   "呼籲",
+	"咸阳":   "咸陽",
+	"哪里":   "哪裡",
+	"回复":   "回覆",
+	"城里":   "城裡",
+	"复习":   "複習",
+	"复制":   "複製",
+	"复印":   "複印",
+	"复合":   "複合",
+	"复数":   "複數",
+	"复杂":   "複雜",
+	"复查":   "複查",
+	"复述":   "複述",
+	"夜里":   "夜裡",
+	"天后":   "天后",
+	"太后":   "太后",
+	"头发":   "頭髮",
+	"奇迹":   "奇蹟",
+	"奋斗":   "奮鬥",
+	"委托":   "委託",
+	"家伙":   "傢伙",
+	"家里":   "家裡",
+	"宽松":   "寬鬆",
+	"导游":   "導遊",
+	"小丑":   "小丑",
+	"尽快":   "儘快",
+	"尽早":   "儘早",
+	"尽管":   "儘管",
+	"尽量":   "儘量",
+	"屋里":   "屋裡",
+	"席卷":   "席捲",
+	"干净":   "乾淨",
+	"干劲":   "幹勁",
+	"干嘛":   "幹嘛",
+	"干旱":   "乾旱",
+	"干杯":   "乾杯",
+	"干枯":   "乾枯",
+	"干活":   "幹活",
+	"干涸":   "乾涸",
+	"干燥":   "乾燥",
+	"干线":   "幹線",
+	"干脆":   "乾脆",
+	"干部":   "幹部",
+	"应征":   "應徵",
+	"开采":   "開採",
+	"弥漫":   "瀰漫",
+	"影后":   "影后",
+	"征兆":   "徵兆",
+	"征收":   "徵收",
+	"征求":   "徵求",
+	"征集":   "徵集",
+	"心脏":   "心臟",
+	"心里":   "心裡",
+	"恶心":   "噁心",
+	"战斗":   "戰鬥",
+	"手里":   "手裡",
+	"才干":   "才幹",
+	"扎营":   "紮營",
+	"打斗":   "打鬥",
+	"托付":   "託付",
+	"批准":   "批准",
+	"折叠":   "摺疊",
+	"护发":   "護髮",
+	"抽签":   "抽籤",
+	"拉面":   "拉麵",
+	"拜托":   "拜託",
+	"挂历":   "掛曆",
+	"搏斗":   "搏鬥",
+	"收获":   "收穫",
+	"放松":   "放鬆",
+	"斗争":   "鬥爭",
+	"斗志":   "鬥志",
+	"方便面":  "方便麵",
+	"旅游":   "旅遊",
+	"日历":   "日曆",
+	"日志":   "日誌",
+	"晒干":   "曬乾",
+	"杂志":   "雜誌",
+	"松动":   "鬆動",
+	"松开":   "鬆開",
+	"松懈":   "鬆懈",
+	"松散":   "鬆散",
+	"染发":   "染髮",
+	"柜台":   "櫃檯",
+	"标志":   "標誌",
+	"标签":   "標籤",
+	"树干":   "樹幹",
+	"格斗":   "格鬥",
+	"梦里":   "夢裡",
+	"每周":   "每週",
+	"毛发":   "毛髮",
+	"水里":   "水裡",
+	"汇总":   "彙總",
+	"汇编":   "彙編",
+	"污蔑":   "污衊",
+	"汤面":   "湯麵",
+	"没关系":  "沒關係",
+	"泡面":   "泡麵",
+	"洗发":   "洗髮",
+	"游客":   "遊客",
+	"游戏":   "遊戲",
+	"游玩":   "遊玩",
+	"游行":   "遊行",
+	"游览":   "遊覽",
+	"炒面":   "炒麵",
+	"点赞":   "點讚",
+	"烘干":   "烘乾",
+	"牙签":   "牙籤",
+	"特征":   "特徵",
+	"王后":   "王后",
+	"理发":   "理髮",
+	"症结":   "癥結",
+	"白发":   "白髮",
+	"皇后":   "皇后",
+	"眼里":   "眼裡",
+	"短发":   "短髮",
+	"研制":   "研製",
+	"秋千":   "鞦韆",
+	"称赞":   "稱讚",
+	"稻谷":   "稻穀",
+	"答复":   "答覆",
+	"系鞋带":  "繫鞋帶",
+	"繁复":   "繁複",
+	"绘制":   "繪製",
+	"维系":   "維繫",
+	"联系":   "聯繫",
+	"肉松":   "肉鬆",
+	"肝脏":   "肝臟",
+	"肾脏":   "腎臟",
+	"胡子":   "鬍子",
+	"胡须":   "鬍鬚",
+	"能干":   "能幹",
+	"脏器":   "臟器",
+	"脱发":   "脫髮",
+	"船只":   "船隻",
+	"茶几":   "茶几",
+	"蓬松":   "蓬鬆",
+	"词汇":   "詞彙",
+	"谷物":   "穀物",
+	"谷类":   "穀類",
+	"象征":   "象徵",
+	"赞叹":   "讚嘆",
+	"赞美":   "讚美",
+	"轻松":   "輕鬆",
+	"这里":   "這裡",
+	"那里":   "那裡",
+	"郊游":   "郊遊",
+	"酒坛":   "酒罈",
+	"采取":   "採取",
+	"采用":   "採用",
+	"采纳":   "採納",
+	"采访":   "採訪",
+	"采购":   "採購",
+	"采集":   "採集",
+	"里头":   "裡頭",
+	"里边":   "裡邊",
+	"里面":   "裡面",
+	"重复":   "重複",
+	"金发":   "金髮",
+	"钟情":   "鍾情",
+	"钟爱":   "鍾愛",
+	"阳历":   "陽曆",
+	"阴历":   "陰曆",
+	"面包":   "麵包",
+	"面条":   "麵條",
+	"面粉":   "麵粉",
+	"面食":   "麵食",
+	"饼干":   "餅乾",
+	"骨干":   "骨幹",
+	"黑发":   "黑髮",
+}
+
+// t2sPhrases 定义了繁体转简体时需要按词语转换的词表，用于处理繁体字在简体中保留原样或者有多种写法的情况。
+var t2sPhrases = map[string]string{
+	"乾坤":  "乾坤",
+	"乾隆":  "乾隆",
+	"倒楣":  "倒霉",
+	"反覆":  "反复",
+	"回覆":  "回复",
+	"執著":  "执着",
+	"意味著": "意味着",
+	"拿著":  "拿着",
+	"接著":  "接着",
+	"沉著":  "沉着",
+	"看著":  "看着",
+	"睡著":  "睡着",
+	"瞭望":  "瞭望",
+	"穿著":  "穿着",
+	"等著":  "等着",
+	"答覆":  "答复",
+	"著急":  "着急",
+	"著想":  "着想",
+	"著手":  "着手",
+	"著涼":  "着凉",
+	"著火":  "着火",
+	"著迷":  "着迷",
+	"著陸":  "着陆",
+	"跟著":  "跟着",
+	"隨著":  "随着",
+}
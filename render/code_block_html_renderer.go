@@ -32,18 +32,19 @@ var languagesNoHighlight = []string{"mermaid", "echarts", "abc", "graphviz"}
 
 func (r *HtmlRenderer) renderCodeBlock(node *ast.Node, entering bool) ast.WalkStatus {
 	if !node.IsFencedCodeBlock {
+		codeBlock := node
 		// 缩进代码块处理
 		r.Newline()
 		rendered := false
 		tokens := node.FirstChild.Tokens
 		if r.Option.CodeSyntaxHighlight {
-			rendered = highlightChroma(tokens, "", r)
+			rendered = highlightChroma(tokens, "", codeBlock, r)
 			if !rendered {
 				tokens = util.EscapeHTML(tokens)
 				r.Write(tokens)
 			}
 		} else {
-			r.WriteString("<pre><code>")
+			r.WriteString("<pre" + r.sourceLineAttrsStr(codeBlock) + r.attributeListAttrsStr(codeBlock) + "><code>")
 			tokens = util.EscapeHTML(tokens)
 			r.Write(tokens)
 		}
@@ -58,6 +59,7 @@ func (r *HtmlRenderer) renderCodeBlock(node *ast.Node, entering bool) ast.WalkSt
 // renderCodeBlockCode 进行代码块 HTML 渲染，实现语法高亮。
 func (r *HtmlRenderer) renderCodeBlockCode(node *ast.Node, entering bool) ast.WalkStatus {
 	if entering {
+		codeBlock := node.Parent
 		tokens := node.Tokens
 		if 0 < len(node.Previous.CodeBlockInfo) {
 			infoWords := lex.Split(node.Previous.CodeBlockInfo, lex.ItemSpace)
@@ -72,19 +74,19 @@ func (r *HtmlRenderer) renderCodeBlockCode(node *ast.Node, entering bool) ast.Wa
 
 			if "mindmap" == language {
 				json := r.renderMindmap(tokens)
-				r.WriteString("<pre><code data-code=\"")
+				r.WriteString("<pre" + r.sourceLineAttrsStr(codeBlock) + r.attributeListAttrsStr(codeBlock) + "><code data-code=\"")
 				r.Write(json)
 				r.WriteString("\" class=\"language-mindmap\">")
 				r.Write(util.EscapeHTML(tokens))
 				rendered = true
 			} else {
 				if r.Option.CodeSyntaxHighlight && !noHighlight(language) {
-					rendered = highlightChroma(tokens, language, r)
+					rendered = highlightChroma(tokens, language, codeBlock, r)
 				}
 			}
 
 			if !rendered {
-				r.WriteString("<pre><code class=\"language-")
+				r.WriteString("<pre" + r.sourceLineAttrsStr(codeBlock) + r.attributeListAttrsStr(codeBlock) + "><code class=\"language-")
 				r.WriteString(language)
 				r.WriteString("\">")
 				tokens = util.EscapeHTML(tokens)
@@ -93,7 +95,7 @@ func (r *HtmlRenderer) renderCodeBlockCode(node *ast.Node, entering bool) ast.Wa
 		} else {
 			rendered := false
 			if r.Option.CodeSyntaxHighlight {
-				rendered = highlightChroma(tokens, "", r)
+				rendered = highlightChroma(tokens, "", codeBlock, r)
 				if !rendered {
 					tokens = util.EscapeHTML(tokens)
 					r.Write(tokens)
@@ -102,12 +104,12 @@ func (r *HtmlRenderer) renderCodeBlockCode(node *ast.Node, entering bool) ast.Wa
 				if r.Option.CodeSyntaxHighlightDetectLang {
 					language := detectLanguage(tokens)
 					if "" != language {
-						r.WriteString("<pre><code class=\"language-" + language)
+						r.WriteString("<pre" + r.sourceLineAttrsStr(codeBlock) + r.attributeListAttrsStr(codeBlock) + "><code class=\"language-" + language)
 					} else {
-						r.WriteString("<pre><code>")
+						r.WriteString("<pre" + r.sourceLineAttrsStr(codeBlock) + r.attributeListAttrsStr(codeBlock) + "><code>")
 					}
 				} else {
-					r.WriteString("<pre><code>")
+					r.WriteString("<pre" + r.sourceLineAttrsStr(codeBlock) + r.attributeListAttrsStr(codeBlock) + "><code>")
 				}
 				tokens = util.EscapeHTML(tokens)
 				r.Write(tokens)
@@ -119,7 +121,7 @@ func (r *HtmlRenderer) renderCodeBlockCode(node *ast.Node, entering bool) ast.Wa
 	return ast.WalkStop
 }
 
-func highlightChroma(tokens []byte, language string, r *HtmlRenderer) (rendered bool) {
+func highlightChroma(tokens []byte, language string, block *ast.Node, r *HtmlRenderer) (rendered bool) {
 	codeBlock := util.BytesToStr(tokens)
 	var lexer chroma.Lexer
 	if "" != language {
@@ -150,9 +152,9 @@ func highlightChroma(tokens []byte, language string, r *HtmlRenderer) (rendered
 		var b bytes.Buffer
 		if err = formatter.Format(&b, style, iterator); nil == err {
 			if !r.Option.CodeSyntaxHighlightInlineStyle {
-				r.WriteString("<pre>")
+				r.WriteString("<pre" + r.sourceLineAttrsStr(block) + r.attributeListAttrsStr(block) + ">")
 			} else {
-				r.WriteString("<pre style=\"" + chromahtml.StyleEntryToCSS(style.Get(chroma.Background)) + "\">")
+				r.WriteString("<pre" + r.sourceLineAttrsStr(block) + r.attributeListAttrsStr(block) + " style=\"" + chromahtml.StyleEntryToCSS(style.Get(chroma.Background)) + "\">")
 			}
 			if "" != language {
 				r.WriteString("<code class=\"language-" + language)
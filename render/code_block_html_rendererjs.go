@@ -21,9 +21,10 @@ import (
 // renderCodeBlock 进行代码块 HTML 渲染，不实现语法高亮。
 func (r *HtmlRenderer) renderCodeBlock(node *ast.Node, entering bool) ast.WalkStatus {
 	if !node.IsFencedCodeBlock {
+		codeBlock := node
 		// 缩进代码块处理
 		r.Newline()
-		r.WriteString("<pre><code>")
+		r.WriteString("<pre" + r.sourceLineAttrsStr(codeBlock) + r.attributeListAttrsStr(codeBlock) + "><code>")
 		r.Write(util.EscapeHTML(node.FirstChild.Tokens))
 		r.WriteString("</code></pre>")
 		r.Newline()
@@ -35,6 +36,7 @@ func (r *HtmlRenderer) renderCodeBlock(node *ast.Node, entering bool) ast.WalkSt
 
 func (r *HtmlRenderer) renderCodeBlockCode(node *ast.Node, entering bool) ast.WalkStatus {
 	if entering {
+		codeBlock := node.Parent
 		r.Newline()
 		tokens := node.Tokens
 		if 0 < len(node.Previous.CodeBlockInfo) {
@@ -42,16 +44,16 @@ func (r *HtmlRenderer) renderCodeBlockCode(node *ast.Node, entering bool) ast.Wa
 			language := string(infoWords[0])
 			if "mindmap" == language {
 				json := r.renderMindmap(tokens)
-				r.WriteString("<pre><code data-code=\"")
+				r.WriteString("<pre" + r.sourceLineAttrsStr(codeBlock) + r.attributeListAttrsStr(codeBlock) + "><code data-code=\"")
 				r.Write(json)
 				r.WriteString("\" class=\"language-mindmap\">")
 			} else {
-				r.WriteString("<pre><code class=\"language-" + language + "\">")
+				r.WriteString("<pre" + r.sourceLineAttrsStr(codeBlock) + r.attributeListAttrsStr(codeBlock) + "><code class=\"language-" + language + "\">")
 			}
 			tokens = util.EscapeHTML(tokens)
 			r.Write(tokens)
 		} else {
-			r.WriteString("<pre><code>")
+			r.WriteString("<pre" + r.sourceLineAttrsStr(codeBlock) + r.attributeListAttrsStr(codeBlock) + "><code>")
 			tokens = util.EscapeHTML(tokens)
 			r.Write(tokens)
 		}
@@ -26,7 +26,10 @@ import (
 // FormatRenderer 描述了格式化渲染器。
 type FormatRenderer struct {
 	*BaseRenderer
-	nodeWriterStack []*bytes.Buffer // 节点输出缓冲栈
+	nodeWriterStack       []*bytes.Buffer   // 节点输出缓冲栈
+	tableCellLines        [][][]byte        // 多行表行中每个单元格按行拆分后的内容
+	tableCaption          string            // 待输出的表标题
+	writtenHeadingNumbers map[*ast.Node]int // 之前格式化时写入标题开头的编号长度
 }
 
 // NewFormatRenderer 创建一个格式化渲染器。
@@ -48,6 +51,10 @@ func NewFormatRenderer(tree *parse.Tree) *FormatRenderer {
 	ret.RendererFuncs[ast.NodeMathBlockOpenMarker] = ret.renderMathBlockOpenMarker
 	ret.RendererFuncs[ast.NodeMathBlockContent] = ret.renderMathBlockContent
 	ret.RendererFuncs[ast.NodeMathBlockCloseMarker] = ret.renderMathBlockCloseMarker
+	ret.RendererFuncs[ast.NodeFrontMatter] = ret.renderFrontMatter
+	ret.RendererFuncs[ast.NodeFrontMatterOpenMarker] = ret.renderFrontMatterOpenMarker
+	ret.RendererFuncs[ast.NodeFrontMatterContent] = ret.renderFrontMatterContent
+	ret.RendererFuncs[ast.NodeFrontMatterCloseMarker] = ret.renderFrontMatterCloseMarker
 	ret.RendererFuncs[ast.NodeInlineMath] = ret.renderInlineMath
 	ret.RendererFuncs[ast.NodeInlineMathOpenMarker] = ret.renderInlineMathOpenMarker
 	ret.RendererFuncs[ast.NodeInlineMathContent] = ret.renderInlineMathContent
@@ -64,10 +71,16 @@ func NewFormatRenderer(tree *parse.Tree) *FormatRenderer {
 	ret.RendererFuncs[ast.NodeStrongU8eCloseMarker] = ret.renderStrongU8eCloseMarker
 	ret.RendererFuncs[ast.NodeBlockquote] = ret.renderBlockquote
 	ret.RendererFuncs[ast.NodeBlockquoteMarker] = ret.renderBlockquoteMarker
+	ret.RendererFuncs[ast.NodeGitHubAlert] = ret.renderBlockquote
+	ret.RendererFuncs[ast.NodeGitHubAlertMarker] = ret.renderGitHubAlertMarker
+	ret.RendererFuncs[ast.NodeCustomContainer] = ret.renderCustomContainer
 	ret.RendererFuncs[ast.NodeHeading] = ret.renderHeading
 	ret.RendererFuncs[ast.NodeHeadingC8hMarker] = ret.renderHeadingC8hMarker
 	ret.RendererFuncs[ast.NodeList] = ret.renderList
 	ret.RendererFuncs[ast.NodeListItem] = ret.renderListItem
+	ret.RendererFuncs[ast.NodeDefinitionList] = ret.renderDefinitionList
+	ret.RendererFuncs[ast.NodeDefinitionTerm] = ret.renderDefinitionTerm
+	ret.RendererFuncs[ast.NodeDefinitionDescription] = ret.renderDefinitionDescription
 	ret.RendererFuncs[ast.NodeThematicBreak] = ret.renderThematicBreak
 	ret.RendererFuncs[ast.NodeHardBreak] = ret.renderHardBreak
 	ret.RendererFuncs[ast.NodeSoftBreak] = ret.renderSoftBreak
@@ -89,6 +102,15 @@ func NewFormatRenderer(tree *parse.Tree) *FormatRenderer {
 	ret.RendererFuncs[ast.NodeStrikethrough1CloseMarker] = ret.renderStrikethrough1CloseMarker
 	ret.RendererFuncs[ast.NodeStrikethrough2OpenMarker] = ret.renderStrikethrough2OpenMarker
 	ret.RendererFuncs[ast.NodeStrikethrough2CloseMarker] = ret.renderStrikethrough2CloseMarker
+	ret.RendererFuncs[ast.NodeMark] = ret.renderMark
+	ret.RendererFuncs[ast.NodeMarkOpenMarker] = ret.renderMarkOpenMarker
+	ret.RendererFuncs[ast.NodeMarkCloseMarker] = ret.renderMarkCloseMarker
+	ret.RendererFuncs[ast.NodeSup] = ret.renderSup
+	ret.RendererFuncs[ast.NodeSupOpenMarker] = ret.renderSupOpenMarker
+	ret.RendererFuncs[ast.NodeSupCloseMarker] = ret.renderSupCloseMarker
+	ret.RendererFuncs[ast.NodeSub] = ret.renderSub
+	ret.RendererFuncs[ast.NodeSubOpenMarker] = ret.renderSubOpenMarker
+	ret.RendererFuncs[ast.NodeSubCloseMarker] = ret.renderSubCloseMarker
 	ret.RendererFuncs[ast.NodeTaskListItemMarker] = ret.renderTaskListItemMarker
 	ret.RendererFuncs[ast.NodeTable] = ret.renderTable
 	ret.RendererFuncs[ast.NodeTableHead] = ret.renderTableHead
@@ -100,7 +122,15 @@ func NewFormatRenderer(tree *parse.Tree) *FormatRenderer {
 	ret.RendererFuncs[ast.NodeEmojiAlias] = ret.renderEmojiAlias
 	ret.RendererFuncs[ast.NodeFootnotesDef] = ret.renderFootnotesDef
 	ret.RendererFuncs[ast.NodeFootnotesRef] = ret.renderFootnotesRef
+	ret.RendererFuncs[ast.NodeWikiLink] = ret.renderWikiLink
+	ret.RendererFuncs[ast.NodeAbbr] = ret.renderAbbr
+	ret.RendererFuncs[ast.NodeTag] = ret.renderTag
+	ret.RendererFuncs[ast.NodeRuby] = ret.renderRuby
+	ret.RendererFuncs[ast.NodeCrossRef] = ret.renderCrossRef
+	ret.RendererFuncs[ast.NodeCitation] = ret.renderCitation
+	ret.RendererFuncs[ast.NodeReference] = ret.renderReference
 	ret.RendererFuncs[ast.NodeToC] = ret.renderToC
+	ret.RendererFuncs[ast.NodeTableCaption] = ret.renderTableCaption
 	ret.RendererFuncs[ast.NodeBackslash] = ret.renderBackslash
 	ret.RendererFuncs[ast.NodeBackslashContent] = ret.renderBackslashContent
 	ret.RendererFuncs[ast.NodeHTMLEntity] = ret.renderHtmlEntity
@@ -109,18 +139,34 @@ func NewFormatRenderer(tree *parse.Tree) *FormatRenderer {
 
 func (r *FormatRenderer) Render() (output []byte) {
 	output = r.BaseRenderer.Render()
-	if 1 > len(r.Tree.Context.LinkRefDefs) {
+	var inlineFootnotesDefs []*ast.Node
+	if r.Option.HoistInlineFootnotes {
+		for _, def := range r.Tree.Context.FootnotesDefs {
+			if nil != def.FootnotesInline {
+				inlineFootnotesDefs = append(inlineFootnotesDefs, def)
+			}
+		}
+	}
+	if 1 > len(r.Tree.Context.LinkRefDefs) && 1 > len(r.Tree.Context.Abbreviations) && 1 > len(inlineFootnotesDefs) {
 		return
 	}
 
 	buf := &bytes.Buffer{}
 	buf.WriteByte(lex.ItemNewline)
+	// 将提升后的行内脚注定义添加到末尾
+	for _, def := range inlineFootnotesDefs {
+		buf.WriteString("[" + util.BytesToStr(def.Tokens) + "]: " + util.BytesToStr(lex.TrimWhitespace(def.FootnotesInline)) + "\n")
+	}
 	// 将链接引用定义添加到末尾
 	for _, node := range r.Tree.Context.LinkRefDefs {
 		label := node.LinkRefLabel
 		dest := node.ChildByType(ast.NodeLinkDest).Tokens
 		buf.WriteString("[" + util.BytesToStr(label) + "]: " + util.BytesToStr(dest) + "\n")
 	}
+	// 将缩写定义添加到末尾
+	for _, def := range r.abbreviationDefs() {
+		buf.WriteString(def + "\n")
+	}
 	output = append(output, buf.Bytes()...)
 	return
 }
@@ -143,11 +189,19 @@ func (r *FormatRenderer) renderBackslash(node *ast.Node, entering bool) ast.Walk
 }
 
 func (r *FormatRenderer) renderToC(node *ast.Node, entering bool) ast.WalkStatus {
-	r.WriteString("[toc]\n\n")
+	tocType := node.ToCType
+	if "" == tocType {
+		tocType = parse.ToCHeadings
+	}
+	r.WriteString("[" + tocType + "]\n\n")
 	return ast.WalkStop
 }
 
 func (r *FormatRenderer) renderFootnotesRef(node *ast.Node, entering bool) ast.WalkStatus {
+	if nil != node.FootnotesInline && !r.Option.HoistInlineFootnotes {
+		r.WriteString("^[" + util.BytesToStr(node.FootnotesInline) + "]")
+		return ast.WalkStop
+	}
 	r.WriteString("[" + util.BytesToStr(node.Tokens) + "]")
 	return ast.WalkStop
 }
@@ -177,7 +231,36 @@ func (r *FormatRenderer) renderEmoji(node *ast.Node, entering bool) ast.WalkStat
 }
 
 func (r *FormatRenderer) renderTableCell(node *ast.Node, entering bool) ast.WalkStatus {
-	padding := node.TableCellContentMaxWidth - node.TableCellContentWidth
+	if lex.ItemPipe == node.TableCellSpanned {
+		// 被左侧单元格合并，输出 || 中的第二个 |
+		if entering && 2 > node.Parent.TableRowLines {
+			r.WriteByte(lex.ItemPipe)
+		}
+		return ast.WalkSkipChildren
+	}
+
+	if 1 < node.Parent.TableRowLines {
+		// 多行单元格先输出到缓冲中，在表行结束时再按行拼接
+		if entering {
+			r.Writer = &bytes.Buffer{}
+			r.nodeWriterStack = append(r.nodeWriterStack, r.Writer)
+			if lex.ItemCaret == node.TableCellSpanned {
+				r.WriteString("^^")
+			}
+		} else {
+			writer := r.nodeWriterStack[len(r.nodeWriterStack)-1]
+			r.nodeWriterStack = r.nodeWriterStack[:len(r.nodeWriterStack)-1]
+			r.Writer = r.nodeWriterStack[len(r.nodeWriterStack)-1]
+			lines := bytes.Split(writer.Bytes(), []byte("\\\n"))
+			for i, line := range lines {
+				lines[i] = lex.TrimWhitespace(line)
+			}
+			r.tableCellLines = append(r.tableCellLines, lines)
+		}
+		return ast.WalkContinue
+	}
+
+	padding := r.tableCellPadding(node, node.TableCellContentWidth)
 	if entering {
 		r.WriteByte(lex.ItemPipe)
 		r.WriteByte(lex.ItemSpace)
@@ -187,6 +270,9 @@ func (r *FormatRenderer) renderTableCell(node *ast.Node, entering bool) ast.Walk
 		case 3:
 			r.Write(bytes.Repeat([]byte{lex.ItemSpace}, padding))
 		}
+		if lex.ItemCaret == node.TableCellSpanned {
+			r.WriteString("^^")
+		}
 	} else {
 		switch node.TableCellAlign {
 		case 2:
@@ -201,12 +287,86 @@ func (r *FormatRenderer) renderTableCell(node *ast.Node, entering bool) ast.Walk
 }
 
 func (r *FormatRenderer) renderTableRow(node *ast.Node, entering bool) ast.WalkStatus {
+	if 1 < node.TableRowLines {
+		if entering {
+			r.tableCellLines = nil
+		} else {
+			r.renderTableMultilineRow(node)
+		}
+		return ast.WalkContinue
+	}
+
 	if !entering {
 		r.WriteString("|\n")
 	}
 	return ast.WalkContinue
 }
 
+// tableRow 返回节点 node 所在的表行。
+func tableRow(node *ast.Node) (ret *ast.Node) {
+	for ret = node.Parent; nil != ret && ast.NodeTableRow != ret.Type; ret = ret.Parent {
+	}
+	return
+}
+
+// tableCellPadding 返回单元格 cell 中宽度为 width 的内容需要补齐的空白数。没有计算过列宽的表（比如从 HTML 转换而来）不补齐。
+func (r *FormatRenderer) tableCellPadding(cell *ast.Node, width int) int {
+	if 1 > cell.TableCellContentMaxWidth {
+		return 0
+	}
+	if ret := parse.TableCellSpanWidth(cell) - width; 0 < ret {
+		return ret
+	}
+	return 0
+}
+
+// renderTableMultilineRow 输出多行表行 row，除最后一行外每行都以 \ 结尾。
+func (r *FormatRenderer) renderTableMultilineRow(row *ast.Node) {
+	lines := 1
+	for _, cellLines := range r.tableCellLines {
+		if lines < len(cellLines) {
+			lines = len(cellLines)
+		}
+	}
+
+	for i := 0; i < lines; i++ {
+		c := 0
+		for cell := row.FirstChild; nil != cell; cell = cell.Next {
+			if lex.ItemPipe == cell.TableCellSpanned {
+				r.WriteByte(lex.ItemPipe)
+				continue
+			}
+
+			var content []byte
+			if cellLines := r.tableCellLines[c]; i < len(cellLines) {
+				content = cellLines[i]
+			}
+			c++
+			padding := r.tableCellPadding(cell, len(content))
+			r.WriteString("| ")
+			switch cell.TableCellAlign {
+			case 2:
+				r.Write(bytes.Repeat([]byte{lex.ItemSpace}, padding/2))
+				r.Write(content)
+				r.Write(bytes.Repeat([]byte{lex.ItemSpace}, padding/2))
+			case 3:
+				r.Write(bytes.Repeat([]byte{lex.ItemSpace}, padding))
+				r.Write(content)
+			default:
+				r.Write(content)
+				r.Write(bytes.Repeat([]byte{lex.ItemSpace}, padding))
+			}
+			r.WriteByte(lex.ItemSpace)
+		}
+		r.WriteByte(lex.ItemPipe)
+		if i < lines-1 {
+			r.WriteString(" \\")
+		}
+		r.WriteByte(lex.ItemNewline)
+	}
+	r.tableCellLines = nil
+}
+
 func (r *FormatRenderer) renderTableHead(node *ast.Node, entering bool) ast.WalkStatus {
 	if !entering {
 		headRow := node.FirstChild
@@ -244,9 +404,34 @@ func (r *FormatRenderer) renderTableHead(node *ast.Node, entering bool) ast.Walk
 	return ast.WalkContinue
 }
 
+func (r *FormatRenderer) renderTableCaption(node *ast.Node, entering bool) ast.WalkStatus {
+	if entering {
+		r.Writer = &bytes.Buffer{}
+		r.nodeWriterStack = append(r.nodeWriterStack, r.Writer)
+	} else {
+		writer := r.nodeWriterStack[len(r.nodeWriterStack)-1]
+		r.nodeWriterStack = r.nodeWriterStack[:len(r.nodeWriterStack)-1]
+		r.Writer = r.nodeWriterStack[len(r.nodeWriterStack)-1]
+		r.tableCaption = "Table: " + util.BytesToStr(bytes.TrimSpace(writer.Bytes()))
+		if table := node.Parent; 0 < len(table.Attributes) {
+			r.tableCaption += " " + parse.AttributeListStr(table.Attributes)
+		}
+		if node.TableCaptionBefore {
+			r.WriteString(r.tableCaption + "\n\n")
+			r.tableCaption = ""
+		}
+	}
+	return ast.WalkContinue
+}
+
 func (r *FormatRenderer) renderTable(node *ast.Node, entering bool) ast.WalkStatus {
 	if !entering {
 		r.Newline()
+		if "" != r.tableCaption {
+			// 表之后的标题和表之间空一行
+			r.WriteString("\n" + r.tableCaption + "\n")
+			r.tableCaption = ""
+		}
 		if !r.isLastNode(r.Tree.Root, node) {
 			r.WriteByte(lex.ItemNewline)
 		}
@@ -283,6 +468,110 @@ func (r *FormatRenderer) renderStrikethrough2CloseMarker(node *ast.Node, enterin
 	return ast.WalkStop
 }
 
+func (r *FormatRenderer) renderMark(node *ast.Node, entering bool) ast.WalkStatus {
+	if entering {
+		r.TextAutoSpacePrevious(node)
+	} else {
+		r.TextAutoSpaceNext(node)
+	}
+	return ast.WalkContinue
+}
+
+func (r *FormatRenderer) renderMarkOpenMarker(node *ast.Node, entering bool) ast.WalkStatus {
+	r.WriteString("==")
+	return ast.WalkStop
+}
+
+func (r *FormatRenderer) renderMarkCloseMarker(node *ast.Node, entering bool) ast.WalkStatus {
+	r.WriteString("==")
+	return ast.WalkStop
+}
+
+func (r *FormatRenderer) renderSup(node *ast.Node, entering bool) ast.WalkStatus {
+	if entering {
+		r.TextAutoSpacePrevious(node)
+	} else {
+		r.TextAutoSpaceNext(node)
+	}
+	return ast.WalkContinue
+}
+
+func (r *FormatRenderer) renderSupOpenMarker(node *ast.Node, entering bool) ast.WalkStatus {
+	r.WriteByte(lex.ItemCaret)
+	return ast.WalkStop
+}
+
+func (r *FormatRenderer) renderSupCloseMarker(node *ast.Node, entering bool) ast.WalkStatus {
+	r.WriteByte(lex.ItemCaret)
+	return ast.WalkStop
+}
+
+func (r *FormatRenderer) renderSub(node *ast.Node, entering bool) ast.WalkStatus {
+	if entering {
+		r.TextAutoSpacePrevious(node)
+	} else {
+		r.TextAutoSpaceNext(node)
+	}
+	return ast.WalkContinue
+}
+
+func (r *FormatRenderer) renderSubOpenMarker(node *ast.Node, entering bool) ast.WalkStatus {
+	r.WriteByte(lex.ItemTilde)
+	return ast.WalkStop
+}
+
+func (r *FormatRenderer) renderSubCloseMarker(node *ast.Node, entering bool) ast.WalkStatus {
+	r.WriteByte(lex.ItemTilde)
+	return ast.WalkStop
+}
+
+func (r *FormatRenderer) renderReference(node *ast.Node, entering bool) ast.WalkStatus {
+	r.Write(node.Tokens)
+	return ast.WalkStop
+}
+
+func (r *FormatRenderer) renderTag(node *ast.Node, entering bool) ast.WalkStatus {
+	r.WriteByte(lex.ItemCrosshatch)
+	r.Write(node.Tokens)
+	if node.TagClosed {
+		r.WriteByte(lex.ItemCrosshatch)
+	}
+	return ast.WalkStop
+}
+
+func (r *FormatRenderer) renderRuby(node *ast.Node, entering bool) ast.WalkStatus {
+	r.WriteByte(lex.ItemOpenBrace)
+	r.Write(node.Tokens)
+	r.WriteByte(lex.ItemCloseBrace)
+	return ast.WalkStop
+}
+
+func (r *FormatRenderer) renderCrossRef(node *ast.Node, entering bool) ast.WalkStatus {
+	r.Write(node.Tokens)
+	return ast.WalkStop
+}
+
+func (r *FormatRenderer) renderCitation(node *ast.Node, entering bool) ast.WalkStatus {
+	r.Write(node.Tokens)
+	return ast.WalkStop
+}
+
+func (r *FormatRenderer) renderAbbr(node *ast.Node, entering bool) ast.WalkStatus {
+	if entering {
+		r.TextAutoSpacePrevious(node)
+	} else {
+		r.TextAutoSpaceNext(node)
+	}
+	return ast.WalkContinue
+}
+
+func (r *FormatRenderer) renderWikiLink(node *ast.Node, entering bool) ast.WalkStatus {
+	r.WriteString("[[")
+	r.Write(node.Tokens)
+	r.WriteString("]]")
+	return ast.WalkStop
+}
+
 func (r *FormatRenderer) renderLinkTitle(node *ast.Node, entering bool) ast.WalkStatus {
 	r.WriteString("\"")
 	r.Write(node.Tokens)
@@ -304,6 +593,9 @@ func (r *FormatRenderer) renderLinkText(node *ast.Node, entering bool) ast.WalkS
 	if r.Option.AutoSpace {
 		r.Space(node)
 	}
+	if "" != r.Option.ChineseConvert {
+		r.ConvertChinese(node)
+	}
 	r.Write(node.Tokens)
 	return ast.WalkStop
 }
@@ -334,6 +626,9 @@ func (r *FormatRenderer) renderBang(node *ast.Node, entering bool) ast.WalkStatu
 }
 
 func (r *FormatRenderer) renderImage(node *ast.Node, entering bool) ast.WalkStatus {
+	if !entering {
+		r.renderAttributeList(node)
+	}
 	return ast.WalkContinue
 }
 
@@ -347,9 +642,11 @@ func (r *FormatRenderer) renderLink(node *ast.Node, entering bool) ast.WalkStatu
 			} else {
 				r.WriteString("[" + util.BytesToStr(text) + "][" + util.BytesToStr(node.LinkRefLabel) + "]")
 			}
+			r.renderAttributeList(node)
 			return ast.WalkStop
 		}
 	} else {
+		r.renderAttributeList(node)
 		r.LinkTextAutoSpaceNext(node)
 	}
 	return ast.WalkContinue
@@ -370,6 +667,15 @@ func (r *FormatRenderer) renderInlineHTML(node *ast.Node, entering bool) ast.Wal
 	return ast.WalkStop
 }
 
+// renderSource 返回节点 node 及其子节点格式化后的 Markdown 源码。
+func (r *FormatRenderer) renderSource(node *ast.Node) []byte {
+	r.LastOut = lex.ItemNewline
+	r.Writer = &bytes.Buffer{}
+	r.nodeWriterStack = []*bytes.Buffer{r.Writer}
+	ast.Walk(node, r.renderNode)
+	return r.Writer.Bytes()
+}
+
 func (r *FormatRenderer) renderDocument(node *ast.Node, entering bool) ast.WalkStatus {
 	if entering {
 		r.Writer = &bytes.Buffer{}
@@ -386,6 +692,10 @@ func (r *FormatRenderer) renderDocument(node *ast.Node, entering bool) ast.WalkS
 
 func (r *FormatRenderer) renderParagraph(node *ast.Node, entering bool) ast.WalkStatus {
 	if !entering {
+		if 0 < len(node.Attributes) {
+			r.Newline()
+			r.renderAttributeList(node)
+		}
 		if !node.ParentIs(ast.NodeTableCell) {
 			r.Newline()
 		}
@@ -406,6 +716,8 @@ func (r *FormatRenderer) renderParagraph(node *ast.Node, entering bool) ast.Walk
 				} else {
 					inTightList = true
 				}
+			} else if ast.NodeDefinitionDescription == parent.Type { // DefinitionDescription.Paragraph
+				inTightList = parent.Tight
 			}
 		}
 
@@ -426,6 +738,9 @@ func (r *FormatRenderer) renderText(node *ast.Node, entering bool) ast.WalkStatu
 	if r.Option.ChinesePunct {
 		r.ChinesePunct(node)
 	}
+	if "" != r.Option.ChineseConvert {
+		r.ConvertChinese(node)
+	}
 	r.Write(node.Tokens)
 	return ast.WalkStop
 }
@@ -521,6 +836,10 @@ func (r *FormatRenderer) renderInlineMath(node *ast.Node, entering bool) ast.Wal
 
 func (r *FormatRenderer) renderMathBlockCloseMarker(node *ast.Node, entering bool) ast.WalkStatus {
 	r.Write(parse.MathBlockMarker)
+	if 0 < len(node.Parent.Attributes) {
+		r.WriteByte(lex.ItemSpace)
+		r.renderAttributeList(node.Parent)
+	}
 	r.WriteByte(lex.ItemNewline)
 	return ast.WalkStop
 }
@@ -545,6 +864,33 @@ func (r *FormatRenderer) renderMathBlock(node *ast.Node, entering bool) ast.Walk
 	return ast.WalkContinue
 }
 
+func (r *FormatRenderer) renderFrontMatterCloseMarker(node *ast.Node, entering bool) ast.WalkStatus {
+	r.Write(node.Tokens)
+	r.WriteByte(lex.ItemNewline)
+	return ast.WalkStop
+}
+
+func (r *FormatRenderer) renderFrontMatterContent(node *ast.Node, entering bool) ast.WalkStatus {
+	if 0 < len(node.Tokens) {
+		r.Write(node.Tokens)
+		r.WriteByte(lex.ItemNewline)
+	}
+	return ast.WalkStop
+}
+
+func (r *FormatRenderer) renderFrontMatterOpenMarker(node *ast.Node, entering bool) ast.WalkStatus {
+	r.Write(node.Tokens)
+	r.WriteByte(lex.ItemNewline)
+	return ast.WalkStop
+}
+
+func (r *FormatRenderer) renderFrontMatter(node *ast.Node, entering bool) ast.WalkStatus {
+	if !entering && !r.isLastNode(r.Tree.Root, node) {
+		r.WriteByte(lex.ItemNewline)
+	}
+	return ast.WalkContinue
+}
+
 func (r *FormatRenderer) renderCodeBlockCloseMarker(node *ast.Node, entering bool) ast.WalkStatus {
 	r.Newline()
 	r.Write(bytes.Repeat([]byte{lex.ItemBacktick}, node.CodeBlockFenceLen))
@@ -562,10 +908,21 @@ func (r *FormatRenderer) renderCodeBlockCode(node *ast.Node, entering bool) ast.
 
 func (r *FormatRenderer) renderCodeBlockInfoMarker(node *ast.Node, entering bool) ast.WalkStatus {
 	r.Write(node.CodeBlockInfo)
+	if 0 < len(node.CodeBlockInfo) && 0 < len(node.Parent.Attributes) {
+		r.WriteByte(lex.ItemSpace)
+	}
+	r.renderAttributeList(node.Parent)
 	r.WriteByte(lex.ItemNewline)
 	return ast.WalkStop
 }
 
+// renderAttributeList 输出节点 node 的属性列表 {#id .class key=value}。
+func (r *FormatRenderer) renderAttributeList(node *ast.Node) {
+	if 0 < len(node.Attributes) {
+		r.WriteString(parse.AttributeListStr(node.Attributes))
+	}
+}
+
 func (r *FormatRenderer) renderCodeBlockOpenMarker(node *ast.Node, entering bool) ast.WalkStatus {
 	r.Write(bytes.Repeat([]byte{lex.ItemBacktick}, node.CodeBlockFenceLen))
 	return ast.WalkStop
@@ -593,6 +950,7 @@ func (r *FormatRenderer) renderEmphasis(node *ast.Node, entering bool) ast.WalkS
 	if entering {
 		r.TextAutoSpacePrevious(node)
 	} else {
+		r.renderAttributeList(node)
 		r.TextAutoSpaceNext(node)
 	}
 	return ast.WalkContinue
@@ -622,6 +980,7 @@ func (r *FormatRenderer) renderStrong(node *ast.Node, entering bool) ast.WalkSta
 	if entering {
 		r.TextAutoSpacePrevious(node)
 	} else {
+		r.renderAttributeList(node)
 		r.TextAutoSpaceNext(node)
 	}
 	return ast.WalkContinue
@@ -703,16 +1062,79 @@ func (r *FormatRenderer) renderBlockquoteMarker(node *ast.Node, entering bool) a
 	return ast.WalkStop
 }
 
+func (r *FormatRenderer) renderCustomContainer(node *ast.Node, entering bool) ast.WalkStatus {
+	if entering {
+		r.Writer = &bytes.Buffer{}
+		r.nodeWriterStack = append(r.nodeWriterStack, r.Writer)
+	} else {
+		writer := r.nodeWriterStack[len(r.nodeWriterStack)-1]
+		r.nodeWriterStack = r.nodeWriterStack[:len(r.nodeWriterStack)-1]
+		r.Writer = r.nodeWriterStack[len(r.nodeWriterStack)-1]
+
+		fence := strings.Repeat(":", customContainerFenceLen(node))
+		r.WriteString(fence + " " + node.CustomContainerName)
+		if "" != node.CustomContainerArgs {
+			r.WriteString(" " + node.CustomContainerArgs)
+		}
+		r.WriteByte(lex.ItemNewline)
+		if content := bytes.Trim(writer.Bytes(), "\n"); 0 < len(content) {
+			r.Write(content)
+			r.WriteByte(lex.ItemNewline)
+		}
+		r.WriteString(fence)
+		r.WriteByte(lex.ItemNewline)
+		if !r.isLastNode(r.Tree.Root, node) {
+			r.WriteByte(lex.ItemNewline)
+		}
+	}
+	return ast.WalkContinue
+}
+
+// customContainerFenceLen 计算自定义容器 node 的围栏长度。内层容器的结束围栏不能闭合外层容器，所以外层围栏至少要比内层长 1。
+func customContainerFenceLen(node *ast.Node) (ret int) {
+	ret = node.CustomContainerFenceLen
+	if 3 > ret {
+		ret = 3
+	}
+	for child := node.FirstChild; nil != child; child = child.Next {
+		ast.Walk(child, func(n *ast.Node, entering bool) ast.WalkStatus {
+			if entering && ast.NodeCustomContainer == n.Type {
+				if fenceLen := customContainerFenceLen(n) + 1; fenceLen > ret {
+					ret = fenceLen
+				}
+				return ast.WalkSkipChildren
+			}
+			return ast.WalkContinue
+		})
+	}
+	return
+}
+
+func (r *FormatRenderer) renderGitHubAlertMarker(node *ast.Node, entering bool) ast.WalkStatus {
+	r.Write(node.Tokens)
+	r.WriteByte(lex.ItemNewline)
+	return ast.WalkStop
+}
+
 func (r *FormatRenderer) renderHeading(node *ast.Node, entering bool) ast.WalkStatus {
 	if entering {
 		if !node.HeadingSetext {
 			r.Write(bytes.Repeat([]byte{lex.ItemCrosshatch}, node.HeadingLevel))
 			r.WriteByte(lex.ItemSpace)
 		}
+		if number := r.headingNumber(node); "" != number {
+			r.WriteString(number)
+		}
 	} else {
+		if 0 < len(node.Attributes) {
+			r.WriteByte(lex.ItemSpace)
+			r.renderAttributeList(node)
+		} else if r.Option.HeadingID && nil != node.HeadingID && !node.HeadingSetext {
+			r.WriteString(" {" + util.BytesToStr(node.HeadingID) + "}")
+		}
 		if node.HeadingSetext {
 			r.WriteByte(lex.ItemNewline)
-			content := node.Text()
+			content := r.headingNumber(node) + node.Text()
 			contentLen := 0
 			for _, r := range content {
 				if utf8.RuneSelf <= r {
@@ -727,9 +1149,6 @@ func (r *FormatRenderer) renderHeading(node *ast.Node, entering bool) ast.WalkSt
 				r.WriteString(strings.Repeat("-", contentLen))
 			}
 		}
-		if r.Option.HeadingID && nil != node.HeadingID {
-			r.WriteString(" {" + util.BytesToStr(node.HeadingID) + "}")
-		}
 
 		if !node.ParentIs(ast.NodeTableCell) {
 			r.Newline()
@@ -739,6 +1158,95 @@ func (r *FormatRenderer) renderHeading(node *ast.Node, entering bool) ast.WalkSt
 	return ast.WalkContinue
 }
 
+// headingNumber 返回格式化时需要写入标题 node 开头的编号。标题文本已经以之前写入的编号开头时先去掉该编号，
+// 这样标题顺序变化后重新格式化会更新编号而不是重复写入。
+func (r *FormatRenderer) headingNumber(node *ast.Node) string {
+	if !r.Option.HeadingNumbering || !r.Option.FormatHeadingNumber || "" == node.HeadingNumber {
+		return ""
+	}
+	if nil == r.writtenHeadingNumbers {
+		r.writtenHeadingNumbers = r.findWrittenHeadingNumbers()
+	}
+	if length := r.writtenHeadingNumbers[node]; 0 < length {
+		text := headingText(node)
+		text.Tokens = text.Tokens[length:]
+		delete(r.writtenHeadingNumbers, node)
+	}
+	return node.HeadingNumber + " "
+}
+
+// findWrittenHeadingNumbers 返回之前格式化时写入各个标题开头的编号长度（包括之后的空格）。
+//
+// 标题开头的数字只有在和本次的某个标题编号相同，或者以上一级标题之前写入的编号开头时才是之前写入的编号，
+// 所以 # 2020 Review 这样本身以数字开头的标题文本不会被改动。
+func (r *FormatRenderer) findWrittenHeadingNumbers() map[*ast.Node]int {
+	var headings []*ast.Node
+	numbers := map[string]bool{}
+	ast.Walk(r.Tree.Root, func(n *ast.Node, entering bool) ast.WalkStatus {
+		if entering && ast.NodeHeading == n.Type && "" != n.HeadingNumber {
+			headings = append(headings, n)
+			numbers[n.HeadingNumber] = true
+		}
+		return ast.WalkContinue
+	})
+
+	separator := r.Option.HeadingNumberingSeparator
+	ret := map[*ast.Node]int{}
+	var written []string // 按层数记录最近一个标题之前写入的编号
+	for _, heading := range headings {
+		depth := len(strings.Split(heading.HeadingNumber, separator))
+		for len(written) < depth {
+			written = append(written, "")
+		}
+		written = written[:depth]
+		written[depth-1] = ""
+
+		text := headingText(heading)
+		if nil == text {
+			continue
+		}
+		number := leadingHeadingNumber(util.BytesToStr(text.Tokens), depth, separator)
+		if "" == number {
+			continue
+		}
+		if numbers[number] || (1 < depth && "" != written[depth-2] && strings.HasPrefix(number, written[depth-2]+separator)) {
+			ret[heading] = len(number) + 1
+			written[depth-1] = number
+		}
+	}
+	return ret
+}
+
+// headingText 返回标题 heading 开头的文本节点，标题不以文本开头时返回 nil。
+func headingText(heading *ast.Node) *ast.Node {
+	text := heading.FirstChild
+	if nil != text && ast.NodeHeadingC8hMarker == text.Type {
+		text = text.Next
+	}
+	if nil == text || ast.NodeText != text.Type {
+		return nil
+	}
+	return text
+}
+
+// leadingHeadingNumber 返回 text 开头由 depth 个数字和分隔符 separator 组成、之后跟着空格的编号，没有时返回空。
+func leadingHeadingNumber(text string, depth int, separator string) string {
+	space := strings.IndexByte(text, lex.ItemSpace)
+	if 1 > space {
+		return ""
+	}
+	parts := strings.Split(text[:space], separator)
+	if len(parts) != depth {
+		return ""
+	}
+	for _, part := range parts {
+		if "" == part || "" != strings.TrimLeft(part, "0123456789") {
+			return ""
+		}
+	}
+	return text[:space]
+}
+
 func (r *FormatRenderer) renderHeadingC8hMarker(node *ast.Node, entering bool) ast.WalkStatus {
 	return ast.WalkStop
 }
@@ -762,6 +1270,64 @@ func (r *FormatRenderer) renderList(node *ast.Node, entering bool) ast.WalkStatu
 	return ast.WalkContinue
 }
 
+func (r *FormatRenderer) renderDefinitionList(node *ast.Node, entering bool) ast.WalkStatus {
+	if entering {
+		r.Writer = &bytes.Buffer{}
+		r.nodeWriterStack = append(r.nodeWriterStack, r.Writer)
+	} else {
+		writer := r.nodeWriterStack[len(r.nodeWriterStack)-1]
+		r.nodeWriterStack = r.nodeWriterStack[:len(r.nodeWriterStack)-1]
+		r.nodeWriterStack[len(r.nodeWriterStack)-1].Write(writer.Bytes())
+		r.Writer = r.nodeWriterStack[len(r.nodeWriterStack)-1]
+		buf := bytes.TrimSpace(r.Writer.Bytes())
+		r.Writer.Reset()
+		r.Write(buf)
+		r.WriteString("\n\n")
+	}
+	return ast.WalkContinue
+}
+
+func (r *FormatRenderer) renderDefinitionTerm(node *ast.Node, entering bool) ast.WalkStatus {
+	if entering {
+		r.Newline()
+		if previous := node.Previous; nil != previous && ast.NodeDefinitionDescription == previous.Type {
+			// 术语前需要空行，否则会被当作上一个描述的延续文本
+			r.WriteByte(lex.ItemNewline)
+		}
+	} else {
+		r.Newline()
+	}
+	return ast.WalkContinue
+}
+
+func (r *FormatRenderer) renderDefinitionDescription(node *ast.Node, entering bool) ast.WalkStatus {
+	if entering {
+		r.Writer = &bytes.Buffer{}
+		r.nodeWriterStack = append(r.nodeWriterStack, r.Writer)
+	} else {
+		writer := r.nodeWriterStack[len(r.nodeWriterStack)-1]
+		r.nodeWriterStack = r.nodeWriterStack[:len(r.nodeWriterStack)-1]
+		indentSpaces := []byte("  ")
+		indentedLines := bytes.Buffer{}
+		if !node.Tight {
+			indentedLines.WriteByte(lex.ItemNewline)
+		}
+		indentedLines.Write(node.Marker)
+		indentedLines.WriteByte(lex.ItemSpace)
+		lines := bytes.Split(bytes.TrimSpace(writer.Bytes()), []byte{lex.ItemNewline})
+		for i, line := range lines {
+			if 0 < i && 0 < len(line) {
+				indentedLines.Write(indentSpaces)
+			}
+			indentedLines.Write(line)
+			indentedLines.WriteByte(lex.ItemNewline)
+		}
+		r.Writer = r.nodeWriterStack[len(r.nodeWriterStack)-1]
+		r.Write(indentedLines.Bytes())
+	}
+	return ast.WalkContinue
+}
+
 func (r *FormatRenderer) renderListItem(node *ast.Node, entering bool) ast.WalkStatus {
 	if entering {
 		r.Writer = &bytes.Buffer{}
@@ -822,7 +1388,9 @@ func (r *FormatRenderer) renderListItem(node *ast.Node, entering bool) ast.WalkS
 
 func (r *FormatRenderer) renderTaskListItemMarker(node *ast.Node, entering bool) ast.WalkStatus {
 	r.WriteByte(lex.ItemOpenBracket)
-	if node.TaskListItemChecked {
+	if 0 != node.TaskListItemState {
+		r.WriteByte(node.TaskListItemState)
+	} else if node.TaskListItemChecked {
 		r.WriteByte('X')
 	} else {
 		r.WriteByte(lex.ItemSpace)
@@ -845,7 +1413,12 @@ func (r *FormatRenderer) renderHardBreak(node *ast.Node, entering bool) ast.Walk
 		r.WriteString("\\\n")
 	} else {
 		if node.ParentIs(ast.NodeTableCell) {
-			r.WriteString("<br/>")
+			if node.ParentIs(ast.NodeTableRow) && 1 < tableRow(node).TableRowLines {
+				// 多行表行中的换行在表行结束时拆分为续行
+				r.WriteString("\\\n")
+			} else {
+				r.WriteString("<br/>")
+			}
 		} else {
 			r.WriteByte(lex.ItemNewline)
 		}
@@ -854,6 +1427,10 @@ func (r *FormatRenderer) renderHardBreak(node *ast.Node, entering bool) ast.Walk
 }
 
 func (r *FormatRenderer) renderSoftBreak(node *ast.Node, entering bool) ast.WalkStatus {
+	if r.dropSoftBreak(node) {
+		// 去掉东亚宽字符之间的软换行，将两行连接起来
+		return ast.WalkStop
+	}
 	r.Newline()
 	return ast.WalkStop
 }
@@ -48,6 +48,10 @@ func NewHtmlRenderer(tree *parse.Tree) *HtmlRenderer {
 	ret.RendererFuncs[ast.NodeMathBlockOpenMarker] = ret.renderMathBlockOpenMarker
 	ret.RendererFuncs[ast.NodeMathBlockContent] = ret.renderMathBlockContent
 	ret.RendererFuncs[ast.NodeMathBlockCloseMarker] = ret.renderMathBlockCloseMarker
+	ret.RendererFuncs[ast.NodeFrontMatter] = ret.renderFrontMatter
+	ret.RendererFuncs[ast.NodeFrontMatterOpenMarker] = ret.renderFrontMatterOpenMarker
+	ret.RendererFuncs[ast.NodeFrontMatterContent] = ret.renderFrontMatterContent
+	ret.RendererFuncs[ast.NodeFrontMatterCloseMarker] = ret.renderFrontMatterCloseMarker
 	ret.RendererFuncs[ast.NodeInlineMath] = ret.renderInlineMath
 	ret.RendererFuncs[ast.NodeInlineMathOpenMarker] = ret.renderInlineMathOpenMarker
 	ret.RendererFuncs[ast.NodeInlineMathContent] = ret.renderInlineMathContent
@@ -64,10 +68,16 @@ func NewHtmlRenderer(tree *parse.Tree) *HtmlRenderer {
 	ret.RendererFuncs[ast.NodeStrongU8eCloseMarker] = ret.renderStrongU8eCloseMarker
 	ret.RendererFuncs[ast.NodeBlockquote] = ret.renderBlockquote
 	ret.RendererFuncs[ast.NodeBlockquoteMarker] = ret.renderBlockquoteMarker
+	ret.RendererFuncs[ast.NodeGitHubAlert] = ret.renderGitHubAlert
+	ret.RendererFuncs[ast.NodeGitHubAlertMarker] = ret.renderGitHubAlertMarker
+	ret.RendererFuncs[ast.NodeCustomContainer] = ret.renderCustomContainer
 	ret.RendererFuncs[ast.NodeHeading] = ret.renderHeading
 	ret.RendererFuncs[ast.NodeHeadingC8hMarker] = ret.renderHeadingC8hMarker
 	ret.RendererFuncs[ast.NodeList] = ret.renderList
 	ret.RendererFuncs[ast.NodeListItem] = ret.renderListItem
+	ret.RendererFuncs[ast.NodeDefinitionList] = ret.renderDefinitionList
+	ret.RendererFuncs[ast.NodeDefinitionTerm] = ret.renderDefinitionTerm
+	ret.RendererFuncs[ast.NodeDefinitionDescription] = ret.renderDefinitionDescription
 	ret.RendererFuncs[ast.NodeThematicBreak] = ret.renderThematicBreak
 	ret.RendererFuncs[ast.NodeHardBreak] = ret.renderHardBreak
 	ret.RendererFuncs[ast.NodeSoftBreak] = ret.renderSoftBreak
@@ -89,17 +99,34 @@ func NewHtmlRenderer(tree *parse.Tree) *HtmlRenderer {
 	ret.RendererFuncs[ast.NodeStrikethrough1CloseMarker] = ret.renderStrikethrough1CloseMarker
 	ret.RendererFuncs[ast.NodeStrikethrough2OpenMarker] = ret.renderStrikethrough2OpenMarker
 	ret.RendererFuncs[ast.NodeStrikethrough2CloseMarker] = ret.renderStrikethrough2CloseMarker
+	ret.RendererFuncs[ast.NodeMark] = ret.renderMark
+	ret.RendererFuncs[ast.NodeMarkOpenMarker] = ret.renderMarkOpenMarker
+	ret.RendererFuncs[ast.NodeMarkCloseMarker] = ret.renderMarkCloseMarker
+	ret.RendererFuncs[ast.NodeSup] = ret.renderSup
+	ret.RendererFuncs[ast.NodeSupOpenMarker] = ret.renderSupOpenMarker
+	ret.RendererFuncs[ast.NodeSupCloseMarker] = ret.renderSupCloseMarker
+	ret.RendererFuncs[ast.NodeSub] = ret.renderSub
+	ret.RendererFuncs[ast.NodeSubOpenMarker] = ret.renderSubOpenMarker
+	ret.RendererFuncs[ast.NodeSubCloseMarker] = ret.renderSubCloseMarker
 	ret.RendererFuncs[ast.NodeTaskListItemMarker] = ret.renderTaskListItemMarker
 	ret.RendererFuncs[ast.NodeTable] = ret.renderTable
 	ret.RendererFuncs[ast.NodeTableHead] = ret.renderTableHead
 	ret.RendererFuncs[ast.NodeTableRow] = ret.renderTableRow
 	ret.RendererFuncs[ast.NodeTableCell] = ret.renderTableCell
+	ret.RendererFuncs[ast.NodeTableCaption] = ret.renderTableCaption
 	ret.RendererFuncs[ast.NodeEmoji] = ret.renderEmoji
 	ret.RendererFuncs[ast.NodeEmojiUnicode] = ret.renderEmojiUnicode
 	ret.RendererFuncs[ast.NodeEmojiImg] = ret.renderEmojiImg
 	ret.RendererFuncs[ast.NodeEmojiAlias] = ret.renderEmojiAlias
 	ret.RendererFuncs[ast.NodeFootnotesDef] = ret.renderFootnotesDef
 	ret.RendererFuncs[ast.NodeFootnotesRef] = ret.renderFootnotesRef
+	ret.RendererFuncs[ast.NodeWikiLink] = ret.renderWikiLink
+	ret.RendererFuncs[ast.NodeAbbr] = ret.renderAbbr
+	ret.RendererFuncs[ast.NodeTag] = ret.renderTag
+	ret.RendererFuncs[ast.NodeRuby] = ret.renderRuby
+	ret.RendererFuncs[ast.NodeCrossRef] = ret.renderCrossRef
+	ret.RendererFuncs[ast.NodeCitation] = ret.renderCitation
+	ret.RendererFuncs[ast.NodeReference] = ret.renderReference
 	ret.RendererFuncs[ast.NodeToC] = ret.renderToC
 	ret.RendererFuncs[ast.NodeBackslash] = ret.renderBackslash
 	ret.RendererFuncs[ast.NodeBackslashContent] = ret.renderBackslashContent
@@ -122,6 +149,15 @@ func (r *HtmlRenderer) renderBackslash(node *ast.Node, entering bool) ast.WalkSt
 }
 
 func (r *HtmlRenderer) renderToC(node *ast.Node, entering bool) ast.WalkStatus {
+	switch node.ToCType {
+	case parse.ToCTables:
+		r.renderNumberedItemsToC(r.Tree.NumberedTables())
+		return ast.WalkStop
+	case parse.ToCFigures:
+		r.renderNumberedItemsToC(r.Tree.NumberedFigures())
+		return ast.WalkStop
+	}
+
 	headings := r.headings()
 	length := len(headings)
 	if 1 > length {
@@ -133,12 +169,37 @@ func (r *HtmlRenderer) renderToC(node *ast.Node, entering bool) ast.WalkStatus {
 		spaces := (heading.HeadingLevel - 1) * 2
 		r.WriteString(strings.Repeat("&emsp;", spaces))
 		r.WriteString("<span class=\"toc-h" + level + "\">")
-		r.WriteString("<a class=\"toc-a\" href=\"#" + HeadingID(heading) + "\">" + heading.Text() + "</a></span><br>")
+		r.WriteString("<a class=\"toc-a\" href=\"#" + HeadingID(heading) + "\">")
+		if r.Option.HeadingNumbering && "" != heading.HeadingNumber {
+			r.WriteString("<span class=\"heading-number\">" + heading.HeadingNumber + "</span> ")
+		}
+		r.WriteString(heading.Text() + "</a></span><br>")
 	}
 	r.WriteString("</div>")
 	return ast.WalkStop
 }
 
+// renderNumberedItemsToC 渲染表目录或者图目录。
+func (r *HtmlRenderer) renderNumberedItemsToC(items []*parse.NumberedItem) {
+	if 1 > len(items) {
+		return
+	}
+	r.WriteString("<div class=\"vditor-toc\">")
+	for _, item := range items {
+		r.WriteString("<span class=\"toc-" + item.Type + "\">")
+		r.WriteString("<a class=\"toc-a\" href=\"#" + item.ID + "\">" + util.BytesToStr(util.EscapeHTML(util.StrToBytes(item.Label+" "+item.Caption))) + "</a></span><br>")
+	}
+	r.WriteString("</div>")
+}
+
+// renderPreview 返回节点 node 及其子节点渲染后的 HTML。
+func (r *HtmlRenderer) renderPreview(node *ast.Node) []byte {
+	r.LastOut = lex.ItemNewline
+	r.Writer = &bytes.Buffer{}
+	ast.Walk(node, r.renderNode)
+	return r.Writer.Bytes()
+}
+
 func (r *HtmlRenderer) RenderFootnotesDefs(context *parse.Context) []byte {
 	r.WriteString("<div class=\"footnotes-defs-div\">")
 	r.WriteString("<hr class=\"footnotes-defs-hr\" />\n")
@@ -237,6 +298,11 @@ func (r *HtmlRenderer) renderInlineMath(node *ast.Node, entering bool) ast.WalkS
 
 func (r *HtmlRenderer) renderMathBlockCloseMarker(node *ast.Node, entering bool) ast.WalkStatus {
 	r.tag("/div", nil, false)
+	if mathBlock := node.Parent; 0 < mathBlock.CaptionNumber {
+		r.tag("span", [][]string{{"class", "equation-number"}}, false)
+		r.WriteString("(" + strconv.Itoa(mathBlock.CaptionNumber) + ")")
+		r.tag("/span", nil, false)
+	}
 	return ast.WalkStop
 }
 
@@ -247,6 +313,9 @@ func (r *HtmlRenderer) renderMathBlockContent(node *ast.Node, entering bool) ast
 
 func (r *HtmlRenderer) renderMathBlockOpenMarker(node *ast.Node, entering bool) ast.WalkStatus {
 	attrs := [][]string{{"class", "vditor-math"}}
+	if id := node.Parent.Attributes["id"]; "" != id {
+		attrs = append(attrs, []string{"id", util.BytesToStr(util.EscapeHTML(util.StrToBytes(id)))})
+	}
 	r.tag("div", attrs, false)
 	return ast.WalkStop
 }
@@ -256,11 +325,32 @@ func (r *HtmlRenderer) renderMathBlock(node *ast.Node, entering bool) ast.WalkSt
 	return ast.WalkContinue
 }
 
+func (r *HtmlRenderer) renderFrontMatterCloseMarker(node *ast.Node, entering bool) ast.WalkStatus {
+	return ast.WalkStop
+}
+
+func (r *HtmlRenderer) renderFrontMatterContent(node *ast.Node, entering bool) ast.WalkStatus {
+	return ast.WalkStop
+}
+
+func (r *HtmlRenderer) renderFrontMatterOpenMarker(node *ast.Node, entering bool) ast.WalkStatus {
+	return ast.WalkStop
+}
+
+func (r *HtmlRenderer) renderFrontMatter(node *ast.Node, entering bool) ast.WalkStatus {
+	// 前置元数据仅用于描述文档，不渲染到 HTML 中
+	return ast.WalkSkipChildren
+}
+
 func (r *HtmlRenderer) renderTableCell(node *ast.Node, entering bool) ast.WalkStatus {
 	tag := "td"
 	if ast.NodeTableHead == node.Parent.Parent.Type {
 		tag = "th"
 	}
+	if 0 != node.TableCellSpanned {
+		// 被合并的单元格不输出
+		return ast.WalkSkipChildren
+	}
 	if entering {
 		var attrs [][]string
 		switch node.TableCellAlign {
@@ -271,6 +361,7 @@ func (r *HtmlRenderer) renderTableCell(node *ast.Node, entering bool) ast.WalkSt
 		case 3:
 			attrs = append(attrs, []string{"align", "right"})
 		}
+		attrs = append(attrs, r.tableCellSpanAttrs(node)...)
 		r.tag(tag, attrs, false)
 	} else {
 		r.tag("/"+tag, nil, false)
@@ -281,7 +372,7 @@ func (r *HtmlRenderer) renderTableCell(node *ast.Node, entering bool) ast.WalkSt
 
 func (r *HtmlRenderer) renderTableRow(node *ast.Node, entering bool) ast.WalkStatus {
 	if entering {
-		r.tag("tr", nil, false)
+		r.tag("tr", r.sourceLineAttrs(node), false)
 		r.Newline()
 	} else {
 		r.tag("/tr", nil, false)
@@ -305,12 +396,32 @@ func (r *HtmlRenderer) renderTableHead(node *ast.Node, entering bool) ast.WalkSt
 	return ast.WalkContinue
 }
 
+func (r *HtmlRenderer) renderTableCaption(node *ast.Node, entering bool) ast.WalkStatus {
+	if entering {
+		r.tag("caption", nil, false)
+		if table := node.Parent; 0 < table.CaptionNumber {
+			r.tag("span", [][]string{{"class", "caption-number"}}, false)
+			r.WriteString(util.BytesToStr(util.EscapeHTML(util.StrToBytes(r.Tree.Context.CaptionLabel(table)))))
+			r.tag("/span", nil, false)
+			r.WriteByte(lex.ItemSpace)
+		}
+	} else {
+		r.tag("/caption", nil, false)
+		r.Newline()
+	}
+	return ast.WalkContinue
+}
+
 func (r *HtmlRenderer) renderTable(node *ast.Node, entering bool) ast.WalkStatus {
 	if entering {
-		r.tag("table", nil, false)
+		var attrs [][]string
+		if 0 < node.CaptionNumber {
+			attrs = append(attrs, []string{"id", r.Tree.Context.CaptionID(node)})
+		}
+		r.tag("table", attrs, false)
 		r.Newline()
 	} else {
-		if nil != node.FirstChild.Next {
+		if nil != node.ChildByType(ast.NodeTableHead).Next {
 			r.tag("/tbody", nil, false)
 		}
 		r.Newline()
@@ -349,6 +460,320 @@ func (r *HtmlRenderer) renderStrikethrough2CloseMarker(node *ast.Node, entering
 	return ast.WalkStop
 }
 
+func (r *HtmlRenderer) renderMark(node *ast.Node, entering bool) ast.WalkStatus {
+	if entering {
+		r.TextAutoSpacePrevious(node)
+	} else {
+		r.TextAutoSpaceNext(node)
+	}
+	return ast.WalkContinue
+}
+
+func (r *HtmlRenderer) renderMarkOpenMarker(node *ast.Node, entering bool) ast.WalkStatus {
+	r.tag("mark", nil, false)
+	return ast.WalkStop
+}
+
+func (r *HtmlRenderer) renderMarkCloseMarker(node *ast.Node, entering bool) ast.WalkStatus {
+	r.tag("/mark", nil, false)
+	return ast.WalkStop
+}
+
+func (r *HtmlRenderer) renderSup(node *ast.Node, entering bool) ast.WalkStatus {
+	if entering {
+		r.TextAutoSpacePrevious(node)
+	} else {
+		r.TextAutoSpaceNext(node)
+	}
+	return ast.WalkContinue
+}
+
+func (r *HtmlRenderer) renderSupOpenMarker(node *ast.Node, entering bool) ast.WalkStatus {
+	r.tag("sup", nil, false)
+	return ast.WalkStop
+}
+
+func (r *HtmlRenderer) renderSupCloseMarker(node *ast.Node, entering bool) ast.WalkStatus {
+	r.tag("/sup", nil, false)
+	return ast.WalkStop
+}
+
+func (r *HtmlRenderer) renderSub(node *ast.Node, entering bool) ast.WalkStatus {
+	if entering {
+		r.TextAutoSpacePrevious(node)
+	} else {
+		r.TextAutoSpaceNext(node)
+	}
+	return ast.WalkContinue
+}
+
+func (r *HtmlRenderer) renderSubOpenMarker(node *ast.Node, entering bool) ast.WalkStatus {
+	r.tag("sub", nil, false)
+	return ast.WalkStop
+}
+
+func (r *HtmlRenderer) renderSubCloseMarker(node *ast.Node, entering bool) ast.WalkStatus {
+	r.tag("/sub", nil, false)
+	return ast.WalkStop
+}
+
+func (r *HtmlRenderer) renderReference(node *ast.Node, entering bool) ast.WalkStatus {
+	url, exists := r.Tree.Context.ResolveReference(node)
+	if !exists {
+		r.Write(util.EscapeHTML(node.Tokens))
+		return ast.WalkStop
+	}
+	r.tag("a", [][]string{{"href", util.BytesToStr(util.EscapeHTML(util.StrToBytes(url)))}, {"class", node.ReferenceType}}, false)
+	r.Write(util.EscapeHTML(node.Tokens))
+	r.tag("/a", nil, false)
+	return ast.WalkStop
+}
+
+func (r *HtmlRenderer) renderCrossRef(node *ast.Node, entering bool) ast.WalkStatus {
+	target := r.Tree.Context.CrossRefs[node.CrossRefLabel]
+	if nil == target {
+		r.tag("span", [][]string{{"class", "cross-ref cross-ref-undefined"}}, false)
+		r.Write(util.EscapeHTML(node.Tokens))
+		r.tag("/span", nil, false)
+		return ast.WalkStop
+	}
+
+	id := node.CrossRefLabel
+	switch target.Type {
+	case ast.NodeHeading:
+		id = HeadingID(target)
+	case ast.NodeImage, ast.NodeTable:
+		id = r.Tree.Context.CaptionID(target)
+	}
+	r.tag("a", [][]string{{"href", "#" + util.BytesToStr(util.EscapeHTML(util.StrToBytes(id)))}, {"class", "cross-ref"}}, false)
+	r.Write(util.EscapeHTML(util.StrToBytes(node.CrossRefText)))
+	r.tag("/a", nil, false)
+	return ast.WalkStop
+}
+
+func (r *HtmlRenderer) renderCitation(node *ast.Node, entering bool) ast.WalkStatus {
+	context := r.Tree.Context
+	numeric := parse.CitationStyleNumeric == r.Option.CitationStyle
+	var keys []string
+	for _, item := range node.CitationItems {
+		keys = append(keys, item.Key)
+	}
+	r.tag("span", [][]string{{"class", "citation"}, {"data-cites", util.BytesToStr(util.EscapeHTML(util.StrToBytes(strings.Join(keys, " "))))}}, false)
+
+	if node.CitationInText {
+		// 行文中的引用 @smith2020 渲染为 Smith (2020) 或者 Smith [1]
+		item := node.CitationItems[0]
+		entry := r.Option.Bibliography[item.Key]
+		if nil == entry {
+			r.renderUndefinedCitationItem(item)
+		} else {
+			text := entry.CitationAuthor() + " (" + entry.CitationYear() + ")"
+			if numeric {
+				text = entry.CitationAuthor() + " [" + strconv.Itoa(context.CitationNumber(item.Key)) + "]"
+			}
+			r.renderCitationItem(item, text)
+		}
+		r.tag("/span", nil, false)
+		return ast.WalkStop
+	}
+
+	opener, separator, closer := "(", "; ", ")"
+	if numeric {
+		opener, separator, closer = "[", ", ", "]"
+		for _, item := range node.CitationItems {
+			if "" != item.Prefix || "" != item.Suffix {
+				separator = "; "
+			}
+		}
+	}
+	r.WriteString(opener)
+	for i, item := range node.CitationItems {
+		if 0 < i {
+			r.WriteString(separator)
+		}
+		if "" != item.Prefix {
+			r.Write(util.EscapeHTML(util.StrToBytes(item.Prefix + " ")))
+		}
+		entry := r.Option.Bibliography[item.Key]
+		if nil == entry {
+			r.renderUndefinedCitationItem(item)
+			continue
+		}
+
+		var text string
+		if numeric {
+			text = strconv.Itoa(context.CitationNumber(item.Key))
+		} else if item.SuppressAuthor {
+			text = entry.CitationYear()
+		} else {
+			text = entry.CitationAuthor() + " " + entry.CitationYear()
+		}
+		if "" != item.Suffix {
+			if ',' != item.Suffix[0] {
+				text += " "
+			}
+			text += item.Suffix
+		}
+		r.renderCitationItem(item, text)
+	}
+	r.WriteString(closer)
+	r.tag("/span", nil, false)
+	return ast.WalkStop
+}
+
+func (r *HtmlRenderer) renderCitationItem(item *ast.CitationItem, text string) {
+	r.tag("a", [][]string{{"href", "#ref-" + util.BytesToStr(util.EscapeHTML(util.StrToBytes(item.Key)))}}, false)
+	r.Write(util.EscapeHTML(util.StrToBytes(text)))
+	r.tag("/a", nil, false)
+}
+
+// renderUndefinedCitationItem 渲染参考文献库中不存在的文献，渲染为加粗的 key?。
+func (r *HtmlRenderer) renderUndefinedCitationItem(item *ast.CitationItem) {
+	r.tag("strong", [][]string{{"class", "citation-undefined"}}, false)
+	r.Write(util.EscapeHTML(util.StrToBytes(item.Key + "?")))
+	r.tag("/strong", nil, false)
+}
+
+// RenderBibliography 在已经渲染的内容后追加引用过的文献列表。
+func (r *HtmlRenderer) RenderBibliography(context *parse.Context) []byte {
+	numeric := parse.CitationStyleNumeric == context.Option.CitationStyle
+	r.WriteString("<div id=\"refs\" class=\"references\">\n")
+	for _, entry := range context.CitedEntries() {
+		r.WriteString("<div id=\"ref-" + util.BytesToStr(util.EscapeHTML(util.StrToBytes(entry.Key))) + "\" class=\"csl-entry\">")
+		if numeric {
+			r.WriteString("<span class=\"csl-left-margin\">[" + strconv.Itoa(context.CitationNumber(entry.Key)) + "]</span> ")
+		}
+		r.renderBibEntry(entry)
+		r.WriteString("</div>\n")
+	}
+	r.WriteString("</div>")
+	return r.Writer.Bytes()
+}
+
+// renderBibEntry 按照 作者. 年份. 标题. 期刊, 页码. 出版者. 链接 的格式渲染一条文献。
+func (r *HtmlRenderer) renderBibEntry(entry *parse.BibEntry) {
+	var names []string
+	for i, author := range entry.Authors {
+		if 0 == i && "" == author.Literal {
+			names = append(names, strings.TrimSuffix(author.Family+", "+author.Given, ", "))
+		} else {
+			names = append(names, author.String())
+		}
+	}
+	authors := strings.Join(names, ", ")
+	if 1 < len(names) {
+		authors = strings.Join(names[:len(names)-1], ", ") + " and " + names[len(names)-1]
+	}
+
+	container := util.BytesToStr(util.EscapeHTML(util.StrToBytes(entry.Container)))
+	if "" != container {
+		container = "<em>" + container + "</em>"
+		if "" != entry.Pages {
+			container += ", " + util.BytesToStr(util.EscapeHTML(util.StrToBytes(entry.Pages)))
+		}
+	}
+	var parts []string
+	for _, part := range []string{authors, entry.CitationYear(), entry.Title} {
+		if "" != part {
+			parts = append(parts, util.BytesToStr(util.EscapeHTML(util.StrToBytes(part))))
+		}
+	}
+	if "" != container {
+		parts = append(parts, container)
+	}
+	if "" != entry.Publisher {
+		parts = append(parts, util.BytesToStr(util.EscapeHTML(util.StrToBytes(entry.Publisher))))
+	}
+	for i, part := range parts {
+		if 0 < i {
+			r.WriteString(" ")
+		}
+		r.WriteString(part)
+		if !strings.HasSuffix(part, ".") && !strings.HasSuffix(part, "?") && !strings.HasSuffix(part, "!") {
+			r.WriteString(".")
+		}
+	}
+
+	link := entry.URL
+	if "" != entry.DOI {
+		link = "https://doi.org/" + entry.DOI
+	}
+	if "" != link {
+		link = util.BytesToStr(util.EscapeHTML(util.StrToBytes(link)))
+		r.WriteString(" <a href=\"" + link + "\">" + link + "</a>")
+	}
+}
+
+func (r *HtmlRenderer) renderTag(node *ast.Node, entering bool) ast.WalkStatus {
+	text := "#" + util.BytesToStr(node.Tokens)
+	if node.TagClosed {
+		text += "#"
+	}
+	if url := r.Tree.Context.TagURL(node); "" != url {
+		r.tag("a", [][]string{{"href", util.BytesToStr(util.EscapeHTML(util.StrToBytes(url)))}, {"class", "tag"}}, false)
+		r.Write(util.EscapeHTML(util.StrToBytes(text)))
+		r.tag("/a", nil, false)
+	} else {
+		r.tag("span", [][]string{{"class", "tag"}}, false)
+		r.Write(util.EscapeHTML(util.StrToBytes(text)))
+		r.tag("/span", nil, false)
+	}
+	return ast.WalkStop
+}
+
+func (r *HtmlRenderer) renderRuby(node *ast.Node, entering bool) ast.WalkStatus {
+	r.tag("ruby", nil, false)
+	for i, base := range node.RubyBases {
+		r.tag("rb", nil, false)
+		r.Write(util.EscapeHTML(util.StrToBytes(base)))
+		r.tag("/rb", nil, false)
+		r.tag("rt", nil, false)
+		r.Write(util.EscapeHTML(util.StrToBytes(node.RubyTexts[i])))
+		r.tag("/rt", nil, false)
+	}
+	r.tag("/ruby", nil, false)
+	return ast.WalkStop
+}
+
+func (r *HtmlRenderer) renderAbbr(node *ast.Node, entering bool) ast.WalkStatus {
+	if entering {
+		r.TextAutoSpacePrevious(node)
+		var attrs [][]string
+		if "" != node.AbbrTitle {
+			attrs = append(attrs, []string{"title", util.BytesToStr(util.EscapeHTML(util.StrToBytes(node.AbbrTitle)))})
+		}
+		r.tag("abbr", attrs, false)
+	} else {
+		r.tag("/abbr", nil, false)
+		r.TextAutoSpaceNext(node)
+	}
+	return ast.WalkContinue
+}
+
+func (r *HtmlRenderer) renderWikiLink(node *ast.Node, entering bool) ast.WalkStatus {
+	url, exists := r.Tree.Context.ResolveWikiLink(node)
+	class := "wiki-link"
+	if !exists {
+		class += " wiki-link--new"
+	}
+	href := r.Tree.Context.RelativePath(util.StrToBytes(url))
+	r.tag("a", [][]string{{"href", util.BytesToStr(util.EscapeHTML(href))}, {"class", class}}, false)
+	r.Write(util.EscapeHTML(util.StrToBytes(wikiLinkText(node))))
+	r.tag("/a", nil, false)
+	return ast.WalkStop
+}
+
+// wikiLinkText 返回维基链接 node 的显示文本，没有指定显示文本时使用链接目标 Page#Heading。
+func wikiLinkText(node *ast.Node) string {
+	if "" != node.WikiLinkLabel {
+		return node.WikiLinkLabel
+	}
+	if "" == node.WikiLinkAnchor {
+		return node.WikiLinkPage
+	}
+	return node.WikiLinkPage + "#" + node.WikiLinkAnchor
+}
+
 func (r *HtmlRenderer) renderLinkTitle(node *ast.Node, entering bool) ast.WalkStatus {
 	return ast.WalkStop
 }
@@ -365,6 +790,9 @@ func (r *HtmlRenderer) renderLinkText(node *ast.Node, entering bool) ast.WalkSta
 	if r.Option.AutoSpace {
 		r.Space(node)
 	}
+	if "" != r.Option.ChineseConvert {
+		r.ConvertChinese(node)
+	}
 	r.Write(util.EscapeHTML(node.Tokens))
 	return ast.WalkStop
 }
@@ -414,6 +842,16 @@ func (r *HtmlRenderer) renderImage(node *ast.Node, entering bool) ast.WalkStatus
 			r.Write(util.EscapeHTML(title.Tokens))
 			r.WriteString("\"")
 		}
+		if 0 < node.CaptionNumber && "" == node.Attributes["id"] { // 自定义 ID 会作为属性输出
+			r.WriteString(" id=\"" + r.Tree.Context.CaptionID(node) + "\"")
+		}
+		for _, attr := range r.attributeListAttrs(node) {
+			switch attr[0] {
+			case "src", "data-src", "alt", "title": // 已经根据图片本身输出
+				continue
+			}
+			r.WriteString(" " + attr[0] + "=\"" + attr[1] + "\"")
+		}
 		r.WriteString(" />")
 
 		if r.Option.Sanitize {
@@ -426,6 +864,16 @@ func (r *HtmlRenderer) renderImage(node *ast.Node, entering bool) ast.WalkStatus
 			r.Writer.Truncate(idx)
 			r.Writer.Write(imgBuf)
 		}
+
+		if 0 < node.CaptionNumber {
+			// 已编号的图在图片后输出编号和标题
+			r.WriteString("<span class=\"figure-caption\"><span class=\"caption-number\">" + util.BytesToStr(util.EscapeHTML(util.StrToBytes(r.Tree.Context.CaptionLabel(node)))) + "</span>")
+			if title := node.ChildByType(ast.NodeLinkTitle); nil != title && 0 < len(title.Tokens) {
+				r.WriteString(" ")
+				r.Write(util.EscapeHTML(title.Tokens))
+			}
+			r.WriteString("</span>")
+		}
 	}
 	return ast.WalkContinue
 }
@@ -441,6 +889,7 @@ func (r *HtmlRenderer) renderLink(node *ast.Node, entering bool) ast.WalkStatus
 		if title := node.ChildByType(ast.NodeLinkTitle); nil != title && nil != title.Tokens {
 			attrs = append(attrs, []string{"title", util.BytesToStr(util.EscapeHTML(title.Tokens))})
 		}
+		attrs = mergeAttrs(attrs, r.attributeListAttrs(node))
 		r.tag("a", attrs, false)
 	} else {
 		r.tag("/a", nil, false)
@@ -478,10 +927,13 @@ func (r *HtmlRenderer) renderParagraph(node *ast.Node, entering bool) ast.WalkSt
 	if grandparent := node.Parent.Parent; nil != grandparent && ast.NodeList == grandparent.Type && grandparent.Tight { // List.ListItem.Paragraph
 		return ast.WalkContinue
 	}
+	if parent := node.Parent; ast.NodeDefinitionDescription == parent.Type && parent.Tight { // DefinitionDescription.Paragraph
+		return ast.WalkContinue
+	}
 
 	if entering {
 		r.Newline()
-		r.tag("p", nil, false)
+		r.tag("p", append(r.sourceLineAttrs(node), r.attributeListAttrs(node)...), false)
 		if r.Option.ChineseParagraphBeginningSpace && ast.NodeDocument == node.Parent.Type {
 			r.WriteString("&emsp;&emsp;")
 		}
@@ -502,6 +954,9 @@ func (r *HtmlRenderer) renderText(node *ast.Node, entering bool) ast.WalkStatus
 	if r.Option.ChinesePunct {
 		r.ChinesePunct(node)
 	}
+	if "" != r.Option.ChineseConvert {
+		r.ConvertChinese(node)
+	}
 	r.Write(util.EscapeHTML(node.Tokens))
 	return ast.WalkStop
 }
@@ -554,7 +1009,7 @@ func (r *HtmlRenderer) renderEmphasis(node *ast.Node, entering bool) ast.WalkSta
 }
 
 func (r *HtmlRenderer) renderEmAsteriskOpenMarker(node *ast.Node, entering bool) ast.WalkStatus {
-	r.tag("em", nil, false)
+	r.tag("em", r.attributeListAttrs(node.Parent), false)
 	return ast.WalkStop
 }
 
@@ -564,7 +1019,7 @@ func (r *HtmlRenderer) renderEmAsteriskCloseMarker(node *ast.Node, entering bool
 }
 
 func (r *HtmlRenderer) renderEmUnderscoreOpenMarker(node *ast.Node, entering bool) ast.WalkStatus {
-	r.tag("em", nil, false)
+	r.tag("em", r.attributeListAttrs(node.Parent), false)
 	return ast.WalkStop
 }
 
@@ -583,7 +1038,7 @@ func (r *HtmlRenderer) renderStrong(node *ast.Node, entering bool) ast.WalkStatu
 }
 
 func (r *HtmlRenderer) renderStrongA6kOpenMarker(node *ast.Node, entering bool) ast.WalkStatus {
-	r.tag("strong", nil, false)
+	r.tag("strong", r.attributeListAttrs(node.Parent), false)
 	return ast.WalkStop
 }
 
@@ -593,7 +1048,7 @@ func (r *HtmlRenderer) renderStrongA6kCloseMarker(node *ast.Node, entering bool)
 }
 
 func (r *HtmlRenderer) renderStrongU8eOpenMarker(node *ast.Node, entering bool) ast.WalkStatus {
-	r.tag("strong", nil, false)
+	r.tag("strong", r.attributeListAttrs(node.Parent), false)
 	return ast.WalkStop
 }
 
@@ -605,7 +1060,7 @@ func (r *HtmlRenderer) renderStrongU8eCloseMarker(node *ast.Node, entering bool)
 func (r *HtmlRenderer) renderBlockquote(node *ast.Node, entering bool) ast.WalkStatus {
 	if entering {
 		r.Newline()
-		r.WriteString("<blockquote>")
+		r.WriteString("<blockquote" + r.sourceLineAttrsStr(node) + ">")
 		r.Newline()
 	} else {
 		r.Newline()
@@ -619,6 +1074,48 @@ func (r *HtmlRenderer) renderBlockquoteMarker(node *ast.Node, entering bool) ast
 	return ast.WalkStop
 }
 
+func (r *HtmlRenderer) renderCustomContainer(node *ast.Node, entering bool) ast.WalkStatus {
+	html, status := CustomContainerHTML(node, entering)
+	r.Newline()
+	r.WriteString(html)
+	return status
+}
+
+// CustomContainerHTML 返回自定义容器 node 的默认 HTML 渲染结果。
+// 该函数签名和 ExtRendererFunc 一致，按容器名称自定义渲染时可以用它来渲染其他名称的容器。
+func CustomContainerHTML(node *ast.Node, entering bool) (string, ast.WalkStatus) {
+	if !entering {
+		return "</div>\n", ast.WalkContinue
+	}
+
+	ret := "<div class=\"" + util.BytesToStr(util.EscapeHTML([]byte(node.CustomContainerName))) + "\">\n"
+	if "" != node.CustomContainerArgs {
+		ret += "<p class=\"custom-container-title\">" + util.BytesToStr(util.EscapeHTML([]byte(node.CustomContainerArgs))) + "</p>\n"
+	}
+	return ret, ast.WalkContinue
+}
+
+func (r *HtmlRenderer) renderGitHubAlert(node *ast.Node, entering bool) ast.WalkStatus {
+	if entering {
+		r.Newline()
+		r.tag("div", append([][]string{{"class", "markdown-alert markdown-alert-" + node.GitHubAlertType}}, r.sourceLineAttrs(node)...), false)
+		r.Newline()
+	} else {
+		r.Newline()
+		r.tag("/div", nil, false)
+		r.Newline()
+	}
+	return ast.WalkContinue
+}
+
+func (r *HtmlRenderer) renderGitHubAlertMarker(node *ast.Node, entering bool) ast.WalkStatus {
+	r.tag("p", [][]string{{"class", "markdown-alert-title"}}, false)
+	r.WriteString(parse.GitHubAlertTitles[node.GitHubAlertType])
+	r.tag("/p", nil, false)
+	r.Newline()
+	return ast.WalkStop
+}
+
 var headingLevel = " 123456"
 
 func (r *HtmlRenderer) renderHeading(node *ast.Node, entering bool) ast.WalkStatus {
@@ -627,10 +1124,19 @@ func (r *HtmlRenderer) renderHeading(node *ast.Node, entering bool) ast.WalkStat
 		level := headingLevel[node.HeadingLevel : node.HeadingLevel+1]
 		r.WriteString("<h" + level)
 		id := HeadingID(node)
-		if r.Option.ToC || r.Option.HeadingID {
+		if r.Option.ToC || r.Option.HeadingID || "" != node.Attributes["id"] {
 			r.WriteString(" id=\"" + id + "\"")
 		}
+		r.WriteString(r.sourceLineAttrsStr(node))
+		for _, attr := range r.attributeListAttrs(node) {
+			if "id" != attr[0] { // 自定义 ID 已经作为标题 ID 输出
+				r.WriteString(" " + attr[0] + "=\"" + attr[1] + "\"")
+			}
+		}
 		r.WriteString(">")
+		if r.Option.HeadingNumbering && "" != node.HeadingNumber {
+			r.WriteString("<span class=\"heading-number\">" + node.HeadingNumber + "</span> ")
+		}
 	} else {
 		if r.Option.HeadingAnchor {
 			id := HeadingID(node)
@@ -687,9 +1193,9 @@ func (r *HtmlRenderer) renderListItem(node *ast.Node, entering bool) ast.WalkSta
 	if entering {
 		if 3 == node.ListData.Typ && "" != r.Option.GFMTaskListItemClass &&
 			nil != node.FirstChild && nil != node.FirstChild.FirstChild && ast.NodeTaskListItemMarker == node.FirstChild.FirstChild.Type {
-			r.tag("li", [][]string{{"class", r.Option.GFMTaskListItemClass}}, false)
+			r.tag("li", append([][]string{{"class", r.Option.GFMTaskListItemClass}}, r.sourceLineAttrs(node)...), false)
 		} else {
-			r.tag("li", nil, false)
+			r.tag("li", r.sourceLineAttrs(node), false)
 		}
 	} else {
 		r.tag("/li", nil, false)
@@ -698,6 +1204,39 @@ func (r *HtmlRenderer) renderListItem(node *ast.Node, entering bool) ast.WalkSta
 	return ast.WalkContinue
 }
 
+func (r *HtmlRenderer) renderDefinitionList(node *ast.Node, entering bool) ast.WalkStatus {
+	if entering {
+		r.Newline()
+		r.tag("dl", nil, false)
+		r.Newline()
+	} else {
+		r.Newline()
+		r.tag("/dl", nil, false)
+		r.Newline()
+	}
+	return ast.WalkContinue
+}
+
+func (r *HtmlRenderer) renderDefinitionTerm(node *ast.Node, entering bool) ast.WalkStatus {
+	if entering {
+		r.tag("dt", r.sourceLineAttrs(node), false)
+	} else {
+		r.tag("/dt", nil, false)
+		r.Newline()
+	}
+	return ast.WalkContinue
+}
+
+func (r *HtmlRenderer) renderDefinitionDescription(node *ast.Node, entering bool) ast.WalkStatus {
+	if entering {
+		r.tag("dd", r.sourceLineAttrs(node), false)
+	} else {
+		r.tag("/dd", nil, false)
+		r.Newline()
+	}
+	return ast.WalkContinue
+}
+
 func (r *HtmlRenderer) renderTaskListItemMarker(node *ast.Node, entering bool) ast.WalkStatus {
 	if entering {
 		var attrs [][]string
@@ -705,6 +1244,10 @@ func (r *HtmlRenderer) renderTaskListItemMarker(node *ast.Node, entering bool) a
 			attrs = append(attrs, []string{"checked", ""})
 		}
 		attrs = append(attrs, []string{"disabled", ""}, []string{"type", "checkbox"})
+		attrs = append(attrs, r.taskListItemStateAttrs(node)...)
+		if r.Option.TaskListItemIndex && 0 <= node.TaskListItemIndex {
+			attrs = append(attrs, []string{"data-task-index", strconv.Itoa(node.TaskListItemIndex)})
+		}
 		r.tag("input", attrs, true)
 	}
 	return ast.WalkContinue
@@ -729,7 +1272,7 @@ func (r *HtmlRenderer) renderSoftBreak(node *ast.Node, entering bool) ast.WalkSt
 	if r.Option.SoftBreak2HardBreak {
 		r.tag("br", nil, true)
 		r.Newline()
-	} else {
+	} else if !r.dropSoftBreak(node) {
 		r.Newline()
 	}
 	return ast.WalkStop
@@ -12,6 +12,8 @@ package render
 
 import (
 	"bytes"
+	"sort"
+	"strconv"
 	"strings"
 	"unicode"
 	"unicode/utf8"
@@ -60,29 +62,60 @@ func (r *BaseRenderer) Render() (output []byte) {
 	r.Writer = &bytes.Buffer{}
 	r.Writer.Grow(4096)
 
-	ast.Walk(r.Tree.Root, func(n *ast.Node, entering bool) ast.WalkStatus {
-		extRender := r.ExtRendererFuncs[n.Type]
-		if nil != extRender {
-			output, status := extRender(n, entering)
-			r.WriteString(output)
-			return status
-		}
+	ast.Walk(r.Tree.Root, r.renderNode)
 
-		render := r.RendererFuncs[n.Type]
-		if nil == render {
-			if nil != r.DefaultRendererFunc {
-				return r.DefaultRendererFunc(n, entering)
-			} else {
-				return r.renderDefault(n, entering)
-			}
+	output = r.Writer.Bytes()
+	return
+}
+
+// renderNode 使用节点 n 对应的渲染器函数渲染该节点。
+func (r *BaseRenderer) renderNode(n *ast.Node, entering bool) ast.WalkStatus {
+	extRender := r.ExtRendererFuncs[n.Type]
+	if nil != extRender {
+		output, status := extRender(n, entering)
+		r.WriteString(output)
+		return status
+	}
+
+	render := r.RendererFuncs[n.Type]
+	if nil == render {
+		if nil != r.DefaultRendererFunc {
+			return r.DefaultRendererFunc(n, entering)
+		} else {
+			return r.renderDefault(n, entering)
 		}
-		return render(n, entering)
-	})
+	}
+	return render(n, entering)
+}
 
-	output = r.Writer.Bytes()
+// nodeSource 返回节点 node 的 Markdown 源码。
+// Vditor 渲染器中没有对应编辑元素的扩展语法节点以源码的形式输出，转换回 Markdown 时直接使用源码。
+func (r *BaseRenderer) nodeSource(node *ast.Node) []byte {
+	return bytes.TrimSpace(NewFormatRenderer(r.Tree).renderSource(node))
+}
+
+// editableFootnotesDefs 返回 Vditor 编辑器中需要在脚注块中渲染的脚注定义。
+// 不提升的行内脚注 ^[note] 作为行内源码编辑，其脚注定义不在脚注块中渲染，这样回写 Markdown 时保持行内写法。
+func (r *BaseRenderer) editableFootnotesDefs(context *parse.Context) (ret []*ast.Node) {
+	for _, def := range context.FootnotesDefs {
+		if !r.inlineFootnotes(def) {
+			ret = append(ret, def)
+		}
+	}
 	return
 }
 
+// inlineFootnotes 判断脚注引用或者脚注定义 node 是否是需要保持行内写法的行内脚注。
+func (r *BaseRenderer) inlineFootnotes(node *ast.Node) bool {
+	return nil != node.FootnotesInline && !r.Option.HoistInlineFootnotes
+}
+
+// nodePreview 返回节点 node 的 HTML 预览。
+func (r *BaseRenderer) nodePreview(node *ast.Node) []byte {
+	preview := NewHtmlRenderer(r.Tree).renderPreview(node)
+	return bytes.ReplaceAll(preview, []byte(parse.Caret), nil)
+}
+
 func (r *BaseRenderer) renderDefault(n *ast.Node, entering bool) ast.WalkStatus {
 	r.WriteString("not found render function for node [type=" + n.Type.String() + ", Tokens=" + util.BytesToStr(n.Tokens) + "]")
 	return ast.WalkContinue
@@ -118,6 +151,140 @@ func (r *BaseRenderer) Newline() {
 	}
 }
 
+// sourceLineAttrs 返回块节点 node 的源码行号属性 data-source-line 和 data-source-end，未开启 RenderSourceLine 或者节点没有源码位置时返回 nil。
+func (r *BaseRenderer) sourceLineAttrs(node *ast.Node) [][]string {
+	if !r.Option.RenderSourceLine || 1 > node.StartLn {
+		return nil
+	}
+	return [][]string{{"data-source-line", strconv.Itoa(node.StartLn)}, {"data-source-end", strconv.Itoa(node.EndLn)}}
+}
+
+// sourceLineAttrsStr 返回拼接好的源码行号属性字符串，用于直接输出标签的情况。
+func (r *BaseRenderer) sourceLineAttrsStr(node *ast.Node) (ret string) {
+	for _, attr := range r.sourceLineAttrs(node) {
+		ret += " " + attr[0] + "=\"" + attr[1] + "\""
+	}
+	return
+}
+
+// tableCellSpanAttrs 返回单元格 node 的 colspan 和 rowspan 属性，没有合并单元格时返回 nil。
+func (r *BaseRenderer) tableCellSpanAttrs(node *ast.Node) (ret [][]string) {
+	if 1 < node.TableCellColspan {
+		ret = append(ret, []string{"colspan", strconv.Itoa(node.TableCellColspan)})
+	}
+	if 1 < node.TableCellRowspan {
+		ret = append(ret, []string{"rowspan", strconv.Itoa(node.TableCellRowspan)})
+	}
+	return
+}
+
+// attributeListAttrs 返回节点 node 属性列表中的属性，属性值会进行 HTML 转义。启用 XSS 安全过滤时只保留安全的属性。
+func (r *BaseRenderer) attributeListAttrs(node *ast.Node) (ret [][]string) {
+	if 1 > len(node.Attributes) {
+		return
+	}
+
+	for _, key := range append([]string{"id", "class"}, parse.SortedAttributeKeys(node.Attributes)...) {
+		value, ok := node.Attributes[key]
+		if !ok {
+			continue
+		}
+		if r.Option.Sanitize && (!allowAttr(key) || strings.HasPrefix(strings.ToLower(strings.TrimSpace(value)), "javascript")) {
+			continue
+		}
+		ret = append(ret, []string{key, util.BytesToStr(util.EscapeHTML(util.StrToBytes(value)))})
+	}
+	return
+}
+
+// mergeAttrs 将属性列表中的属性 extra 合并到已有的属性 attrs 中：class 追加到已有的 class 之后，id 覆盖已有的 id，
+// 其他已有的属性（比如链接的 href）不会被属性列表覆盖，也不会重复输出。
+func mergeAttrs(attrs, extra [][]string) [][]string {
+	for _, attr := range extra {
+		i := 0
+		for ; i < len(attrs); i++ {
+			if attrs[i][0] == attr[0] {
+				break
+			}
+		}
+		if i == len(attrs) {
+			attrs = append(attrs, attr)
+			continue
+		}
+		switch attr[0] {
+		case "class":
+			attrs[i] = []string{"class", attrs[i][1] + " " + attr[1]}
+		case "id":
+			attrs[i] = attr
+		}
+	}
+	return attrs
+}
+
+// attributeListAttrsStr 返回拼接好的属性列表属性字符串，用于直接输出标签的情况。
+func (r *BaseRenderer) attributeListAttrsStr(node *ast.Node) (ret string) {
+	for _, attr := range r.attributeListAttrs(node) {
+		ret += " " + attr[0] + "=\"" + attr[1] + "\""
+	}
+	return
+}
+
+// attributeListDataAttrs 返回 Vditor 编辑器使用的 data-attrs 属性，用于在编辑器 DOM 和 Markdown 之间转换时保留属性列表。
+func (r *BaseRenderer) attributeListDataAttrs(node *ast.Node) [][]string {
+	if 1 > len(node.Attributes) {
+		return nil
+	}
+	return [][]string{{"data-attrs", util.BytesToStr(util.EscapeHTML(util.StrToBytes(parse.AttributeListStr(node.Attributes))))}}
+}
+
+// attributeListDataAttrsStr 返回拼接好的 data-attrs 属性字符串，用于直接输出标签的情况。
+func (r *BaseRenderer) attributeListDataAttrsStr(node *ast.Node) (ret string) {
+	for _, attr := range r.attributeListDataAttrs(node) {
+		ret += " " + attr[0] + "=\"" + attr[1] + "\""
+	}
+	return
+}
+
+// taskListItemStateAttrs 返回扩展任务状态的 data-task 和 class 属性，[ ] 和 [x] 等未配置名称的状态返回空。
+func (r *BaseRenderer) taskListItemStateAttrs(node *ast.Node) [][]string {
+	name := r.Option.TaskListItemStates[node.TaskListItemState]
+	if "" == name {
+		return nil
+	}
+	state := util.BytesToStr(util.EscapeHTML([]byte{node.TaskListItemState}))
+	return [][]string{{"data-task", state}, {"class", "task-" + name}}
+}
+
+// abbreviationDefs 返回按缩写排序的缩写定义行 *[HTML]: Hyper Text Markup Language。
+func (r *BaseRenderer) abbreviationDefs() (ret []string) {
+	abbrs := make([]string, 0, len(r.Tree.Context.Abbreviations))
+	for abbr := range r.Tree.Context.Abbreviations {
+		abbrs = append(abbrs, abbr)
+	}
+	sort.Strings(abbrs)
+	for _, abbr := range abbrs {
+		ret = append(ret, strings.TrimSpace("*["+abbr+"]: "+r.Tree.Context.Abbreviations[abbr]))
+	}
+	return
+}
+
+// dropSoftBreak 判断是否需要去掉软换行 node：关闭软换行转硬换行并打开 CJKSoftBreak 时，两侧都是东亚宽字符的软换行不输出。
+func (r *BaseRenderer) dropSoftBreak(node *ast.Node) bool {
+	if r.Option.SoftBreak2HardBreak || !r.Option.CJKSoftBreak {
+		return false
+	}
+
+	prevLast, _ := utf8.DecodeLastRuneInString(node.PreviousNodeText())
+	nextFirst, _ := utf8.DecodeRuneInString(node.NextNodeText())
+	return isCJKWide(prevLast) && isCJKWide(nextFirst)
+}
+
+// isCJKWide 判断 r 是否是折行时不需要空格分隔的东亚宽字符。韩文使用空格分词，所以谚文不在其中，
+// 参考 https://www.w3.org/TR/css-text-3/#line-break-transform
+func isCJKWide(r rune) bool {
+	return lex.IsCJK(r) && !unicode.Is(unicode.Hangul, r)
+}
+
 func (r *BaseRenderer) TextAutoSpacePrevious(node *ast.Node) {
 	if r.Option.AutoSpace {
 		if text := node.ChildByType(ast.NodeText); nil != text && nil != text.Tokens {
@@ -228,3 +395,57 @@ func (r *BaseRenderer) headings() (ret []*ast.Node) {
 	}
 	return
 }
+
+// renderVditorToC 渲染 Vditor 中的目录块 node，块上的 data-marker 记录了目录标记符 [toc]、[lof] 或者 [lot]。
+func (r *BaseRenderer) renderVditorToC(node *ast.Node) {
+	tocType := node.ToCType
+	if "" == tocType {
+		tocType = parse.ToCHeadings
+	}
+	marker := "[" + tocType + "]"
+	r.WriteString("<div class=\"vditor-toc\" data-block=\"0\" data-type=\"toc-block\" data-marker=\"" + marker + "\" contenteditable=\"false\">")
+	var items []*parse.NumberedItem
+	switch tocType {
+	case parse.ToCTables:
+		items = r.Tree.NumberedTables()
+	case parse.ToCFigures:
+		items = r.Tree.NumberedFigures()
+	default:
+		for _, heading := range r.headings() {
+			spaces := (heading.HeadingLevel - 1) * 2
+			r.WriteString(strings.Repeat("&emsp;", spaces))
+			r.WriteString("<span data-type=\"toc-h\">")
+			r.WriteString(heading.Text() + "</span><br>")
+			marker = ""
+		}
+	}
+	for _, item := range items {
+		r.WriteString("<span data-type=\"toc-h\">")
+		r.Write(util.EscapeHTML(util.StrToBytes(item.Label + " " + item.Caption)))
+		r.WriteString("</span><br>")
+		marker = ""
+	}
+	if "" != marker {
+		r.WriteString(marker + "<br>")
+	}
+	r.WriteString("</div>")
+}
+
+// renderVditorTableCaption 渲染 Vditor 中的表标题 node，标题以源码形式输出，data-before 记录标题是否位于表之前。
+func (r *BaseRenderer) renderVditorTableCaption(node *ast.Node) {
+	r.WriteString("<caption data-type=\"table-caption\"")
+	if node.TableCaptionBefore {
+		r.WriteString(" data-before=\"1\"")
+	}
+	r.WriteString(">")
+	format, buf := NewFormatRenderer(r.Tree), &bytes.Buffer{}
+	for c := node.FirstChild; nil != c; c = c.Next {
+		buf.Write(format.renderSource(c))
+	}
+	caption := util.BytesToStr(bytes.TrimSpace(buf.Bytes()))
+	if table := node.Parent; 0 < len(table.Attributes) {
+		caption += " " + parse.AttributeListStr(table.Attributes)
+	}
+	r.Write(util.EscapeHTML(util.StrToBytes(caption)))
+	r.WriteString("</caption>")
+}
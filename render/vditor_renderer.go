@@ -48,6 +48,10 @@ func NewVditorRenderer(tree *parse.Tree) *VditorRenderer {
 	ret.RendererFuncs[ast.NodeMathBlockOpenMarker] = ret.renderMathBlockOpenMarker
 	ret.RendererFuncs[ast.NodeMathBlockContent] = ret.renderMathBlockContent
 	ret.RendererFuncs[ast.NodeMathBlockCloseMarker] = ret.renderMathBlockCloseMarker
+	ret.RendererFuncs[ast.NodeFrontMatter] = ret.renderFrontMatter
+	ret.RendererFuncs[ast.NodeFrontMatterOpenMarker] = ret.renderFrontMatterOpenMarker
+	ret.RendererFuncs[ast.NodeFrontMatterContent] = ret.renderFrontMatterContent
+	ret.RendererFuncs[ast.NodeFrontMatterCloseMarker] = ret.renderFrontMatterCloseMarker
 	ret.RendererFuncs[ast.NodeInlineMath] = ret.renderInlineMath
 	ret.RendererFuncs[ast.NodeInlineMathOpenMarker] = ret.renderInlineMathOpenMarker
 	ret.RendererFuncs[ast.NodeInlineMathContent] = ret.renderInlineMathContent
@@ -64,10 +68,14 @@ func NewVditorRenderer(tree *parse.Tree) *VditorRenderer {
 	ret.RendererFuncs[ast.NodeStrongU8eCloseMarker] = ret.renderStrongU8eCloseMarker
 	ret.RendererFuncs[ast.NodeBlockquote] = ret.renderBlockquote
 	ret.RendererFuncs[ast.NodeBlockquoteMarker] = ret.renderBlockquoteMarker
+	ret.RendererFuncs[ast.NodeGitHubAlert] = ret.renderGitHubAlert
+	ret.RendererFuncs[ast.NodeGitHubAlertMarker] = ret.renderGitHubAlertMarker
+	ret.RendererFuncs[ast.NodeCustomContainer] = ret.renderCustomContainer
 	ret.RendererFuncs[ast.NodeHeading] = ret.renderHeading
 	ret.RendererFuncs[ast.NodeHeadingC8hMarker] = ret.renderHeadingC8hMarker
 	ret.RendererFuncs[ast.NodeList] = ret.renderList
 	ret.RendererFuncs[ast.NodeListItem] = ret.renderListItem
+	ret.RendererFuncs[ast.NodeDefinitionList] = ret.renderDefinitionList
 	ret.RendererFuncs[ast.NodeThematicBreak] = ret.renderThematicBreak
 	ret.RendererFuncs[ast.NodeHardBreak] = ret.renderHardBreak
 	ret.RendererFuncs[ast.NodeSoftBreak] = ret.renderSoftBreak
@@ -89,6 +97,15 @@ func NewVditorRenderer(tree *parse.Tree) *VditorRenderer {
 	ret.RendererFuncs[ast.NodeStrikethrough1CloseMarker] = ret.renderStrikethrough1CloseMarker
 	ret.RendererFuncs[ast.NodeStrikethrough2OpenMarker] = ret.renderStrikethrough2OpenMarker
 	ret.RendererFuncs[ast.NodeStrikethrough2CloseMarker] = ret.renderStrikethrough2CloseMarker
+	ret.RendererFuncs[ast.NodeMark] = ret.renderMark
+	ret.RendererFuncs[ast.NodeMarkOpenMarker] = ret.renderMarkOpenMarker
+	ret.RendererFuncs[ast.NodeMarkCloseMarker] = ret.renderMarkCloseMarker
+	ret.RendererFuncs[ast.NodeSup] = ret.renderSup
+	ret.RendererFuncs[ast.NodeSupOpenMarker] = ret.renderSupOpenMarker
+	ret.RendererFuncs[ast.NodeSupCloseMarker] = ret.renderSupCloseMarker
+	ret.RendererFuncs[ast.NodeSub] = ret.renderSub
+	ret.RendererFuncs[ast.NodeSubOpenMarker] = ret.renderSubOpenMarker
+	ret.RendererFuncs[ast.NodeSubCloseMarker] = ret.renderSubCloseMarker
 	ret.RendererFuncs[ast.NodeTaskListItemMarker] = ret.renderTaskListItemMarker
 	ret.RendererFuncs[ast.NodeTable] = ret.renderTable
 	ret.RendererFuncs[ast.NodeTableHead] = ret.renderTableHead
@@ -104,16 +121,24 @@ func NewVditorRenderer(tree *parse.Tree) *VditorRenderer {
 	ret.RendererFuncs[ast.NodeBackslash] = ret.renderBackslash
 	ret.RendererFuncs[ast.NodeBackslashContent] = ret.renderBackslashContent
 	ret.RendererFuncs[ast.NodeHTMLEntity] = ret.renderHtmlEntity
+	ret.RendererFuncs[ast.NodeWikiLink] = ret.renderWikiLink
+	ret.RendererFuncs[ast.NodeAbbr] = ret.renderAbbr
+	ret.RendererFuncs[ast.NodeTag] = ret.renderTag
+	ret.RendererFuncs[ast.NodeReference] = ret.renderReference
+	ret.RendererFuncs[ast.NodeTableCaption] = ret.renderTableCaption
+	ret.RendererFuncs[ast.NodeCrossRef] = ret.renderCrossRef
+	ret.RendererFuncs[ast.NodeCitation] = ret.renderCitation
+	ret.RendererFuncs[ast.NodeRuby] = ret.renderRuby
 	return ret
 }
 
 func (r *VditorRenderer) Render() (output []byte) {
 	output = r.BaseRenderer.Render()
-	if 1 > len(r.Tree.Context.LinkRefDefs) || r.needRenderFootnotesDef {
+	if (1 > len(r.Tree.Context.LinkRefDefs) && 1 > len(r.Tree.Context.Abbreviations)) || r.needRenderFootnotesDef {
 		return
 	}
 
-	// 将链接引用定义添加到末尾
+	// 将链接引用定义和缩写定义添加到末尾
 	r.WriteString("<div data-block=\"0\" data-type=\"link-ref-defs-block\">")
 	for _, node := range r.Tree.Context.LinkRefDefs {
 		label := node.LinkRefLabel
@@ -125,15 +150,24 @@ func (r *VditorRenderer) Render() (output []byte) {
 		}
 		r.WriteString(destStr + "\n")
 	}
+	for _, def := range r.abbreviationDefs() {
+		r.Write(util.EscapeHTML([]byte(def)))
+		r.WriteString("\n")
+	}
 	r.WriteString("</div>")
 	output = r.Writer.Bytes()
 	return
 }
 
 func (r *VditorRenderer) RenderFootnotesDefs(context *parse.Context) []byte {
+	defs := r.editableFootnotesDefs(context)
+	if 1 > len(defs) {
+		return r.Writer.Bytes()
+	}
+
 	r.WriteString("<div data-block=\"0\" data-type=\"footnotes-block\">")
 	r.WriteString("<ol data-type=\"footnotes-defs-ol\">")
-	for _, def := range context.FootnotesDefs {
+	for _, def := range defs {
 		r.WriteString("<li data-type=\"footnotes-li\" data-marker=\"" + string(def.Tokens) + "\">")
 		tree := &parse.Tree{Name: "", Context: context}
 		tree.Context.Tree = tree
@@ -190,20 +224,7 @@ func (r *VditorRenderer) renderBackslash(node *ast.Node, entering bool) ast.Walk
 }
 
 func (r *VditorRenderer) renderToC(node *ast.Node, entering bool) ast.WalkStatus {
-	headings := r.headings()
-	length := len(headings)
-	r.WriteString("<div class=\"vditor-toc\" data-block=\"0\" data-type=\"toc-block\" contenteditable=\"false\">")
-	if 0 < length {
-		for _, heading := range headings {
-			spaces := (heading.HeadingLevel - 1) * 2
-			r.WriteString(strings.Repeat("&emsp;", spaces))
-			r.WriteString("<span data-type=\"toc-h\">")
-			r.WriteString(heading.Text() + "</span><br>")
-		}
-	} else {
-		r.WriteString("[toc]<br>")
-	}
-	r.WriteString("</div>")
+	r.renderVditorToC(node)
 	caretInDest := bytes.Contains(node.Tokens, []byte(parse.Caret))
 	r.WriteString("<p data-block=\"0\">")
 	if caretInDest {
@@ -221,6 +242,9 @@ func (r *VditorRenderer) renderFootnotesDef(node *ast.Node, entering bool) ast.W
 }
 
 func (r *VditorRenderer) renderFootnotesRef(node *ast.Node, entering bool) ast.WalkStatus {
+	if r.inlineFootnotes(node) {
+		return r.renderSourceInline(node)
+	}
 	previousNodeText := node.PreviousNodeText()
 	previousNodeText = strings.ReplaceAll(previousNodeText, parse.Caret, "")
 	if "" == previousNodeText {
@@ -342,6 +366,37 @@ func (r *VditorRenderer) renderMathBlock(node *ast.Node, entering bool) ast.Walk
 	return ast.WalkContinue
 }
 
+func (r *VditorRenderer) renderFrontMatterCloseMarker(node *ast.Node, entering bool) ast.WalkStatus {
+	return ast.WalkStop
+}
+
+func (r *VditorRenderer) renderFrontMatterContent(node *ast.Node, entering bool) ast.WalkStatus {
+	codeLen := len(node.Tokens)
+	codeIsEmpty := 1 > codeLen || (len(parse.Caret) == codeLen && parse.Caret == string(node.Tokens))
+	r.WriteString("<pre>")
+	r.tag("code", [][]string{{"data-type", "front-matter"}}, false)
+	if codeIsEmpty {
+		r.WriteString("<wbr>\n")
+	} else {
+		r.Write(util.EscapeHTML(node.Tokens))
+	}
+	r.WriteString("</code></pre>")
+	return ast.WalkStop
+}
+
+func (r *VditorRenderer) renderFrontMatterOpenMarker(node *ast.Node, entering bool) ast.WalkStatus {
+	return ast.WalkStop
+}
+
+func (r *VditorRenderer) renderFrontMatter(node *ast.Node, entering bool) ast.WalkStatus {
+	if entering {
+		r.tag("div", [][]string{{"class", "vditor-wysiwyg__block"}, {"data-type", "front-matter"}, {"data-block", "0"}, {"data-marker", string(node.FrontMatterMarker)}}, false)
+	} else {
+		r.WriteString("</div>")
+	}
+	return ast.WalkContinue
+}
+
 func (r *VditorRenderer) renderTableCell(node *ast.Node, entering bool) ast.WalkStatus {
 	tag := "td"
 	if ast.NodeTableHead == node.Parent.Parent.Type {
@@ -373,7 +428,7 @@ func (r *VditorRenderer) renderTableCell(node *ast.Node, entering bool) ast.Walk
 
 func (r *VditorRenderer) renderTableRow(node *ast.Node, entering bool) ast.WalkStatus {
 	if entering {
-		r.tag("tr", nil, false)
+		r.tag("tr", r.sourceLineAttrs(node), false)
 	} else {
 		r.tag("/tr", nil, false)
 	}
@@ -392,11 +447,18 @@ func (r *VditorRenderer) renderTableHead(node *ast.Node, entering bool) ast.Walk
 	return ast.WalkContinue
 }
 
+func (r *VditorRenderer) renderTableCaption(node *ast.Node, entering bool) ast.WalkStatus {
+	if entering {
+		r.renderVditorTableCaption(node)
+	}
+	return ast.WalkSkipChildren
+}
+
 func (r *VditorRenderer) renderTable(node *ast.Node, entering bool) ast.WalkStatus {
 	if entering {
 		r.tag("table", [][]string{{"data-block", "0"}}, false)
 	} else {
-		if nil != node.FirstChild.Next {
+		if nil != node.ChildByType(ast.NodeTableHead).Next {
 			r.tag("/tbody", nil, false)
 		}
 		r.tag("/table", nil, false)
@@ -428,6 +490,52 @@ func (r *VditorRenderer) renderStrikethrough2CloseMarker(node *ast.Node, enterin
 	return ast.WalkStop
 }
 
+func (r *VditorRenderer) renderMark(node *ast.Node, entering bool) ast.WalkStatus {
+	return ast.WalkContinue
+}
+
+func (r *VditorRenderer) renderMarkOpenMarker(node *ast.Node, entering bool) ast.WalkStatus {
+	r.tag("mark", [][]string{{"data-marker", "=="}}, false)
+	return ast.WalkStop
+}
+
+func (r *VditorRenderer) renderMarkCloseMarker(node *ast.Node, entering bool) ast.WalkStatus {
+	r.tag("/mark", nil, false)
+	return ast.WalkStop
+}
+
+func (r *VditorRenderer) renderSup(node *ast.Node, entering bool) ast.WalkStatus {
+	return ast.WalkContinue
+}
+
+func (r *VditorRenderer) renderSupOpenMarker(node *ast.Node, entering bool) ast.WalkStatus {
+	r.tag("sup", [][]string{{"data-marker", "^"}}, false)
+	return ast.WalkStop
+}
+
+func (r *VditorRenderer) renderSupCloseMarker(node *ast.Node, entering bool) ast.WalkStatus {
+	r.tag("/sup", nil, false)
+	return ast.WalkStop
+}
+
+func (r *VditorRenderer) renderSub(node *ast.Node, entering bool) ast.WalkStatus {
+	return ast.WalkContinue
+}
+
+func (r *VditorRenderer) renderSubOpenMarker(node *ast.Node, entering bool) ast.WalkStatus {
+	r.tag("sub", [][]string{{"data-marker", "~"}}, false)
+	return ast.WalkStop
+}
+
+func (r *VditorRenderer) renderSubCloseMarker(node *ast.Node, entering bool) ast.WalkStatus {
+	r.tag("/sub", nil, false)
+	return ast.WalkStop
+}
+
+func (r *VditorRenderer) renderAbbr(node *ast.Node, entering bool) ast.WalkStatus {
+	return ast.WalkContinue
+}
+
 func (r *VditorRenderer) renderLinkTitle(node *ast.Node, entering bool) ast.WalkStatus {
 	return ast.WalkStop
 }
@@ -604,7 +712,7 @@ func (r *VditorRenderer) renderParagraph(node *ast.Node, entering bool) ast.Walk
 	}
 
 	if entering {
-		r.tag("p", [][]string{{"data-block", "0"}}, false)
+		r.tag("p", append(append([][]string{{"data-block", "0"}}, r.sourceLineAttrs(node)...), r.attributeListDataAttrs(node)...), false)
 	} else {
 		r.WriteByte(lex.ItemNewline)
 		r.tag("/p", nil, false)
@@ -622,6 +730,9 @@ func (r *VditorRenderer) renderText(node *ast.Node, entering bool) ast.WalkStatu
 	if r.Option.ChinesePunct {
 		r.ChinesePunct(node)
 	}
+	if "" != r.Option.ChineseConvert {
+		r.ConvertChinese(node)
+	}
 
 	node.Tokens = bytes.TrimRight(node.Tokens, "\n")
 	// 有的场景需要零宽空格撑起，但如果有其他文本内容的话需要把零宽空格删掉
@@ -721,7 +832,7 @@ func (r *VditorRenderer) renderStrongU8eCloseMarker(node *ast.Node, entering boo
 
 func (r *VditorRenderer) renderBlockquote(node *ast.Node, entering bool) ast.WalkStatus {
 	if entering {
-		r.WriteString(`<blockquote data-block="0">`)
+		r.WriteString(`<blockquote data-block="0"` + r.sourceLineAttrsStr(node) + `>`)
 	} else {
 		r.WriteString("</blockquote>")
 	}
@@ -732,9 +843,31 @@ func (r *VditorRenderer) renderBlockquoteMarker(node *ast.Node, entering bool) a
 	return ast.WalkStop
 }
 
+func (r *VditorRenderer) renderGitHubAlert(node *ast.Node, entering bool) ast.WalkStatus {
+	if entering {
+		r.WriteString(`<blockquote data-block="0" data-type="github-alert" data-alert-type="` + node.GitHubAlertType + `" class="vditor-alert vditor-alert--` + node.GitHubAlertType + `"` + r.sourceLineAttrsStr(node) + `>`)
+	} else {
+		r.WriteString("</blockquote>")
+	}
+	return ast.WalkContinue
+}
+
+func (r *VditorRenderer) renderGitHubAlertMarker(node *ast.Node, entering bool) ast.WalkStatus {
+	// 标记符作为块引用中的首个段落输出，转换回 Markdown 时按照普通块引用处理即可
+	r.tag("p", [][]string{{"data-block", "0"}, {"data-type", "github-alert-marker"}}, false)
+	r.Write(util.EscapeHTML(node.Tokens))
+	r.WriteByte(lex.ItemNewline)
+	r.tag("/p", nil, false)
+	return ast.WalkStop
+}
+
+func (r *VditorRenderer) renderCustomContainer(node *ast.Node, entering bool) ast.WalkStatus {
+	return r.renderSourceBlock(node)
+}
+
 func (r *VditorRenderer) renderHeading(node *ast.Node, entering bool) ast.WalkStatus {
 	if entering {
-		r.WriteString("<h" + headingLevel[node.HeadingLevel:node.HeadingLevel+1] + " data-block=\"0\"")
+		r.WriteString("<h" + headingLevel[node.HeadingLevel:node.HeadingLevel+1] + " data-block=\"0\"" + r.sourceLineAttrsStr(node) + r.attributeListDataAttrsStr(node))
 		id := string(node.HeadingID)
 		if r.Option.HeadingID && "" != id {
 			r.WriteString(" data-id=\"" + id + "\"")
@@ -821,7 +954,7 @@ func (r *VditorRenderer) renderListItem(node *ast.Node, entering bool) ast.WalkS
 				attrs = append(attrs, []string{"class", r.Option.GFMTaskListItemClass})
 			}
 		}
-		r.tag("li", attrs, false)
+		r.tag("li", append(attrs, r.sourceLineAttrs(node)...), false)
 		if nil == node.FirstChild {
 			r.WriteString(parse.Zwsp)
 		}
@@ -831,12 +964,17 @@ func (r *VditorRenderer) renderListItem(node *ast.Node, entering bool) ast.WalkS
 	return ast.WalkContinue
 }
 
+func (r *VditorRenderer) renderDefinitionList(node *ast.Node, entering bool) ast.WalkStatus {
+	return r.renderSourceBlock(node)
+}
+
 func (r *VditorRenderer) renderTaskListItemMarker(node *ast.Node, entering bool) ast.WalkStatus {
 	var attrs [][]string
 	if node.TaskListItemChecked {
 		attrs = append(attrs, []string{"checked", ""})
 	}
 	attrs = append(attrs, []string{"type", "checkbox"})
+	attrs = append(attrs, r.taskListItemStateAttrs(node)...)
 	r.tag("input", attrs, true)
 	return ast.WalkStop
 }
@@ -886,7 +1024,7 @@ func (r *VditorRenderer) renderCodeBlock(node *ast.Node, entering bool) ast.Walk
 		if nil != node.FirstChild {
 			marker = string(node.FirstChild.Tokens)
 		}
-		r.WriteString(`<div class="vditor-wysiwyg__block" data-type="code-block" data-block="0" data-marker="` + marker + `">`)
+		r.WriteString(`<div class="vditor-wysiwyg__block" data-type="code-block" data-block="0" data-marker="` + marker + `"` + r.sourceLineAttrsStr(node) + `>`)
 	} else {
 		r.WriteString("</div>")
 	}
@@ -938,3 +1076,59 @@ func (r *VditorRenderer) renderCodeBlockCode(node *ast.Node, entering bool) ast.
 	}
 	return ast.WalkStop
 }
+
+// renderSourceBlock 以源码加预览的形式输出没有对应编辑元素的扩展语法块节点 node。
+func (r *VditorRenderer) renderSourceBlock(node *ast.Node) ast.WalkStatus {
+	r.WriteString(`<div class="vditor-wysiwyg__block" data-type="source-block" data-block="0"` + r.sourceLineAttrsStr(node) + `>`)
+	r.WriteString("<pre>")
+	r.tag("code", [][]string{{"data-type", "source-block"}}, false)
+	r.Write(util.EscapeHTML(r.nodeSource(node)))
+	r.WriteString("</code></pre>")
+	r.tag("div", [][]string{{"class", "vditor-wysiwyg__preview"}, {"data-render", "2"}}, false)
+	r.Write(r.nodePreview(node))
+	r.WriteString("</div></div>")
+	return ast.WalkStop
+}
+
+// renderSourceInline 以源码加预览的形式输出没有对应编辑元素的扩展语法行级节点 node。
+func (r *VditorRenderer) renderWikiLink(node *ast.Node, entering bool) ast.WalkStatus {
+	return r.renderSourceInline(node)
+}
+
+func (r *VditorRenderer) renderTag(node *ast.Node, entering bool) ast.WalkStatus {
+	return r.renderSourceInline(node)
+}
+
+func (r *VditorRenderer) renderReference(node *ast.Node, entering bool) ast.WalkStatus {
+	return r.renderSourceInline(node)
+}
+
+func (r *VditorRenderer) renderCrossRef(node *ast.Node, entering bool) ast.WalkStatus {
+	return r.renderSourceInline(node)
+}
+
+func (r *VditorRenderer) renderCitation(node *ast.Node, entering bool) ast.WalkStatus {
+	return r.renderSourceInline(node)
+}
+
+func (r *VditorRenderer) renderRuby(node *ast.Node, entering bool) ast.WalkStatus {
+	return r.renderSourceInline(node)
+}
+
+func (r *VditorRenderer) renderSourceInline(node *ast.Node) ast.WalkStatus {
+	previousNodeText := node.PreviousNodeText()
+	previousNodeText = strings.ReplaceAll(previousNodeText, parse.Caret, "")
+	if "" == previousNodeText {
+		r.WriteString(parse.Zwsp)
+	}
+
+	r.WriteString("<span class=\"vditor-wysiwyg__block\" data-type=\"source-inline\">")
+	r.tag("code", [][]string{{"data-type", "source-inline"}}, false)
+	r.WriteString(parse.Zwsp)
+	r.Write(util.EscapeHTML(r.nodeSource(node)))
+	r.WriteString("</code>")
+	r.tag("span", [][]string{{"class", "vditor-wysiwyg__preview"}, {"data-render", "2"}}, false)
+	r.Write(r.nodePreview(node))
+	r.WriteString("</span></span>" + parse.Zwsp)
+	return ast.WalkStop
+}
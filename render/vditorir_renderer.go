@@ -46,6 +46,10 @@ func NewVditorIRRenderer(tree *parse.Tree) *VditorIRRenderer {
 	ret.RendererFuncs[ast.NodeMathBlockOpenMarker] = ret.renderMathBlockOpenMarker
 	ret.RendererFuncs[ast.NodeMathBlockContent] = ret.renderMathBlockContent
 	ret.RendererFuncs[ast.NodeMathBlockCloseMarker] = ret.renderMathBlockCloseMarker
+	ret.RendererFuncs[ast.NodeFrontMatter] = ret.renderFrontMatter
+	ret.RendererFuncs[ast.NodeFrontMatterOpenMarker] = ret.renderFrontMatterOpenMarker
+	ret.RendererFuncs[ast.NodeFrontMatterContent] = ret.renderFrontMatterContent
+	ret.RendererFuncs[ast.NodeFrontMatterCloseMarker] = ret.renderFrontMatterCloseMarker
 	ret.RendererFuncs[ast.NodeInlineMath] = ret.renderInlineMath
 	ret.RendererFuncs[ast.NodeInlineMathOpenMarker] = ret.renderInlineMathOpenMarker
 	ret.RendererFuncs[ast.NodeInlineMathContent] = ret.renderInlineMathContent
@@ -62,10 +66,14 @@ func NewVditorIRRenderer(tree *parse.Tree) *VditorIRRenderer {
 	ret.RendererFuncs[ast.NodeStrongU8eCloseMarker] = ret.renderStrongU8eCloseMarker
 	ret.RendererFuncs[ast.NodeBlockquote] = ret.renderBlockquote
 	ret.RendererFuncs[ast.NodeBlockquoteMarker] = ret.renderBlockquoteMarker
+	ret.RendererFuncs[ast.NodeGitHubAlert] = ret.renderGitHubAlert
+	ret.RendererFuncs[ast.NodeGitHubAlertMarker] = ret.renderGitHubAlertMarker
+	ret.RendererFuncs[ast.NodeCustomContainer] = ret.renderCustomContainer
 	ret.RendererFuncs[ast.NodeHeading] = ret.renderHeading
 	ret.RendererFuncs[ast.NodeHeadingC8hMarker] = ret.renderHeadingC8hMarker
 	ret.RendererFuncs[ast.NodeList] = ret.renderList
 	ret.RendererFuncs[ast.NodeListItem] = ret.renderListItem
+	ret.RendererFuncs[ast.NodeDefinitionList] = ret.renderDefinitionList
 	ret.RendererFuncs[ast.NodeThematicBreak] = ret.renderThematicBreak
 	ret.RendererFuncs[ast.NodeHardBreak] = ret.renderHardBreak
 	ret.RendererFuncs[ast.NodeSoftBreak] = ret.renderSoftBreak
@@ -87,6 +95,15 @@ func NewVditorIRRenderer(tree *parse.Tree) *VditorIRRenderer {
 	ret.RendererFuncs[ast.NodeStrikethrough1CloseMarker] = ret.renderStrikethrough1CloseMarker
 	ret.RendererFuncs[ast.NodeStrikethrough2OpenMarker] = ret.renderStrikethrough2OpenMarker
 	ret.RendererFuncs[ast.NodeStrikethrough2CloseMarker] = ret.renderStrikethrough2CloseMarker
+	ret.RendererFuncs[ast.NodeMark] = ret.renderMark
+	ret.RendererFuncs[ast.NodeMarkOpenMarker] = ret.renderMarkOpenMarker
+	ret.RendererFuncs[ast.NodeMarkCloseMarker] = ret.renderMarkCloseMarker
+	ret.RendererFuncs[ast.NodeSup] = ret.renderSup
+	ret.RendererFuncs[ast.NodeSupOpenMarker] = ret.renderSupOpenMarker
+	ret.RendererFuncs[ast.NodeSupCloseMarker] = ret.renderSupCloseMarker
+	ret.RendererFuncs[ast.NodeSub] = ret.renderSub
+	ret.RendererFuncs[ast.NodeSubOpenMarker] = ret.renderSubOpenMarker
+	ret.RendererFuncs[ast.NodeSubCloseMarker] = ret.renderSubCloseMarker
 	ret.RendererFuncs[ast.NodeTaskListItemMarker] = ret.renderTaskListItemMarker
 	ret.RendererFuncs[ast.NodeTable] = ret.renderTable
 	ret.RendererFuncs[ast.NodeTableHead] = ret.renderTableHead
@@ -98,20 +115,28 @@ func NewVditorIRRenderer(tree *parse.Tree) *VditorIRRenderer {
 	ret.RendererFuncs[ast.NodeEmojiAlias] = ret.renderEmojiAlias
 	ret.RendererFuncs[ast.NodeFootnotesDef] = ret.renderFootnotesDef
 	ret.RendererFuncs[ast.NodeFootnotesRef] = ret.renderFootnotesRef
+	ret.RendererFuncs[ast.NodeWikiLink] = ret.renderWikiLink
 	ret.RendererFuncs[ast.NodeToC] = ret.renderToC
 	ret.RendererFuncs[ast.NodeBackslash] = ret.renderBackslash
 	ret.RendererFuncs[ast.NodeBackslashContent] = ret.renderBackslashContent
 	ret.RendererFuncs[ast.NodeHTMLEntity] = ret.renderHtmlEntity
+	ret.RendererFuncs[ast.NodeAbbr] = ret.renderAbbr
+	ret.RendererFuncs[ast.NodeTag] = ret.renderTag
+	ret.RendererFuncs[ast.NodeReference] = ret.renderReference
+	ret.RendererFuncs[ast.NodeTableCaption] = ret.renderTableCaption
+	ret.RendererFuncs[ast.NodeCrossRef] = ret.renderCrossRef
+	ret.RendererFuncs[ast.NodeCitation] = ret.renderCitation
+	ret.RendererFuncs[ast.NodeRuby] = ret.renderRuby
 	return ret
 }
 
 func (r *VditorIRRenderer) Render() (output []byte) {
 	output = r.BaseRenderer.Render()
-	if 1 > len(r.Tree.Context.LinkRefDefs) || r.needRenderFootnotesDef {
+	if (1 > len(r.Tree.Context.LinkRefDefs) && 1 > len(r.Tree.Context.Abbreviations)) || r.needRenderFootnotesDef {
 		return
 	}
 
-	// 将链接引用定义添加到末尾
+	// 将链接引用定义和缩写定义添加到末尾
 	r.WriteString("<div data-block=\"0\" data-type=\"link-ref-defs-block\">")
 	for _, node := range r.Tree.Context.LinkRefDefs {
 		label := node.LinkRefLabel
@@ -123,14 +148,23 @@ func (r *VditorIRRenderer) Render() (output []byte) {
 		}
 		r.WriteString(destStr + "\n")
 	}
+	for _, def := range r.abbreviationDefs() {
+		r.Write(util.EscapeHTML([]byte(def)))
+		r.WriteString("\n")
+	}
 	r.WriteString("</div>")
 	output = r.Writer.Bytes()
 	return
 }
 
 func (r *VditorIRRenderer) RenderFootnotesDefs(context *parse.Context) []byte {
+	defs := r.editableFootnotesDefs(context)
+	if 1 > len(defs) {
+		return r.Writer.Bytes()
+	}
+
 	r.WriteString("<div data-block=\"0\" data-type=\"footnotes-block\">")
-	for _, def := range context.FootnotesDefs {
+	for _, def := range defs {
 		r.WriteString("<div data-type=\"footnotes-def\">")
 		tree := &parse.Tree{Name: "", Context: context}
 		tree.Context.Tree = tree
@@ -182,20 +216,7 @@ func (r *VditorIRRenderer) renderBackslash(node *ast.Node, entering bool) ast.Wa
 }
 
 func (r *VditorIRRenderer) renderToC(node *ast.Node, entering bool) ast.WalkStatus {
-	headings := r.headings()
-	length := len(headings)
-	r.WriteString("<div class=\"vditor-toc\" data-block=\"0\" data-type=\"toc-block\" contenteditable=\"false\">")
-	if 0 < length {
-		for _, heading := range headings {
-			spaces := (heading.HeadingLevel - 1) * 2
-			r.WriteString(strings.Repeat("&emsp;", spaces))
-			r.WriteString("<span data-type=\"toc-h\">")
-			r.WriteString(heading.Text() + "</span><br>")
-		}
-	} else {
-		r.WriteString("[toc]<br>")
-	}
-	r.WriteString("</div>")
+	r.renderVditorToC(node)
 	caretInDest := bytes.Contains(node.Tokens, []byte(parse.Caret))
 	r.WriteString("<p data-block=\"0\">")
 	if caretInDest {
@@ -213,6 +234,9 @@ func (r *VditorIRRenderer) renderFootnotesDef(node *ast.Node, entering bool) ast
 }
 
 func (r *VditorIRRenderer) renderFootnotesRef(node *ast.Node, entering bool) ast.WalkStatus {
+	if r.inlineFootnotes(node) {
+		return r.renderSourceInline(node)
+	}
 	previousNodeText := node.PreviousNodeText()
 	previousNodeText = strings.ReplaceAll(previousNodeText, parse.Caret, "")
 	if "" == previousNodeText {
@@ -246,6 +270,29 @@ func (r *VditorIRRenderer) renderFootnotesRef(node *ast.Node, entering bool) ast
 	return ast.WalkStop
 }
 
+func (r *VditorIRRenderer) renderWikiLink(node *ast.Node, entering bool) ast.WalkStatus {
+	url, exists := r.Tree.Context.ResolveWikiLink(node)
+	class := "vditor-ir__node"
+	if bytes.Contains(node.Tokens, util.StrToBytes(parse.Caret)) {
+		class += " vditor-ir__node--expand"
+	}
+	if !exists {
+		class += " vditor-ir__node--new"
+	}
+	r.tag("span", [][]string{{"data-type", "wiki-link"}, {"class", class}, {"data-href", util.BytesToStr(util.EscapeHTML(util.StrToBytes(url)))}}, false)
+	r.tag("span", [][]string{{"class", "vditor-ir__marker vditor-ir__marker--bracket"}}, false)
+	r.WriteString("[[")
+	r.tag("/span", nil, false)
+	r.tag("span", [][]string{{"class", "vditor-ir__link"}}, false)
+	r.Write(util.EscapeHTML(node.Tokens))
+	r.tag("/span", nil, false)
+	r.tag("span", [][]string{{"class", "vditor-ir__marker vditor-ir__marker--bracket"}}, false)
+	r.WriteString("]]")
+	r.tag("/span", nil, false)
+	r.tag("/span", nil, false)
+	return ast.WalkStop
+}
+
 func (r *VditorIRRenderer) renderCodeBlockCloseMarker(node *ast.Node, entering bool) ast.WalkStatus {
 	r.tag("span", [][]string{{"data-type", "code-block-close-marker"}}, false)
 	r.Write(node.Tokens)
@@ -424,6 +471,43 @@ func (r *VditorIRRenderer) renderMathBlock(node *ast.Node, entering bool) ast.Wa
 	return ast.WalkContinue
 }
 
+func (r *VditorIRRenderer) renderFrontMatterCloseMarker(node *ast.Node, entering bool) ast.WalkStatus {
+	r.tag("span", [][]string{{"data-type", "front-matter-close-marker"}}, false)
+	r.Write(node.Tokens)
+	r.tag("/span", nil, false)
+	return ast.WalkStop
+}
+
+func (r *VditorIRRenderer) renderFrontMatterContent(node *ast.Node, entering bool) ast.WalkStatus {
+	codeLen := len(node.Tokens)
+	codeIsEmpty := 1 > codeLen || (len(parse.Caret) == codeLen && parse.Caret == string(node.Tokens))
+	r.tag("pre", [][]string{{"class", "vditor-ir__marker--pre vditor-ir__marker"}}, false)
+	r.tag("code", [][]string{{"data-type", "front-matter"}}, false)
+	if codeIsEmpty {
+		r.WriteString("<wbr>\n")
+	} else {
+		r.Write(util.EscapeHTML(node.Tokens))
+	}
+	r.WriteString("</code></pre>")
+	return ast.WalkStop
+}
+
+func (r *VditorIRRenderer) renderFrontMatterOpenMarker(node *ast.Node, entering bool) ast.WalkStatus {
+	r.tag("span", [][]string{{"data-type", "front-matter-open-marker"}}, false)
+	r.Write(node.Tokens)
+	r.tag("/span", nil, false)
+	return ast.WalkStop
+}
+
+func (r *VditorIRRenderer) renderFrontMatter(node *ast.Node, entering bool) ast.WalkStatus {
+	if entering {
+		r.renderDivNode(node)
+	} else {
+		r.WriteString("</div>")
+	}
+	return ast.WalkContinue
+}
+
 func (r *VditorIRRenderer) renderTableCell(node *ast.Node, entering bool) ast.WalkStatus {
 	tag := "td"
 	if ast.NodeTableHead == node.Parent.Parent.Type {
@@ -455,7 +539,7 @@ func (r *VditorIRRenderer) renderTableCell(node *ast.Node, entering bool) ast.Wa
 
 func (r *VditorIRRenderer) renderTableRow(node *ast.Node, entering bool) ast.WalkStatus {
 	if entering {
-		r.tag("tr", nil, false)
+		r.tag("tr", r.sourceLineAttrs(node), false)
 	} else {
 		r.tag("/tr", nil, false)
 	}
@@ -474,11 +558,18 @@ func (r *VditorIRRenderer) renderTableHead(node *ast.Node, entering bool) ast.Wa
 	return ast.WalkContinue
 }
 
+func (r *VditorIRRenderer) renderTableCaption(node *ast.Node, entering bool) ast.WalkStatus {
+	if entering {
+		r.renderVditorTableCaption(node)
+	}
+	return ast.WalkSkipChildren
+}
+
 func (r *VditorIRRenderer) renderTable(node *ast.Node, entering bool) ast.WalkStatus {
 	if entering {
 		r.tag("table", [][]string{{"data-block", "0"}, {"data-type", "table"}}, false)
 	} else {
-		if nil != node.FirstChild.Next {
+		if nil != node.ChildByType(ast.NodeTableHead).Next {
 			r.tag("/tbody", nil, false)
 		}
 		r.tag("/table", nil, false)
@@ -527,6 +618,85 @@ func (r *VditorIRRenderer) renderStrikethrough2CloseMarker(node *ast.Node, enter
 	return ast.WalkStop
 }
 
+func (r *VditorIRRenderer) renderMark(node *ast.Node, entering bool) ast.WalkStatus {
+	if entering {
+		r.renderSpanNode(node)
+	} else {
+		r.tag("/span", nil, false)
+	}
+	return ast.WalkContinue
+}
+
+func (r *VditorIRRenderer) renderMarkOpenMarker(node *ast.Node, entering bool) ast.WalkStatus {
+	r.tag("span", [][]string{{"class", "vditor-ir__marker"}}, false)
+	r.WriteString("==")
+	r.tag("/span", nil, false)
+	r.tag("mark", [][]string{{"data-newline", "1"}}, false)
+	return ast.WalkStop
+}
+
+func (r *VditorIRRenderer) renderMarkCloseMarker(node *ast.Node, entering bool) ast.WalkStatus {
+	r.tag("/mark", nil, false)
+	r.tag("span", [][]string{{"class", "vditor-ir__marker"}}, false)
+	r.WriteString("==")
+	r.tag("/span", nil, false)
+	return ast.WalkStop
+}
+
+func (r *VditorIRRenderer) renderSup(node *ast.Node, entering bool) ast.WalkStatus {
+	if entering {
+		r.renderSpanNode(node)
+	} else {
+		r.tag("/span", nil, false)
+	}
+	return ast.WalkContinue
+}
+
+func (r *VditorIRRenderer) renderSupOpenMarker(node *ast.Node, entering bool) ast.WalkStatus {
+	r.tag("span", [][]string{{"class", "vditor-ir__marker"}}, false)
+	r.WriteString("^")
+	r.tag("/span", nil, false)
+	r.tag("sup", [][]string{{"data-newline", "1"}}, false)
+	return ast.WalkStop
+}
+
+func (r *VditorIRRenderer) renderSupCloseMarker(node *ast.Node, entering bool) ast.WalkStatus {
+	r.tag("/sup", nil, false)
+	r.tag("span", [][]string{{"class", "vditor-ir__marker"}}, false)
+	r.WriteString("^")
+	r.tag("/span", nil, false)
+	return ast.WalkStop
+}
+
+func (r *VditorIRRenderer) renderSub(node *ast.Node, entering bool) ast.WalkStatus {
+	if entering {
+		r.renderSpanNode(node)
+	} else {
+		r.tag("/span", nil, false)
+	}
+	return ast.WalkContinue
+}
+
+func (r *VditorIRRenderer) renderSubOpenMarker(node *ast.Node, entering bool) ast.WalkStatus {
+	r.tag("span", [][]string{{"class", "vditor-ir__marker"}}, false)
+	r.WriteString("~")
+	r.tag("/span", nil, false)
+	r.tag("sub", [][]string{{"data-newline", "1"}}, false)
+	return ast.WalkStop
+}
+
+func (r *VditorIRRenderer) renderSubCloseMarker(node *ast.Node, entering bool) ast.WalkStatus {
+	r.tag("/sub", nil, false)
+	r.tag("span", [][]string{{"class", "vditor-ir__marker"}}, false)
+	r.WriteString("~")
+	r.tag("/span", nil, false)
+	return ast.WalkStop
+}
+
+func (r *VditorIRRenderer) renderAbbr(node *ast.Node, entering bool) ast.WalkStatus {
+	return ast.WalkContinue
+}
+
 func (r *VditorIRRenderer) renderLinkTitle(node *ast.Node, entering bool) ast.WalkStatus {
 	r.tag("span", [][]string{{"class", "vditor-ir__marker vditor-ir__marker--title"}}, false)
 	r.WriteByte(lex.ItemDoublequote)
@@ -713,7 +883,7 @@ func (r *VditorIRRenderer) renderParagraph(node *ast.Node, entering bool) ast.Wa
 	}
 
 	if entering {
-		r.tag("p", [][]string{{"data-block", "0"}}, false)
+		r.tag("p", append(append([][]string{{"data-block", "0"}}, r.sourceLineAttrs(node)...), r.attributeListDataAttrs(node)...), false)
 	} else {
 		r.WriteByte(lex.ItemNewline)
 		r.tag("/p", nil, false)
@@ -731,6 +901,9 @@ func (r *VditorIRRenderer) renderText(node *ast.Node, entering bool) ast.WalkSta
 	if r.Option.ChinesePunct {
 		r.ChinesePunct(node)
 	}
+	if "" != r.Option.ChineseConvert {
+		r.ConvertChinese(node)
+	}
 
 	node.Tokens = bytes.TrimRight(node.Tokens, "\n")
 	// 有的场景需要零宽空格撑起，但如果有其他文本内容的话需要把零宽空格删掉
@@ -855,7 +1028,7 @@ func (r *VditorIRRenderer) renderStrongU8eCloseMarker(node *ast.Node, entering b
 
 func (r *VditorIRRenderer) renderBlockquote(node *ast.Node, entering bool) ast.WalkStatus {
 	if entering {
-		r.WriteString(`<blockquote data-block="0">`)
+		r.WriteString(`<blockquote data-block="0"` + r.sourceLineAttrsStr(node) + `>`)
 	} else {
 		r.WriteString("</blockquote>")
 	}
@@ -866,13 +1039,35 @@ func (r *VditorIRRenderer) renderBlockquoteMarker(node *ast.Node, entering bool)
 	return ast.WalkStop
 }
 
+func (r *VditorIRRenderer) renderGitHubAlert(node *ast.Node, entering bool) ast.WalkStatus {
+	if entering {
+		r.WriteString(`<blockquote data-block="0" data-type="github-alert" data-alert-type="` + node.GitHubAlertType + `" class="vditor-alert vditor-alert--` + node.GitHubAlertType + `"` + r.sourceLineAttrsStr(node) + `>`)
+	} else {
+		r.WriteString("</blockquote>")
+	}
+	return ast.WalkContinue
+}
+
+func (r *VditorIRRenderer) renderGitHubAlertMarker(node *ast.Node, entering bool) ast.WalkStatus {
+	// 标记符作为块引用中的首个段落输出，转换回 Markdown 时按照普通块引用处理即可
+	r.tag("p", [][]string{{"data-block", "0"}, {"data-type", "github-alert-marker"}}, false)
+	r.Write(util.EscapeHTML(node.Tokens))
+	r.WriteByte(lex.ItemNewline)
+	r.tag("/p", nil, false)
+	return ast.WalkStop
+}
+
+func (r *VditorIRRenderer) renderCustomContainer(node *ast.Node, entering bool) ast.WalkStatus {
+	return r.renderSourceBlock(node)
+}
+
 func (r *VditorIRRenderer) renderHeading(node *ast.Node, entering bool) ast.WalkStatus {
 	if entering {
 		text := r.Text(node)
 		if strings.Contains(text, parse.Caret) {
-			r.WriteString("<h" + headingLevel[node.HeadingLevel:node.HeadingLevel+1] + " data-block=\"0\" class=\"vditor-ir__node vditor-ir__node--expand\"")
+			r.WriteString("<h" + headingLevel[node.HeadingLevel:node.HeadingLevel+1] + " data-block=\"0\" class=\"vditor-ir__node vditor-ir__node--expand\"" + r.sourceLineAttrsStr(node) + r.attributeListDataAttrsStr(node))
 		} else {
-			r.WriteString("<h" + headingLevel[node.HeadingLevel:node.HeadingLevel+1] + " data-block=\"0\" class=\"vditor-ir__node\"")
+			r.WriteString("<h" + headingLevel[node.HeadingLevel:node.HeadingLevel+1] + " data-block=\"0\" class=\"vditor-ir__node\"" + r.sourceLineAttrsStr(node) + r.attributeListDataAttrsStr(node))
 		}
 
 		id := string(node.HeadingID)
@@ -965,19 +1160,24 @@ func (r *VditorIRRenderer) renderListItem(node *ast.Node, entering bool) ast.Wal
 				attrs = append(attrs, []string{"class", r.Option.GFMTaskListItemClass})
 			}
 		}
-		r.tag("li", attrs, false)
+		r.tag("li", append(attrs, r.sourceLineAttrs(node)...), false)
 	} else {
 		r.tag("/li", nil, false)
 	}
 	return ast.WalkContinue
 }
 
+func (r *VditorIRRenderer) renderDefinitionList(node *ast.Node, entering bool) ast.WalkStatus {
+	return r.renderSourceBlock(node)
+}
+
 func (r *VditorIRRenderer) renderTaskListItemMarker(node *ast.Node, entering bool) ast.WalkStatus {
 	var attrs [][]string
 	if node.TaskListItemChecked {
 		attrs = append(attrs, []string{"checked", ""})
 	}
 	attrs = append(attrs, []string{"type", "checkbox"})
+	attrs = append(attrs, r.taskListItemStateAttrs(node)...)
 	r.tag("input", attrs, true)
 	return ast.WalkStop
 }
@@ -1073,7 +1273,7 @@ func (r *VditorIRRenderer) renderSpanNode(node *ast.Node) {
 
 func (r *VditorIRRenderer) renderDivNode(node *ast.Node) {
 	text := r.Text(node)
-	attrs := [][]string{{"data-block", "0"}}
+	attrs := append([][]string{{"data-block", "0"}}, r.sourceLineAttrs(node)...)
 	switch node.Type {
 	case ast.NodeCodeBlock:
 		attrs = append(attrs, []string{"data-type", "code-block"})
@@ -1081,6 +1281,8 @@ func (r *VditorIRRenderer) renderDivNode(node *ast.Node) {
 		attrs = append(attrs, []string{"data-type", "html-block"})
 	case ast.NodeMathBlock:
 		attrs = append(attrs, []string{"data-type", "math-block"})
+	case ast.NodeFrontMatter:
+		attrs = append(attrs, []string{"data-type", "front-matter"})
 	}
 
 	if strings.Contains(text, parse.Caret) {
@@ -1098,7 +1300,7 @@ func (r *VditorIRRenderer) Text(node *ast.Node) (ret string) {
 	ast.Walk(node, func(n *ast.Node, entering bool) ast.WalkStatus {
 		if entering {
 			switch n.Type {
-			case ast.NodeText, ast.NodeLinkText, ast.NodeLinkDest, ast.NodeLinkTitle, ast.NodeCodeBlockCode, ast.NodeCodeSpanContent, ast.NodeInlineMathContent, ast.NodeMathBlockContent, ast.NodeHTMLBlock:
+			case ast.NodeText, ast.NodeLinkText, ast.NodeLinkDest, ast.NodeLinkTitle, ast.NodeCodeBlockCode, ast.NodeCodeSpanContent, ast.NodeInlineMathContent, ast.NodeMathBlockContent, ast.NodeFrontMatterContent, ast.NodeHTMLBlock:
 				ret += string(n.Tokens)
 			case ast.NodeCodeBlockFenceInfoMarker:
 				ret += string(n.CodeBlockInfo)
@@ -1112,3 +1314,46 @@ func (r *VditorIRRenderer) Text(node *ast.Node) (ret string) {
 	})
 	return
 }
+
+// renderSourceBlock 以源码加预览的形式输出没有对应编辑元素的扩展语法块节点 node。
+func (r *VditorIRRenderer) renderSourceBlock(node *ast.Node) ast.WalkStatus {
+	r.tag("div", append([][]string{{"data-block", "0"}, {"data-type", "source-block"}, {"class", "vditor-ir__node"}}, r.sourceLineAttrs(node)...), false)
+	r.WriteString("<pre class=\"vditor-ir__marker--pre vditor-ir__marker\">")
+	r.tag("code", [][]string{{"data-type", "source-block"}}, false)
+	r.Write(util.EscapeHTML(r.nodeSource(node)))
+	r.WriteString("</code></pre>")
+	r.tag("div", [][]string{{"class", "vditor-ir__preview"}, {"data-render", "2"}}, false)
+	r.Write(r.nodePreview(node))
+	r.WriteString("</div></div>")
+	return ast.WalkStop
+}
+
+// renderSourceInline 以源码的形式输出没有对应编辑元素的扩展语法行级节点 node。
+func (r *VditorIRRenderer) renderTag(node *ast.Node, entering bool) ast.WalkStatus {
+	return r.renderSourceInline(node)
+}
+
+func (r *VditorIRRenderer) renderReference(node *ast.Node, entering bool) ast.WalkStatus {
+	return r.renderSourceInline(node)
+}
+
+func (r *VditorIRRenderer) renderCrossRef(node *ast.Node, entering bool) ast.WalkStatus {
+	return r.renderSourceInline(node)
+}
+
+func (r *VditorIRRenderer) renderCitation(node *ast.Node, entering bool) ast.WalkStatus {
+	return r.renderSourceInline(node)
+}
+
+func (r *VditorIRRenderer) renderRuby(node *ast.Node, entering bool) ast.WalkStatus {
+	return r.renderSourceInline(node)
+}
+
+func (r *VditorIRRenderer) renderSourceInline(node *ast.Node) ast.WalkStatus {
+	r.renderSpanNode(node)
+	r.tag("code", [][]string{{"class", "vditor-ir__marker"}, {"data-type", "source-inline"}}, false)
+	r.Write(util.EscapeHTML(r.nodeSource(node)))
+	r.tag("/code", nil, false)
+	r.tag("/span", nil, false)
+	return ast.WalkStop
+}
@@ -0,0 +1,76 @@
+// Lute - 一款对中文语境优化的 Markdown 引擎，支持 Go 和 JavaScript
+// Copyright (c) 2019-present, b3log.org
+//
+// Lute is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//         http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND, EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT, MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+package lute
+
+import (
+	"errors"
+	"strconv"
+
+	"lute/lex"
+	"lute/parse"
+)
+
+// TaskListItems 返回 markdown 中所有的任务列表项，按照在文档中出现的顺序排列。
+func (lute *Lute) TaskListItems(markdown []byte) []*parse.TaskListItem {
+	tree := parse.Parse("", append([]byte{}, markdown...), lute.Options)
+	return tree.TaskListItems()
+}
+
+// ToggleTaskListItem 切换 markdown 中第 index 个任务列表项的状态：未勾选的切换为 [x]，其他状态切换为 [ ]。
+// 返回修改后的 markdown，只会修改该任务列表项方括号中的状态字符。
+func (lute *Lute) ToggleTaskListItem(markdown []byte, index int) ([]byte, error) {
+	item, err := lute.taskListItem(markdown, index)
+	if nil != err {
+		return nil, err
+	}
+
+	state := byte('x')
+	if " " != item.State {
+		state = lex.ItemSpace
+	}
+	return lute.updateTaskListItem(markdown, item, state)
+}
+
+// UpdateTaskListItem 将 markdown 中第 index 个任务列表项的状态设置为 state，state 需要是空格、x、X 或者
+// Options.TaskListItemStates 中配置的状态字符。返回修改后的 markdown，只会修改该任务列表项方括号中的状态字符。
+func (lute *Lute) UpdateTaskListItem(markdown []byte, index int, state byte) ([]byte, error) {
+	if lex.ItemSpace != state && 'x' != state && 'X' != state {
+		if _, ok := lute.TaskListItemStates[state]; !ok {
+			return nil, errors.New("unknown task list item state [" + string(state) + "]")
+		}
+	}
+
+	item, err := lute.taskListItem(markdown, index)
+	if nil != err {
+		return nil, err
+	}
+	return lute.updateTaskListItem(markdown, item, state)
+}
+
+func (lute *Lute) taskListItem(markdown []byte, index int) (*parse.TaskListItem, error) {
+	for _, item := range lute.TaskListItems(markdown) {
+		if index == item.Index {
+			return item, nil
+		}
+	}
+	return nil, errors.New("task list item [" + strconv.Itoa(index) + "] not found")
+}
+
+func (lute *Lute) updateTaskListItem(markdown []byte, item *parse.TaskListItem, state byte) ([]byte, error) {
+	offset := item.Offset
+	if 0 > offset || len(markdown) <= offset+2 || lex.ItemOpenBracket != markdown[offset] || lex.ItemCloseBracket != markdown[offset+2] {
+		return nil, errors.New("task list item [" + strconv.Itoa(item.Index) + "] marker not found at offset " + strconv.Itoa(offset))
+	}
+
+	ret := append([]byte{}, markdown...)
+	ret[offset+1] = state
+	return ret, nil
+}
@@ -0,0 +1,115 @@
+// Lute - 一款对中文语境优化的 Markdown 引擎，支持 Go 和 JavaScript
+// Copyright (c) 2019-present, b3log.org
+//
+// Lute is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//         http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND, EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT, MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+package test
+
+import (
+	"testing"
+
+	"lute"
+)
+
+var abbreviationTests = []parseTest{
+
+	{"7", "*[HTML]:\nHTML\n", "<p><abbr>HTML</abbr></p>\n"},
+	{"6", "*[HTML]: first\n*[HTML]: second\n\nHTML\n", "<p><abbr title=\"first\">HTML</abbr></p>\n"},
+	{"5", "*not a def*\n", "<p><em>not a def</em></p>\n"},
+	{"4", "# HTML\n\n`HTML` [HTML](/html)\n\n*[HTML]: Hyper Text Markup Language\n", "<h1 id=\"HTML\"><abbr title=\"Hyper Text Markup Language\">HTML</abbr></h1>\n<p><code>HTML</code> <a href=\"/html\">HTML</a></p>\n"},
+	{"3", "使用HTML编写，HTMLX 和 XHTML 不是。\n\n*[HTML]: Hyper Text Markup Language\n", "<p>使用 <abbr title=\"Hyper Text Markup Language\">HTML</abbr> 编写，HTMLX 和 XHTML 不是。</p>\n"},
+	{"2", "The HTML and W3C specs\n*[W3C]: World Wide Web Consortium\n*[HTML]: Hyper Text Markup Language\n", "<p>The <abbr title=\"Hyper Text Markup Language\">HTML</abbr> and <abbr title=\"World Wide Web Consortium\">W3C</abbr> specs</p>\n"},
+	{"1", "*[HTML5]: HTML version 5\n*[HTML]: Hyper Text Markup Language\n\nHTML5 > HTML\n", "<p><abbr title=\"HTML version 5\">HTML5</abbr> &gt; <abbr title=\"Hyper Text Markup Language\">HTML</abbr></p>\n"},
+	{"0", "*[A&B]: A \"and\" B\n\nA&B\n", "<p><abbr title=\"A &quot;and&quot; B\">A&amp;B</abbr></p>\n"},
+}
+
+func TestAbbreviation(t *testing.T) {
+	luteEngine := lute.New()
+	luteEngine.SetAbbreviation(true)
+
+	for _, test := range abbreviationTests {
+		html := luteEngine.MarkdownStr(test.name, test.from)
+		if test.to != html {
+			t.Fatalf("test case [%s] failed\nexpected\n\t%q\ngot\n\t%q\noriginal markdown text\n\t%q", test.name, test.to, html, test.from)
+		}
+	}
+}
+
+var formatAbbreviationTests = []parseTest{
+
+	{"1", "*[W3C]: World Wide Web Consortium\nW3C\n*[HTML]: Hyper Text Markup Language\n", "W3C\n\n*[HTML]: Hyper Text Markup Language\n*[W3C]: World Wide Web Consortium\n"},
+	{"0", "The HTML spec\n\n*[HTML]: Hyper Text Markup Language\n", "The HTML spec\n\n*[HTML]: Hyper Text Markup Language\n"},
+}
+
+func TestFormatAbbreviation(t *testing.T) {
+	luteEngine := lute.New()
+	luteEngine.SetAbbreviation(true)
+
+	for _, test := range formatAbbreviationTests {
+		formatted := luteEngine.FormatStr(test.name, test.from)
+		if test.to != formatted {
+			t.Fatalf("test case [%s] failed\nexpected\n\t%q\ngot\n\t%q\noriginal markdown text\n\t%q", test.name, test.to, formatted, test.from)
+		}
+	}
+}
+
+var abbreviationDisabledTests = []parseTest{
+
+	{"0", "*[HTML]: Hyper Text Markup Language\n\nHTML\n", "<p>*[HTML]: Hyper Text Markup Language</p>\n<p>HTML</p>\n"},
+}
+
+func TestAbbreviationDisabled(t *testing.T) {
+	luteEngine := lute.New()
+
+	for _, test := range abbreviationDisabledTests {
+		html := luteEngine.MarkdownStr(test.name, test.from)
+		if test.to != html {
+			t.Fatalf("test case [%s] failed\nexpected\n\t%q\ngot\n\t%q\noriginal markdown text\n\t%q", test.name, test.to, html, test.from)
+		}
+	}
+}
+
+var md2VditorAbbreviationTests = []parseTest{
+
+	{"0", "The HTML spec\n\n*[HTML]: Hyper Text Markup Language\n", "<p data-block=\"0\">The HTML spec\n</p><div data-block=\"0\" data-type=\"link-ref-defs-block\">*[HTML]: Hyper Text Markup Language\n</div>"},
+}
+
+func TestMd2VditorAbbreviation(t *testing.T) {
+	luteEngine := lute.New()
+	luteEngine.SetAbbreviation(true)
+
+	for _, test := range md2VditorAbbreviationTests {
+		vHTML := luteEngine.Md2VditorDOM(test.from)
+		if test.to != vHTML {
+			t.Fatalf("test case [%s] failed\nexpected\n\t%q\ngot\n\t%q\noriginal markdown text\n\t%q", test.name, test.to, vHTML, test.from)
+		}
+		if md := luteEngine.VditorDOM2Md(vHTML); test.from != md {
+			t.Fatalf("test case [%s] failed\nexpected\n\t%q\ngot\n\t%q\noriginal html\n\t%q", test.name, test.from, md, vHTML)
+		}
+	}
+}
+
+var md2VditorIRAbbreviationTests = []parseTest{
+
+	{"0", "The HTML spec\n\n*[HTML]: Hyper Text Markup Language\n", "<p data-block=\"0\">The HTML spec\n</p><div data-block=\"0\" data-type=\"link-ref-defs-block\">*[HTML]: Hyper Text Markup Language\n</div>"},
+}
+
+func TestMd2VditorIRAbbreviation(t *testing.T) {
+	luteEngine := lute.New()
+	luteEngine.SetAbbreviation(true)
+
+	for _, test := range md2VditorIRAbbreviationTests {
+		vHTML := luteEngine.Md2VditorIRDOM(test.from)
+		if test.to != vHTML {
+			t.Fatalf("test case [%s] failed\nexpected\n\t%q\ngot\n\t%q\noriginal markdown text\n\t%q", test.name, test.to, vHTML, test.from)
+		}
+		if md := luteEngine.VditorIRDOM2Md(vHTML); test.from != md {
+			t.Fatalf("test case [%s] failed\nexpected\n\t%q\ngot\n\t%q\noriginal html\n\t%q", test.name, test.from, md, vHTML)
+		}
+	}
+}
@@ -0,0 +1,103 @@
+// Lute - 一款对中文语境优化的 Markdown 引擎，支持 Go 和 JavaScript
+// Copyright (c) 2019-present, b3log.org
+//
+// Lute is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//         http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND, EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT, MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+package test
+
+import (
+	"testing"
+
+	"lute"
+)
+
+var attributeListTests = []parseTest{
+
+	{"11", "![i](/i.png \"t\"){src=/evil.png alt=x title=y .c}\n", "<p><img src=\"/i.png\" alt=\"i\" title=\"t\" class=\"c\" /></p>\n"},
+	{"10", "[l](/u \"t\"){href=/evil title=x .y #z}\n", "<p><a href=\"/u\" title=\"t\" id=\"z\" class=\"y\">l</a></p>\n"},
+	{"9", "{.only}\n", "<p>{.only}</p>\n"},
+	{"8", "plain {not attr} text {.x}\n", "<p>plain {not attr} text {.x}</p>\n"},
+	{"7", "[ref]{.c}\n\n[ref]: /u\n", "<p><a href=\"/u\" class=\"c\">ref</a></p>\n"},
+	{"6", "*em*{.x} **st**{#s}\n", "<p><em class=\"x\">em</em> <strong id=\"s\">st</strong></p>\n"},
+	{"5", "a [link](/u){.ext target=_blank} b ![img](/i.png){width=50%}\n", "<p>a <a href=\"/u\" class=\"ext\" target=\"_blank\">link</a> b <img src=\"/i.png\" alt=\"img\" width=\"50%\" /></p>\n"},
+	{"4", "```go {#code .numberLines startFrom=10}\nx\n```\n", "<pre id=\"code\" class=\"numberLines\" startFrom=\"10\"><code class=\"language-go highlight-chroma\"><span class=\"highlight-nx\">x</span>\n</code></pre>\n"},
+	{"3", "para line\n{: .note #p1}\n", "<p id=\"p1\" class=\"note\">para line</p>\n"},
+	{"2", "Title {.cls}\n===\n", "<h1 id=\"Title\" class=\"cls\">Title</h1>\n"},
+	{"1", "# Title {myid}\n", "<h1 id=\"myid\">Title</h1>\n"},
+	{"0", "# Title {#intro .big data-x=\"a b\"}\n", "<h1 id=\"intro\" class=\"big\" data-x=\"a b\">Title</h1>\n"},
+}
+
+func TestAttributeList(t *testing.T) {
+	luteEngine := lute.New()
+	luteEngine.SetAttributeList(true)
+
+	for _, test := range attributeListTests {
+		html := luteEngine.MarkdownStr(test.name, test.from)
+		if test.to != html {
+			t.Fatalf("test case [%s] failed\nexpected\n\t%q\ngot\n\t%q\noriginal markdown text\n\t%q", test.name, test.to, html, test.from)
+		}
+	}
+}
+
+var attributeListSanitizeTests = []parseTest{
+
+	{"1", "[a](/b){title2=\"javascript:x\"}\n", "<p><a href=\"/b\">a</a></p>\n"},
+	{"0", "*em*{onclick=\"alert(1)\" .y}\n", "<p><em class=\"y\">em</em></p>\n"},
+}
+
+func TestAttributeListSanitize(t *testing.T) {
+	luteEngine := lute.New()
+	luteEngine.SetAttributeList(true)
+	luteEngine.SetSanitize(true)
+
+	for _, test := range attributeListSanitizeTests {
+		html := luteEngine.MarkdownStr(test.name, test.from)
+		if test.to != html {
+			t.Fatalf("test case [%s] failed\nexpected\n\t%q\ngot\n\t%q\noriginal markdown text\n\t%q", test.name, test.to, html, test.from)
+		}
+	}
+}
+
+var formatAttributeListTests = []parseTest{
+
+	{"4", "Title {.cls}\n===\n", "Title {.cls}\n=====\n"},
+	{"3", "```{.python}\nx\n```\n", "```{.python}\nx\n```\n"},
+	{"2", "para line\n{: .note #p1}\n", "para line\n{#p1 .note}\n"},
+	{"1", "a [link](/u){.ext target=_blank} ![img](/i.png){width=50%} *em*{.x} **st**{#s}\n", "a [link](/u){.ext target=_blank} ![img](/i.png){width=50%} *em*{.x} **st**{#s}\n"},
+	{"0", "# Title {#intro .big data-x=\"a b\"}\n", "# Title {#intro .big data-x=\"a b\"}\n"},
+}
+
+func TestFormatAttributeList(t *testing.T) {
+	luteEngine := lute.New()
+	luteEngine.SetAttributeList(true)
+
+	for _, test := range formatAttributeListTests {
+		formatted := luteEngine.FormatStr(test.name, test.from)
+		if test.to != formatted {
+			t.Fatalf("test case [%s] failed\nexpected\n\t%q\ngot\n\t%q\noriginal markdown text\n\t%q", test.name, test.to, formatted, test.from)
+		}
+	}
+}
+
+var vditorAttributeListTests = []parseTest{
+
+	{"1", "para line\n{: .note}\n", "para line\n{.note}\n"},
+	{"0", "# Title {#intro .big}\n", "# Title {#intro .big}\n"},
+}
+
+func TestVditorAttributeList(t *testing.T) {
+	luteEngine := lute.New()
+	luteEngine.SetAttributeList(true)
+
+	for _, test := range vditorAttributeListTests {
+		md := luteEngine.VditorDOM2Md(luteEngine.Md2VditorDOM(test.from))
+		if test.to != md {
+			t.Fatalf("test case [%s] failed\nexpected\n\t%q\ngot\n\t%q\noriginal markdown text\n\t%q", test.name, test.to, md, test.from)
+		}
+	}
+}
@@ -0,0 +1,82 @@
+// Lute - 一款对中文语境优化的 Markdown 引擎，支持 Go 和 JavaScript
+// Copyright (c) 2019-present, b3log.org
+//
+// Lute is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//         http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND, EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT, MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+package test
+
+import (
+	"testing"
+
+	"lute"
+	"lute/render"
+)
+
+var chineseS2TTests = []parseTest{
+
+	{"4", "见 https://example.com/a 和 [汉字](https://example.com/汉字)\n", "<p>見 <a href=\"https://example.com/a\">https://example.com/a</a> 和 <a href=\"https://example.com/%E6%B1%89%E5%AD%97\">漢字</a></p>\n"},
+	{"3", "`简体代码` $简体$\n\n```\n简体代码块\n```\n", "<p><code>简体代码</code> <span class=\"vditor-math\">简体</span></p>\n<pre><code class=\"highlight-chroma\">简体代码块\n</code></pre>\n"},
+	{"2", "皇后的头发，发现后面还有饼干\n", "<p>皇后的頭髮，發現後面還有餅乾</p>\n"},
+	{"1", "这里是简体中文，繁体转换\n", "<p>這裡是簡體中文，繁體轉換</p>\n"},
+	{"0", "**简体**中文\n", "<p><strong>簡體</strong>中文</p>\n"},
+}
+
+func TestChineseS2T(t *testing.T) {
+	luteEngine := lute.New()
+	luteEngine.SetChineseConvert(render.ChineseS2T)
+
+	for _, test := range chineseS2TTests {
+		html := luteEngine.MarkdownStr(test.name, test.from)
+		if test.to != html {
+			t.Fatalf("test case [%s] failed\nexpected\n\t%q\ngot\n\t%q\noriginal markdown text\n\t%q", test.name, test.to, html, test.from)
+		}
+	}
+}
+
+var formatChineseT2STests = []parseTest{
+
+	{"0", "這裡是繁體中文，`繁體代碼`，著作和睡著了\n", "这里是繁体中文，`繁體代碼`，著作和睡着了\n"},
+}
+
+func TestFormatChineseT2S(t *testing.T) {
+	luteEngine := lute.New()
+	luteEngine.SetChineseConvert(render.ChineseT2S)
+
+	for _, test := range formatChineseT2STests {
+		formatted := luteEngine.FormatStr(test.name, test.from)
+		if test.to != formatted {
+			t.Fatalf("test case [%s] failed\nexpected\n\t%q\ngot\n\t%q\noriginal markdown text\n\t%q", test.name, test.to, formatted, test.from)
+		}
+	}
+}
+
+var convertChineseTests = []struct {
+	name      string
+	text      string
+	direction string
+	converted string
+}{
+
+	{"5", "见 https://zh.wikipedia.org/wiki/汉字。汉字", render.ChineseS2T, "見 https://zh.wikipedia.org/wiki/汉字。漢字"},
+	{"4", "简体", "", "简体"},
+	{"3", "乾隆年間的乾淨衣服", render.ChineseT2S, "乾隆年间的干净衣服"},
+	{"2", "頭髮和發現", render.ChineseT2S, "头发和发现"},
+	{"1", "干部把衣服晒干了", render.ChineseS2T, "幹部把衣服曬乾了"},
+	{"0", "汉字", render.ChineseS2T, "漢字"},
+}
+
+func TestConvertChinese(t *testing.T) {
+	luteEngine := lute.New()
+
+	for _, test := range convertChineseTests {
+		converted := luteEngine.ConvertChinese(test.text, test.direction)
+		if test.converted != converted {
+			t.Fatalf("test case [%s] failed\nexpected\n\t%q\ngot\n\t%q\noriginal text\n\t%q", test.name, test.converted, converted, test.text)
+		}
+	}
+}
@@ -0,0 +1,196 @@
+// Lute - 一款对中文语境优化的 Markdown 引擎，支持 Go 和 JavaScript
+// Copyright (c) 2019-present, b3log.org
+//
+// Lute is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//         http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND, EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT, MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+package test
+
+import (
+	"testing"
+
+	"lute"
+	"lute/parse"
+)
+
+const citationBibTeX = `@string{jst = "Journal of Stuff"}
+@comment{ignored}
+@article{smith2020,
+  author = {Smith, John and Doe, Jane},
+  title = {A {Great} Paper},
+  journal = jst,
+  year = 2020,
+  pages = {33--45},
+  doi = {10.1000/xyz}
+}
+@book{roe2019,
+  author = {Roe, Bob and Poe, Al and Moe, Cy},
+  title = "Big Book",
+  publisher = {ACME},
+  year = {2019}
+}
+@misc{who,
+  author = {{World Health Organization}},
+  title = {Report},
+}
+`
+
+func newCitationLute(t *testing.T) *lute.Lute {
+	bibliography, err := parse.ParseBibTeX([]byte(citationBibTeX))
+	if nil != err {
+		t.Fatalf("parse bibtex failed: %s", err)
+	}
+	luteEngine := lute.New()
+	luteEngine.SetCitation(true)
+	luteEngine.SetBibliography(bibliography)
+	return luteEngine
+}
+
+var citationTests = []parseTest{
+
+	{"2", "`@smith2020` and mail a@smith2020\n", "<p><code>@smith2020</code> and mail <a href=\"mailto:a@smith2020\">a@smith2020</a></p>\n"},
+	{"1", "[@who; @nokey]\n", "<p><span class=\"citation\" data-cites=\"who nokey\">(<a href=\"#ref-who\">World Health Organization n.d.</a>; <strong class=\"citation-undefined\">nokey?</strong>)</span></p>\n<div id=\"refs\" class=\"references\">\n<div id=\"ref-who\" class=\"csl-entry\">World Health Organization. n.d. Report.</div>\n</div>"},
+	{"0", "As @smith2020 shows [see @roe2019, p. 33; -@smith2020].\n", "<p>As <span class=\"citation\" data-cites=\"smith2020\"><a href=\"#ref-smith2020\">Smith and Doe (2020)</a></span> shows <span class=\"citation\" data-cites=\"roe2019 smith2020\">(see <a href=\"#ref-roe2019\">Roe et al. 2019, p. 33</a>; <a href=\"#ref-smith2020\">2020</a>)</span>.</p>\n<div id=\"refs\" class=\"references\">\n<div id=\"ref-roe2019\" class=\"csl-entry\">Roe, Bob, Al Poe and Cy Moe. 2019. Big Book. ACME.</div>\n<div id=\"ref-smith2020\" class=\"csl-entry\">Smith, John and Jane Doe. 2020. A Great Paper. <em>Journal of Stuff</em>, 33–45. <a href=\"https://doi.org/10.1000/xyz\">https://doi.org/10.1000/xyz</a></div>\n</div>"},
+}
+
+func TestCitation(t *testing.T) {
+	luteEngine := newCitationLute(t)
+
+	for _, test := range citationTests {
+		html := luteEngine.MarkdownStr(test.name, test.from)
+		if test.to != html {
+			t.Fatalf("test case [%s] failed\nexpected\n\t%q\ngot\n\t%q\noriginal markdown text\n\t%q", test.name, test.to, html, test.from)
+		}
+	}
+}
+
+var numericCitationTests = []parseTest{
+
+	{"1", "Cited [@roe2019; @smith2020].\n", "<p>Cited <span class=\"citation\" data-cites=\"roe2019 smith2020\">[<a href=\"#ref-roe2019\">1</a>, <a href=\"#ref-smith2020\">2</a>]</span>.</p>\n<div id=\"refs\" class=\"references\">\n<div id=\"ref-roe2019\" class=\"csl-entry\"><span class=\"csl-left-margin\">[1]</span> Roe, Bob, Al Poe and Cy Moe. 2019. Big Book. ACME.</div>\n<div id=\"ref-smith2020\" class=\"csl-entry\"><span class=\"csl-left-margin\">[2]</span> Smith, John and Jane Doe. 2020. A Great Paper. <em>Journal of Stuff</em>, 33–45. <a href=\"https://doi.org/10.1000/xyz\">https://doi.org/10.1000/xyz</a></div>\n</div>"},
+	{"0", "As @smith2020 shows [see @roe2019, p. 33; -@smith2020].\n", "<p>As <span class=\"citation\" data-cites=\"smith2020\"><a href=\"#ref-smith2020\">Smith and Doe [1]</a></span> shows <span class=\"citation\" data-cites=\"roe2019 smith2020\">[see <a href=\"#ref-roe2019\">2, p. 33</a>; <a href=\"#ref-smith2020\">1</a>]</span>.</p>\n<div id=\"refs\" class=\"references\">\n<div id=\"ref-smith2020\" class=\"csl-entry\"><span class=\"csl-left-margin\">[1]</span> Smith, John and Jane Doe. 2020. A Great Paper. <em>Journal of Stuff</em>, 33–45. <a href=\"https://doi.org/10.1000/xyz\">https://doi.org/10.1000/xyz</a></div>\n<div id=\"ref-roe2019\" class=\"csl-entry\"><span class=\"csl-left-margin\">[2]</span> Roe, Bob, Al Poe and Cy Moe. 2019. Big Book. ACME.</div>\n</div>"},
+}
+
+func TestNumericCitation(t *testing.T) {
+	luteEngine := newCitationLute(t)
+	luteEngine.SetCitationStyle(parse.CitationStyleNumeric)
+
+	for _, test := range numericCitationTests {
+		html := luteEngine.MarkdownStr(test.name, test.from)
+		if test.to != html {
+			t.Fatalf("test case [%s] failed\nexpected\n\t%q\ngot\n\t%q\noriginal markdown text\n\t%q", test.name, test.to, html, test.from)
+		}
+	}
+}
+
+var citationMentionTests = []parseTest{
+
+	{"0", "@smith2020 and @someone [@someone]\n", "<p><span class=\"citation\" data-cites=\"smith2020\"><a href=\"#ref-smith2020\">Smith and Doe (2020)</a></span> and <a href=\"/someone\" class=\"mention\">@someone</a> <span class=\"citation\" data-cites=\"someone\">(<strong class=\"citation-undefined\">someone?</strong>)</span></p>\n<div id=\"refs\" class=\"references\">\n<div id=\"ref-smith2020\" class=\"csl-entry\">Smith, John and Jane Doe. 2020. A Great Paper. <em>Journal of Stuff</em>, 33–45. <a href=\"https://doi.org/10.1000/xyz\">https://doi.org/10.1000/xyz</a></div>\n</div>"},
+}
+
+func TestCitationMention(t *testing.T) {
+	luteEngine := newCitationLute(t)
+	luteEngine.SetMention(true)
+	luteEngine.SetReferenceResolver(parse.ReferenceResolverFunc(func(typ, repo, id string) (string, bool) {
+		return "/" + id, true
+	}))
+
+	for _, test := range citationMentionTests {
+		html := luteEngine.MarkdownStr(test.name, test.from)
+		if test.to != html {
+			t.Fatalf("test case [%s] failed\nexpected\n\t%q\ngot\n\t%q\noriginal markdown text\n\t%q", test.name, test.to, html, test.from)
+		}
+	}
+}
+
+func TestFormatCitation(t *testing.T) {
+	luteEngine := newCitationLute(t)
+
+	from := "As @smith2020 shows [see @roe2019, p. 33; -@smith2020].\n"
+	to := "As @smith2020 shows [see @roe2019, p. 33; -@smith2020].\n"
+	if formatted := luteEngine.FormatStr("", from); to != formatted {
+		t.Fatalf("test case failed\nexpected\n\t%q\ngot\n\t%q\noriginal markdown text\n\t%q", to, formatted, from)
+	}
+}
+
+var md2VditorCitationTests = []parseTest{
+
+	{"0", "As @smith2020 shows [see @roe2019, p. 33].\n", "<p data-block=\"0\">As <span class=\"vditor-wysiwyg__block\" data-type=\"source-inline\"><code data-type=\"source-inline\">\u200b@smith2020</code><span class=\"vditor-wysiwyg__preview\" data-render=\"2\"><span class=\"citation\" data-cites=\"smith2020\"><a href=\"#ref-smith2020\">Smith and Doe (2020)</a></span></span></span>\u200b shows <span class=\"vditor-wysiwyg__block\" data-type=\"source-inline\"><code data-type=\"source-inline\">\u200b[see @roe2019, p. 33]</code><span class=\"vditor-wysiwyg__preview\" data-render=\"2\"><span class=\"citation\" data-cites=\"roe2019\">(see <a href=\"#ref-roe2019\">Roe et al. 2019, p. 33</a>)</span></span></span>\u200b.\n</p>"},
+}
+
+func TestMd2VditorCitation(t *testing.T) {
+	luteEngine := newCitationLute(t)
+
+	for _, test := range md2VditorCitationTests {
+		vHTML := luteEngine.Md2VditorDOM(test.from)
+		if test.to != vHTML {
+			t.Fatalf("test case [%s] failed\nexpected\n\t%q\ngot\n\t%q\noriginal markdown text\n\t%q", test.name, test.to, vHTML, test.from)
+		}
+		if md := luteEngine.VditorDOM2Md(vHTML); test.from != md {
+			t.Fatalf("test case [%s] failed\nexpected\n\t%q\ngot\n\t%q\noriginal html\n\t%q", test.name, test.from, md, vHTML)
+		}
+	}
+}
+
+var md2VditorIRCitationTests = []parseTest{
+
+	{"0", "As @smith2020 shows [see @roe2019, p. 33].\n", "<p data-block=\"0\">As <span data-type=\"inline-node\" class=\"vditor-ir__node\"><code class=\"vditor-ir__marker\" data-type=\"source-inline\">@smith2020</code></span> shows <span data-type=\"inline-node\" class=\"vditor-ir__node\"><code class=\"vditor-ir__marker\" data-type=\"source-inline\">[see @roe2019, p. 33]</code></span>.\n</p>"},
+}
+
+func TestMd2VditorIRCitation(t *testing.T) {
+	luteEngine := newCitationLute(t)
+
+	for _, test := range md2VditorIRCitationTests {
+		vHTML := luteEngine.Md2VditorIRDOM(test.from)
+		if test.to != vHTML {
+			t.Fatalf("test case [%s] failed\nexpected\n\t%q\ngot\n\t%q\noriginal markdown text\n\t%q", test.name, test.to, vHTML, test.from)
+		}
+		if md := luteEngine.VditorIRDOM2Md(vHTML); test.from != md {
+			t.Fatalf("test case [%s] failed\nexpected\n\t%q\ngot\n\t%q\noriginal html\n\t%q", test.name, test.from, md, vHTML)
+		}
+	}
+}
+
+func TestCitationDiagnostics(t *testing.T) {
+	luteEngine := newCitationLute(t)
+
+	tests := []struct {
+		markdown    string
+		diagnostics []string
+	}{
+		{"Email a@b.com, user @nobody, [@nobody]\n", []string{"1:21: undefined citation key [nobody]", "1:30: undefined citation key [nobody]"}},
+		{"See [@who; @nokey].\n\nAnd @missing.\n", []string{"1:5: undefined citation key [nokey]", "3:5: undefined citation key [missing]"}},
+	}
+	for _, test := range tests {
+		diagnostics := luteEngine.Diagnostics([]byte(test.markdown))
+		if len(test.diagnostics) != len(diagnostics) {
+			t.Fatalf("expected [%d] diagnostics, got [%d]: %v", len(test.diagnostics), len(diagnostics), diagnostics)
+		}
+		for i, diagnostic := range diagnostics {
+			if test.diagnostics[i] != diagnostic.String() {
+				t.Fatalf("diagnostic [%d] failed\nexpected\n\t%q\ngot\n\t%q", i, test.diagnostics[i], diagnostic.String())
+			}
+		}
+	}
+}
+
+func TestParseCSLJSON(t *testing.T) {
+	bibliography, err := parse.ParseCSLJSON([]byte(`[{"id": "k1", "type": "article-journal", "title": "T", "author": [{"family": "F", "given": "G"}, {"literal": "ACME"}], "issued": {"date-parts": [[2021, 3]]}, "container-title": "J"}]`))
+	if nil != err {
+		t.Fatalf("parse csl-json failed: %s", err)
+	}
+	entry := bibliography["k1"]
+	if nil == entry || "T" != entry.Title || "2021" != entry.Year || "J" != entry.Container || "F and ACME" != entry.CitationAuthor() {
+		t.Fatalf("unexpected entry %+v", entry)
+	}
+
+	if _, err = parse.ParseCSLJSON([]byte(`[{"title": "no id"}]`)); nil == err {
+		t.Fatalf("expected error for item without id")
+	}
+	if _, err = parse.ParseBibTeX([]byte("@article{x,\n title = {oops")); nil == err || "bibtex: line 2: unexpected end of input" != err.Error() {
+		t.Fatalf("unexpected bibtex error %v", err)
+	}
+}
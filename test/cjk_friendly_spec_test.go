@@ -0,0 +1,91 @@
+// Lute - 一款对中文语境优化的 Markdown 引擎，支持 Go 和 JavaScript
+// Copyright (c) 2019-present, b3log.org
+//
+// Lute is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//         http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND, EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT, MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+package test
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"strconv"
+	"testing"
+
+	"lute"
+)
+
+func newCJKFriendlySpecLute() *lute.Lute {
+	luteEngine := lute.New()
+	luteEngine.GFMTaskListItem = false
+	luteEngine.GFMTable = false
+	luteEngine.GFMAutoLink = false
+	luteEngine.GFMStrikethrough = false
+	luteEngine.SoftBreak2HardBreak = false
+	luteEngine.CodeSyntaxHighlight = false
+	luteEngine.HeadingID = false
+	luteEngine.AutoSpace = false
+	luteEngine.FixTermTypo = false
+	luteEngine.ChinesePunct = false
+	luteEngine.Emoji = false
+	luteEngine.SetCJKFriendlyEmphasis(true)
+	return luteEngine
+}
+
+func readSpecTestcases(t *testing.T, path string) (ret []testcase) {
+	bytes, err := ioutil.ReadFile(path)
+	if nil != err {
+		t.Fatalf("read spec test cases failed: " + err.Error())
+	}
+	if err = json.Unmarshal(bytes, &ret); nil != err {
+		t.Fatalf("read spec test caes failed: " + err.Error())
+	}
+	return
+}
+
+func TestCJKFriendlySpec(t *testing.T) {
+	luteEngine := newCJKFriendlySpecLute()
+
+	for _, test := range readSpecTestcases(t, "cjk-friendly-spec.json") {
+		testName := test.Section + " " + strconv.Itoa(test.Example)
+		html := luteEngine.MarkdownStr(testName, test.Markdown)
+		if test.HTML != html {
+			t.Fatalf("test case [%s] failed\nexpected\n\t%q\ngot\n\t%q\noriginal markdown text\n\t%q", testName, test.HTML, html, test.Markdown)
+		}
+	}
+}
+
+// TestCJKFriendlyCommonMarkSpec 确保打开中日韩友好的强调规则后仍然通过 CommonMark 规范测试。
+func TestCJKFriendlyCommonMarkSpec(t *testing.T) {
+	luteEngine := newCJKFriendlySpecLute()
+
+	for _, test := range readSpecTestcases(t, "commonmark-spec.json") {
+		testName := test.Section + " " + strconv.Itoa(test.Example)
+		html := luteEngine.MarkdownStr(testName, test.Markdown)
+		if test.HTML != html {
+			t.Fatalf("test case [%s] failed\nexpected\n\t%q\ngot\n\t%q\noriginal markdown text\n\t%q", testName, test.HTML, html, test.Markdown)
+		}
+	}
+}
+
+var cjkFriendlyStrikethroughTests = []parseTest{
+
+	{"1", "删除~“引号”~文字\n", "<p>删除<del>“引号”</del>文字</p>\n"},
+	{"0", "这是~~“删除”~~文字\n", "<p>这是<del>“删除”</del>文字</p>\n"},
+}
+
+func TestCJKFriendlyStrikethrough(t *testing.T) {
+	luteEngine := newCJKFriendlySpecLute()
+	luteEngine.GFMStrikethrough = true
+
+	for _, test := range cjkFriendlyStrikethroughTests {
+		html := luteEngine.MarkdownStr(test.name, test.from)
+		if test.to != html {
+			t.Fatalf("test case [%s] failed\nexpected\n\t%q\ngot\n\t%q\noriginal markdown text\n\t%q", test.name, test.to, html, test.from)
+		}
+	}
+}
@@ -0,0 +1,113 @@
+// Lute - 一款对中文语境优化的 Markdown 引擎，支持 Go 和 JavaScript
+// Copyright (c) 2019-present, b3log.org
+//
+// Lute is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//         http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND, EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT, MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+package test
+
+import (
+	"testing"
+
+	"lute"
+)
+
+var cjkSoftBreakTests = []parseTest{
+
+	{"6", "한국어\n문장\n", "<p>한국어\n문장</p>\n"},
+	{"5", "中文\n`code`\n", "<p>中文\n<code>code</code></p>\n"},
+	{"4", "**中文**\n强调\n", "<p><strong>中文</strong>强调</p>\n"},
+	{"3", "中文。\n「引号」\n", "<p>中文。「引号」</p>\n"},
+	{"2", "中文\nLatin\n", "<p>中文\nLatin</p>\n"},
+	{"1", "foo\nbar\n", "<p>foo\nbar</p>\n"},
+	{"0", "中文\n折行\nかな\n", "<p>中文折行かな</p>\n"},
+}
+
+func TestCJKSoftBreak(t *testing.T) {
+	luteEngine := lute.New()
+	luteEngine.SetSoftBreak2HardBreak(false)
+	luteEngine.SetAutoSpace(false)
+	luteEngine.SetCJKSoftBreak(true)
+
+	for _, test := range cjkSoftBreakTests {
+		html := luteEngine.MarkdownStr(test.name, test.from)
+		if test.to != html {
+			t.Fatalf("test case [%s] failed\nexpected\n\t%q\ngot\n\t%q\noriginal markdown text\n\t%q", test.name, test.to, html, test.from)
+		}
+	}
+}
+
+var cjkSoftBreakFormatTests = []formatTest{
+
+	{"1", "foo\nbar\n", "foo\nbar\n"},
+	{"0", "中文\n折行\nLatin\n", "中文折行\nLatin\n"},
+}
+
+func TestCJKSoftBreakFormat(t *testing.T) {
+	luteEngine := lute.New()
+	luteEngine.SetSoftBreak2HardBreak(false)
+	luteEngine.SetAutoSpace(false)
+	luteEngine.SetCJKSoftBreak(true)
+
+	for _, test := range cjkSoftBreakFormatTests {
+		formatted := luteEngine.FormatStr(test.name, test.original)
+		if test.formatted != formatted {
+			t.Fatalf("test case [%s] failed\nexpected\n\t%q\ngot\n\t%q\noriginal markdown text\n\t%q", test.name, test.formatted, formatted, test.original)
+		}
+	}
+}
+
+var cjkSoftBreakVditorTests = []parseTest{
+
+	{"1", "中文\nLatin\n", "<p data-block=\"0\">中文\nLatin\n</p>"},
+	{"0", "中文\n折行\n", "<p data-block=\"0\">中文\n折行\n</p>"},
+}
+
+func TestCJKSoftBreakVditor(t *testing.T) {
+	luteEngine := lute.New()
+	luteEngine.SetSoftBreak2HardBreak(false)
+	luteEngine.SetAutoSpace(false)
+	luteEngine.SetCJKSoftBreak(true)
+
+	for _, test := range cjkSoftBreakVditorTests {
+		vHTML := luteEngine.Md2VditorDOM(test.from)
+		if test.to != vHTML {
+			t.Fatalf("test case [%s] failed\nexpected\n\t%q\ngot\n\t%q\noriginal markdown text\n\t%q", test.name, test.to, vHTML, test.from)
+		}
+		if md := luteEngine.VditorDOM2Md(vHTML); test.from != md {
+			t.Fatalf("test case [%s] failed\nexpected\n\t%q\ngot\n\t%q\noriginal html\n\t%q", test.name, test.from, md, vHTML)
+		}
+	}
+}
+
+var cjkSoftBreakVditorHTMLTests = []parseTest{
+
+	{"1", "中文\nLatin\n", "<p>中文\nLatin</p>\n"},
+	{"0", "中文\n折行\n", "<p>中文折行</p>\n"},
+}
+
+func TestCJKSoftBreakVditorHTML(t *testing.T) {
+	luteEngine := lute.New()
+	luteEngine.SetSoftBreak2HardBreak(false)
+	luteEngine.SetAutoSpace(false)
+	luteEngine.SetCJKSoftBreak(true)
+
+	for _, test := range cjkSoftBreakVditorHTMLTests {
+		// 所见即所得和即时渲染 DOM 中保留源码折行，导出 HTML 时去掉软换行
+		vHTML := luteEngine.Md2VditorDOM(test.from)
+		if html := luteEngine.VditorDOM2HTML(vHTML); test.to != html {
+			t.Fatalf("test case [%s] failed\nexpected\n\t%q\ngot\n\t%q\noriginal html\n\t%q", test.name, test.to, html, vHTML)
+		}
+		irHTML := luteEngine.Md2VditorIRDOM(test.from)
+		if md := luteEngine.VditorIRDOM2Md(irHTML); test.from != md {
+			t.Fatalf("test case [%s] failed\nexpected\n\t%q\ngot\n\t%q\noriginal html\n\t%q", test.name, test.from, md, irHTML)
+		}
+		if html := luteEngine.VditorIRDOM2HTML(irHTML); test.to != html {
+			t.Fatalf("test case [%s] failed\nexpected\n\t%q\ngot\n\t%q\noriginal html\n\t%q", test.name, test.to, html, irHTML)
+		}
+	}
+}
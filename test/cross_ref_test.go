@@ -0,0 +1,167 @@
+// Lute - 一款对中文语境优化的 Markdown 引擎，支持 Go 和 JavaScript
+// Copyright (c) 2019-present, b3log.org
+//
+// Lute is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//         http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND, EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT, MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+package test
+
+import (
+	"testing"
+
+	"lute"
+	"lute/parse"
+)
+
+var crossRefTests = []parseTest{
+
+	{"5", "`@fig:a` and @fig:a\n\n![a](a.png){#fig:a}\n", "<p><code>@fig:a</code> and <a href=\"#fig:a\" class=\"cross-ref\">Figure 1</a></p>\n<p><img src=\"a.png\" alt=\"a\" id=\"fig:a\" /><span class=\"figure-caption\"><span class=\"caption-number\">Figure 1</span></span></p>\n"},
+	{"4", "See @fig:none and email@fig:x.\n", "<p>See <span class=\"cross-ref cross-ref-undefined\">@fig:none</span> and email@fig:x.</p>\n"},
+	{"3", "$$\nE=mc^2\n$$ {#eq:emc}\n\nBy @eq:emc.\n", "<div class=\"vditor-math\" id=\"eq:emc\">E=mc^2</div><span class=\"equation-number\">(1)</span>\n<p>By <a href=\"#eq:emc\" class=\"cross-ref\">Equation 1</a>.</p>\n"},
+	{"2", "| a |\n| - |\n| 1 |\nTable: Data {#tbl:data}\n\nSee @tbl:data.\n", "<table id=\"tbl:data\">\n<caption><span class=\"caption-number\">Table 1</span> Data</caption>\n<thead>\n<tr>\n<th>a</th>\n</tr>\n</thead>\n<tbody>\n<tr>\n<td>1</td>\n</tr>\n</tbody>\n</table>\n<p>See <a href=\"#tbl:data\" class=\"cross-ref\">Table 1</a>.</p>\n"},
+	{"1", "![arch](a.png \"Architecture\"){#fig:arch}\n\nAs shown in @fig:arch.\n", "<p><img src=\"a.png\" alt=\"arch\" title=\"Architecture\" id=\"fig:arch\" /><span class=\"figure-caption\"><span class=\"caption-number\">Figure 1</span> Architecture</span></p>\n<p>As shown in <a href=\"#fig:arch\" class=\"cross-ref\">Figure 1</a>.</p>\n"},
+	{"0", "# A {#sec:a}\n\n## B {#sec:b}\n\n# C {#sec:c}\n\nSee [@sec:b] and @sec:c.\n", "<h1 id=\"sec-a\">A</h1>\n<h2 id=\"sec-b\">B</h2>\n<h1 id=\"sec-c\">C</h1>\n<p>See <a href=\"#sec-b\" class=\"cross-ref\">Section 1.1</a> and <a href=\"#sec-c\" class=\"cross-ref\">Section 2</a>.</p>\n"},
+}
+
+func TestCrossRef(t *testing.T) {
+	luteEngine := lute.New()
+	luteEngine.SetCrossRef(true)
+	luteEngine.SetAttributeList(true)
+	luteEngine.SetTableCaption(true)
+
+	for _, test := range crossRefTests {
+		html := luteEngine.MarkdownStr(test.name, test.from)
+		if test.to != html {
+			t.Fatalf("test case [%s] failed\nexpected\n\t%q\ngot\n\t%q\noriginal markdown text\n\t%q", test.name, test.to, html, test.from)
+		}
+	}
+}
+
+var crossRefPrefixTests = []parseTest{
+
+	{"0", "# A {#sec:a}\n\n## B {#sec:b}\n\n见 @sec:b。\n", "<h1 id=\"sec-a\">A</h1>\n<h2 id=\"sec-b\">B</h2>\n<p>见 <a href=\"#sec-b\" class=\"cross-ref\">第 1.1</a>。</p>\n"},
+}
+
+func TestCrossRefPrefix(t *testing.T) {
+	luteEngine := lute.New()
+	luteEngine.SetCrossRef(true)
+	luteEngine.SetAttributeList(true)
+	luteEngine.SetSectionNumberPrefix("第 ")
+
+	for _, test := range crossRefPrefixTests {
+		html := luteEngine.MarkdownStr(test.name, test.from)
+		if test.to != html {
+			t.Fatalf("test case [%s] failed\nexpected\n\t%q\ngot\n\t%q\noriginal markdown text\n\t%q", test.name, test.to, html, test.from)
+		}
+	}
+}
+
+var formatCrossRefTests = []parseTest{
+
+	{"0", "$$\nE=mc^2\n$$   {#eq:emc}\n\n| a |\n| - |\nTable: Data   {#tbl:data}\n\nSee [@eq:emc].\n", "$$\nE=mc^2\n$$ {#eq:emc}\n\n| a |\n| - |\n\nTable: Data {#tbl:data}\n\nSee [@eq:emc].\n"},
+}
+
+func TestFormatCrossRef(t *testing.T) {
+	luteEngine := lute.New()
+	luteEngine.SetCrossRef(true)
+	luteEngine.SetAttributeList(true)
+	luteEngine.SetTableCaption(true)
+
+	for _, test := range formatCrossRefTests {
+		formatted := luteEngine.FormatStr(test.name, test.from)
+		if test.to != formatted {
+			t.Fatalf("test case [%s] failed\nexpected\n\t%q\ngot\n\t%q\noriginal markdown text\n\t%q", test.name, test.to, formatted, test.from)
+		}
+	}
+}
+
+var md2VditorCrossRefTests = []parseTest{
+
+	{"0", "# A {#sec:a}\n\nSee [@sec:a] and @fig:none.\n", "<h1 data-block=\"0\" data-attrs=\"{#sec:a}\" data-id=\"sec:a\" id=\"wysiwyg-sec:a\" data-marker=\"#\">A</h1><p data-block=\"0\">See <span class=\"vditor-wysiwyg__block\" data-type=\"source-inline\"><code data-type=\"source-inline\">\u200b[@sec:a]</code><span class=\"vditor-wysiwyg__preview\" data-render=\"2\"><a href=\"#sec-a\" class=\"cross-ref\">Section 1</a></span></span>\u200b and <span class=\"vditor-wysiwyg__block\" data-type=\"source-inline\"><code data-type=\"source-inline\">\u200b@fig:none</code><span class=\"vditor-wysiwyg__preview\" data-render=\"2\"><span class=\"cross-ref cross-ref-undefined\">@fig:none</span></span></span>\u200b.\n</p>"},
+}
+
+func TestMd2VditorCrossRef(t *testing.T) {
+	luteEngine := lute.New()
+	luteEngine.SetCrossRef(true)
+	luteEngine.SetAttributeList(true)
+
+	for _, test := range md2VditorCrossRefTests {
+		vHTML := luteEngine.Md2VditorDOM(test.from)
+		if test.to != vHTML {
+			t.Fatalf("test case [%s] failed\nexpected\n\t%q\ngot\n\t%q\noriginal markdown text\n\t%q", test.name, test.to, vHTML, test.from)
+		}
+		if md := luteEngine.VditorDOM2Md(vHTML); test.from != md {
+			t.Fatalf("test case [%s] failed\nexpected\n\t%q\ngot\n\t%q\noriginal html\n\t%q", test.name, test.from, md, vHTML)
+		}
+	}
+}
+
+var md2VditorIRCrossRefTests = []parseTest{
+
+	{"0", "# A {#sec:a}\n\nSee [@sec:a] and @fig:none.\n", "<h1 data-block=\"0\" class=\"vditor-ir__node\" data-attrs=\"{#sec:a}\" data-id=\"sec:a\" id=\"ir-sec:a\" data-marker=\"#\"><span class=\"vditor-ir__marker vditor-ir__marker--heading\" data-type=\"heading-marker\"># </span>A</h1><p data-block=\"0\">See <span data-type=\"inline-node\" class=\"vditor-ir__node\"><code class=\"vditor-ir__marker\" data-type=\"source-inline\">[@sec:a]</code></span> and <span data-type=\"inline-node\" class=\"vditor-ir__node\"><code class=\"vditor-ir__marker\" data-type=\"source-inline\">@fig:none</code></span>.\n</p>"},
+}
+
+func TestMd2VditorIRCrossRef(t *testing.T) {
+	luteEngine := lute.New()
+	luteEngine.SetCrossRef(true)
+	luteEngine.SetAttributeList(true)
+
+	for _, test := range md2VditorIRCrossRefTests {
+		vHTML := luteEngine.Md2VditorIRDOM(test.from)
+		if test.to != vHTML {
+			t.Fatalf("test case [%s] failed\nexpected\n\t%q\ngot\n\t%q\noriginal markdown text\n\t%q", test.name, test.to, vHTML, test.from)
+		}
+		if md := luteEngine.VditorIRDOM2Md(vHTML); test.from != md {
+			t.Fatalf("test case [%s] failed\nexpected\n\t%q\ngot\n\t%q\noriginal html\n\t%q", test.name, test.from, md, vHTML)
+		}
+	}
+}
+
+func TestCrossRefUnnumberedHeading(t *testing.T) {
+	luteEngine := lute.New()
+	luteEngine.SetCrossRef(true)
+	luteEngine.SetAttributeList(true)
+	luteEngine.SetHeadingNumbering(true)
+	luteEngine.SetHeadingNumberingMaxDepth(1)
+
+	md := "# A {#sec:a}\n\n## B *b* {#sec:b}\n\nSee @sec:a and @sec:b.\n"
+	expected := "<h1 id=\"sec-a\"><span class=\"heading-number\">1</span> A</h1>\n<h2 id=\"sec-b\">B <em>b</em></h2>\n<p>See <a href=\"#sec-a\" class=\"cross-ref\">Section 1</a> and <a href=\"#sec-b\" class=\"cross-ref\">B b</a>.</p>\n"
+	html := luteEngine.MarkdownStr("", md)
+	if expected != html {
+		t.Fatalf("reference to unnumbered heading failed\nexpected\n\t%q\ngot\n\t%q", expected, html)
+	}
+}
+
+func TestCrossRefSourcePos(t *testing.T) {
+	luteEngine := lute.New()
+	luteEngine.SetCrossRef(true)
+
+	md := "See @fig:a and @fig:b end\n"
+	expected := "NodeParagraph 1:1-1:26 [0,25) See @fig:a and @fig:b end\nNodeText 1:1-1:5 [0,4) See \nNodeCrossRef 1:5-1:11 [4,10) @fig:a\nNodeText 1:11-1:16 [10,15)  and \nNodeCrossRef 1:16-1:22 [15,21) @fig:b\nNodeText 1:22-1:26 [21,25)  end\n"
+	pos := dumpSourcePos(parse.Parse("", []byte(md), luteEngine.Options).Root, md)
+	if expected != pos {
+		t.Fatalf("cross reference source position failed\nexpected\n\t%q\ngot\n\t%q", expected, pos)
+	}
+}
+
+func TestCrossRefDiagnostics(t *testing.T) {
+	luteEngine := lute.New()
+	luteEngine.SetCrossRef(true)
+	luteEngine.SetAttributeList(true)
+
+	md := "See @fig:none.\n\n![a](a.png){#fig:a}\n\n![b](b.png){#fig:a}\n\n![c](c.png){#sec:c}\n"
+	expected := []string{"5:1: duplicate label [fig:a]", "7:1: label [sec:c] can not be used on Image", "1:5: undefined label [fig:none]"}
+	diagnostics := luteEngine.Diagnostics([]byte(md))
+	if len(expected) != len(diagnostics) {
+		t.Fatalf("expected [%d] diagnostics, got [%d]: %v", len(expected), len(diagnostics), diagnostics)
+	}
+	for i, diagnostic := range diagnostics {
+		if expected[i] != diagnostic.String() {
+			t.Fatalf("diagnostic [%d] failed\nexpected\n\t%q\ngot\n\t%q", i, expected[i], diagnostic.String())
+		}
+	}
+}
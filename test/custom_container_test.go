@@ -0,0 +1,123 @@
+// Lute - 一款对中文语境优化的 Markdown 引擎，支持 Go 和 JavaScript
+// Copyright (c) 2019-present, b3log.org
+//
+// Lute is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//         http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND, EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT, MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+package test
+
+import (
+	"testing"
+
+	"lute"
+	"lute/ast"
+)
+
+var customContainerTests = []parseTest{
+
+	{"6", "- ::: x\n  a\n  :::\n", "<ul>\n<li>\n<div class=\"x\">\n<p>a</p>\n</div>\n</li>\n</ul>\n"},
+	{"5", "::: tip\n```\n:::\n```\n:::\n", "<div class=\"tip\">\n<pre><code class=\"highlight-chroma\">:::\n</code></pre>\n</div>\n"},
+	{"4", ":::\nfoo\n", "<p>:::<br />\nfoo</p>\n"},
+	{"3", "::: tip\nfoo\n", "<div class=\"tip\">\n<p>foo</p>\n</div>\n"},
+	{"2", "::::: outer\n::: inner\ntext\n:::\n:::::\n", "<div class=\"outer\">\n<div class=\"inner\">\n<p>text</p>\n</div>\n</div>\n"},
+	{"1", "::: a\n- x\n- y\n:::\nafter\n", "<div class=\"a\">\n<ul>\n<li>x</li>\n<li>y</li>\n</ul>\n</div>\n<p>after</p>\n"},
+	{"0", "::: warning Title <b>\n*foo*\n:::\n", "<div class=\"warning\">\n<p class=\"custom-container-title\">Title &lt;b&gt;</p>\n<p><em>foo</em></p>\n</div>\n"},
+}
+
+func TestCustomContainer(t *testing.T) {
+	luteEngine := lute.New()
+	luteEngine.SetCustomContainer(true)
+
+	for _, test := range customContainerTests {
+		html := luteEngine.MarkdownStr(test.name, test.from)
+		if test.to != html {
+			t.Fatalf("test case [%s] failed\nexpected\n\t%q\ngot\n\t%q\noriginal markdown text\n\t%q", test.name, test.to, html, test.from)
+		}
+	}
+}
+
+var customContainerRendererTests = []parseTest{
+
+	{"0", "::: details Click\nhi\n:::\n\n::: tip\nyo\n:::\n", "<details><summary>Click</summary>\n<p>hi</p>\n</details>\n<div class=\"tip\">\n<p>yo</p>\n</div>\n"},
+}
+
+func TestCustomContainerRenderer(t *testing.T) {
+	luteEngine := lute.New()
+	luteEngine.SetCustomContainer(true)
+	luteEngine.PutCustomContainerRenderer("details", func(node *ast.Node, entering bool) (string, ast.WalkStatus) {
+		if entering {
+			return "<details><summary>" + node.CustomContainerArgs + "</summary>\n", ast.WalkContinue
+		}
+		return "</details>\n", ast.WalkContinue
+	})
+
+	for _, test := range customContainerRendererTests {
+		html := luteEngine.MarkdownStr(test.name, test.from)
+		if test.to != html {
+			t.Fatalf("test case [%s] failed\nexpected\n\t%q\ngot\n\t%q\noriginal markdown text\n\t%q", test.name, test.to, html, test.from)
+		}
+	}
+}
+
+var formatCustomContainerTests = []parseTest{
+
+	{"2", "::: outer\n::: inner\ntext\n:::\n:::\n", ":::: outer\n::: inner\ntext\n:::\n::::\n\n:::\n"},
+	{"1", "::::: outer\n::: inner\ntext\n:::\n:::::\n", "::::: outer\n::: inner\ntext\n:::\n:::::\n"},
+	{"0", "::: warning Title\n*foo*\n:::\nafter\n", "::: warning Title\n*foo*\n:::\n\nafter\n"},
+}
+
+func TestFormatCustomContainer(t *testing.T) {
+	luteEngine := lute.New()
+	luteEngine.SetCustomContainer(true)
+
+	for _, test := range formatCustomContainerTests {
+		formatted := luteEngine.FormatStr(test.name, test.from)
+		if test.to != formatted {
+			t.Fatalf("test case [%s] failed\nexpected\n\t%q\ngot\n\t%q\noriginal markdown text\n\t%q", test.name, test.to, formatted, test.from)
+		}
+	}
+}
+
+var md2VditorCustomContainerTests = []parseTest{
+
+	{"0", "::: warning Take care\nbe **careful**\n:::\n", "<div class=\"vditor-wysiwyg__block\" data-type=\"source-block\" data-block=\"0\"><pre><code data-type=\"source-block\">::: warning Take care\nbe **careful**\n:::</code></pre><div class=\"vditor-wysiwyg__preview\" data-render=\"2\"><div class=\"warning\">\n<p class=\"custom-container-title\">Take care</p>\n<p>be <strong>careful</strong></p>\n</div>\n</div></div>"},
+}
+
+func TestMd2VditorCustomContainer(t *testing.T) {
+	luteEngine := lute.New()
+	luteEngine.SetCustomContainer(true)
+
+	for _, test := range md2VditorCustomContainerTests {
+		vHTML := luteEngine.Md2VditorDOM(test.from)
+		if test.to != vHTML {
+			t.Fatalf("test case [%s] failed\nexpected\n\t%q\ngot\n\t%q\noriginal markdown text\n\t%q", test.name, test.to, vHTML, test.from)
+		}
+		if md := luteEngine.VditorDOM2Md(vHTML); test.from != md {
+			t.Fatalf("test case [%s] failed\nexpected\n\t%q\ngot\n\t%q\noriginal html\n\t%q", test.name, test.from, md, vHTML)
+		}
+	}
+}
+
+var md2VditorIRCustomContainerTests = []parseTest{
+
+	{"0", "::: warning Take care\nbe **careful**\n:::\n", "<div data-block=\"0\" data-type=\"source-block\" class=\"vditor-ir__node\"><pre class=\"vditor-ir__marker--pre vditor-ir__marker\"><code data-type=\"source-block\">::: warning Take care\nbe **careful**\n:::</code></pre><div class=\"vditor-ir__preview\" data-render=\"2\"><div class=\"warning\">\n<p class=\"custom-container-title\">Take care</p>\n<p>be <strong>careful</strong></p>\n</div>\n</div></div>"},
+}
+
+func TestMd2VditorIRCustomContainer(t *testing.T) {
+	luteEngine := lute.New()
+	luteEngine.SetCustomContainer(true)
+
+	for _, test := range md2VditorIRCustomContainerTests {
+		vHTML := luteEngine.Md2VditorIRDOM(test.from)
+		if test.to != vHTML {
+			t.Fatalf("test case [%s] failed\nexpected\n\t%q\ngot\n\t%q\noriginal markdown text\n\t%q", test.name, test.to, vHTML, test.from)
+		}
+		if md := luteEngine.VditorIRDOM2Md(vHTML); test.from != md {
+			t.Fatalf("test case [%s] failed\nexpected\n\t%q\ngot\n\t%q\noriginal html\n\t%q", test.name, test.from, md, vHTML)
+		}
+	}
+}
@@ -0,0 +1,120 @@
+// Lute - 一款对中文语境优化的 Markdown 引擎，支持 Go 和 JavaScript
+// Copyright (c) 2019-present, b3log.org
+//
+// Lute is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//         http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND, EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT, MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+package test
+
+import (
+	"testing"
+
+	"lute"
+)
+
+var definitionListTests = []parseTest{
+
+	{"9", "| a |\n| - |\n| b |\n: d\n", "<table>\n<thead>\n<tr>\n<th>a</th>\n</tr>\n</thead>\n<tbody>\n<tr>\n<td>b</td>\n</tr>\n<tr>\n<td>: d</td>\n</tr>\n</tbody>\n</table>\n"},
+	{"8", "[foo]: /url\n: bar\n", "<p>: bar</p>\n"},
+	{"7", "foo\n:bar\n", "<p>foo<br />\n:bar</p>\n"},
+	{"6", "- item\n\n  T\n  : d\n", "<ul>\n<li>\n<p>item</p>\n<dl>\n<dt>T</dt>\n<dd>d</dd>\n</dl>\n</li>\n</ul>\n"},
+	{"5", "Apple\n:   Pomaceous fruit\n    lazy\n- list\n", "<dl>\n<dt>Apple</dt>\n<dd>Pomaceous fruit<br />\nlazy</dd>\n</dl>\n<ul>\n<li>list</li>\n</ul>\n"},
+	{"4", "T1\n: d1\n\n  p2\n\nafter\n", "<dl>\n<dt>T1</dt>\n<dd>\n<p>d1</p>\n<p>p2</p>\n</dd>\n</dl>\n<p>after</p>\n"},
+	{"3", "T1\n\n: d1\n", "<dl>\n<dt>T1</dt>\n<dd>\n<p>d1</p>\n</dd>\n</dl>\n"},
+	{"2", "T1\n: d1\n\nT2\n: d2\n", "<dl>\n<dt>T1</dt>\n<dd>d1</dd>\n<dt>T2</dt>\n<dd>d2</dd>\n</dl>\n"},
+	{"1", "T1\nT2\n: d1\n: d2\n", "<dl>\n<dt>T1</dt>\n<dt>T2</dt>\n<dd>d1</dd>\n<dd>d2</dd>\n</dl>\n"},
+	{"0", "Term *foo*\n: def\n", "<dl>\n<dt>Term <em>foo</em></dt>\n<dd>def</dd>\n</dl>\n"},
+}
+
+func TestDefinitionList(t *testing.T) {
+	luteEngine := lute.New()
+	luteEngine.SetDefinitionList(true)
+
+	for _, test := range definitionListTests {
+		html := luteEngine.MarkdownStr(test.name, test.from)
+		if test.to != html {
+			t.Fatalf("test case [%s] failed\nexpected\n\t%q\ngot\n\t%q\noriginal markdown text\n\t%q", test.name, test.to, html, test.from)
+		}
+	}
+}
+
+var formatDefinitionListTests = []parseTest{
+
+	{"3", "Apple\n:   Pomaceous fruit\n    lazy\n- list\n", "Apple\n: Pomaceous fruit\n  lazy\n\n- list\n"},
+	{"2", "T1\n\n: d1\n\n    p2\n", "T1\n\n: d1\n\n  p2\n"},
+	{"1", "T1\n: d1\n\nT2\n: d2\n", "T1\n: d1\n\nT2\n: d2\n"},
+	{"0", "T1\nT2\n: d1\n: d2\n", "T1\nT2\n: d1\n: d2\n"},
+}
+
+func TestFormatDefinitionList(t *testing.T) {
+	luteEngine := lute.New()
+	luteEngine.SetDefinitionList(true)
+
+	for _, test := range formatDefinitionListTests {
+		formatted := luteEngine.FormatStr(test.name, test.from)
+		if test.to != formatted {
+			t.Fatalf("test case [%s] failed\nexpected\n\t%q\ngot\n\t%q\noriginal markdown text\n\t%q", test.name, test.to, formatted, test.from)
+		}
+	}
+}
+
+var html2MdDefinitionListTests = []parseTest{
+
+	{"1", "<dl>\n<dt>Foo</dt>\n<dd>bar</dd>\n<dt>Baz</dt>\n<dd><p>one</p><p>two</p></dd>\n</dl>", "Foo\n: bar\n\nBaz\n\n: one\n\n  two\n"},
+	{"0", "<dl><dt>Foo</dt><dd>bar</dd></dl>", "Foo\n: bar\n"},
+}
+
+func TestHTML2MdDefinitionList(t *testing.T) {
+	luteEngine := lute.New()
+
+	for _, test := range html2MdDefinitionListTests {
+		md := luteEngine.HTML2Md(test.from)
+		if test.to != md {
+			t.Fatalf("test case [%s] failed\nexpected\n\t%q\ngot\n\t%q\noriginal html\n\t%q", test.name, test.to, md, test.from)
+		}
+	}
+}
+
+var md2VditorDefinitionListTests = []parseTest{
+
+	{"0", "Apple\n: *red* fruit\n", "<div class=\"vditor-wysiwyg__block\" data-type=\"source-block\" data-block=\"0\"><pre><code data-type=\"source-block\">Apple\n: *red* fruit</code></pre><div class=\"vditor-wysiwyg__preview\" data-render=\"2\"><dl>\n<dt>Apple</dt>\n<dd><em>red</em> fruit</dd>\n</dl>\n</div></div>"},
+}
+
+func TestMd2VditorDefinitionList(t *testing.T) {
+	luteEngine := lute.New()
+	luteEngine.SetDefinitionList(true)
+
+	for _, test := range md2VditorDefinitionListTests {
+		vHTML := luteEngine.Md2VditorDOM(test.from)
+		if test.to != vHTML {
+			t.Fatalf("test case [%s] failed\nexpected\n\t%q\ngot\n\t%q\noriginal markdown text\n\t%q", test.name, test.to, vHTML, test.from)
+		}
+		if md := luteEngine.VditorDOM2Md(vHTML); test.from != md {
+			t.Fatalf("test case [%s] failed\nexpected\n\t%q\ngot\n\t%q\noriginal html\n\t%q", test.name, test.from, md, vHTML)
+		}
+	}
+}
+
+var md2VditorIRDefinitionListTests = []parseTest{
+
+	{"0", "Apple\n: *red* fruit\n", "<div data-block=\"0\" data-type=\"source-block\" class=\"vditor-ir__node\"><pre class=\"vditor-ir__marker--pre vditor-ir__marker\"><code data-type=\"source-block\">Apple\n: *red* fruit</code></pre><div class=\"vditor-ir__preview\" data-render=\"2\"><dl>\n<dt>Apple</dt>\n<dd><em>red</em> fruit</dd>\n</dl>\n</div></div>"},
+}
+
+func TestMd2VditorIRDefinitionList(t *testing.T) {
+	luteEngine := lute.New()
+	luteEngine.SetDefinitionList(true)
+
+	for _, test := range md2VditorIRDefinitionListTests {
+		vHTML := luteEngine.Md2VditorIRDOM(test.from)
+		if test.to != vHTML {
+			t.Fatalf("test case [%s] failed\nexpected\n\t%q\ngot\n\t%q\noriginal markdown text\n\t%q", test.name, test.to, vHTML, test.from)
+		}
+		if md := luteEngine.VditorIRDOM2Md(vHTML); test.from != md {
+			t.Fatalf("test case [%s] failed\nexpected\n\t%q\ngot\n\t%q\noriginal html\n\t%q", test.name, test.from, md, vHTML)
+		}
+	}
+}
@@ -0,0 +1,116 @@
+// Lute - 一款对中文语境优化的 Markdown 引擎，支持 Go 和 JavaScript
+// Copyright (c) 2019-present, b3log.org
+//
+// Lute is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//         http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND, EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT, MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+package test
+
+import (
+	"reflect"
+	"testing"
+
+	"lute"
+	"lute/parse"
+)
+
+var frontMatterTests = []parseTest{
+
+	{"4", "---\nfoo\n", "<hr />\n<p>foo</p>\n"},
+	{"3", "foo\n---\nbar\n---\n", "<h2 id=\"foo\">foo</h2>\n<h2 id=\"bar\">bar</h2>\n"},
+	{"2", "+++\ntitle = \"foo\"\n+++\nbar\n", "<p>bar</p>\n"},
+	{"1", "---\n---\nfoo\n", "<p>foo</p>\n"},
+	{"0", "---\ntitle: foo\n---\n\n# bar\n", "<h1 id=\"bar\">bar</h1>\n"},
+}
+
+func TestFrontMatter(t *testing.T) {
+	luteEngine := lute.New()
+	luteEngine.SetFrontMatter(true)
+
+	for _, test := range frontMatterTests {
+		html := luteEngine.MarkdownStr(test.name, test.from)
+		if test.to != html {
+			t.Fatalf("test case [%s] failed\nexpected\n\t%q\ngot\n\t%q\noriginal markdown text\n\t%q", test.name, test.to, html, test.from)
+		}
+	}
+}
+
+var formatFrontMatterTests = []parseTest{
+
+	{"1", "+++\ntitle = \"foo\"\n+++\nbar\n", "+++\ntitle = \"foo\"\n+++\n\nbar\n"},
+	{"0", "---\ntitle:   foo\ntags: [a, b]\n---\n\n# bar\n", "---\ntitle:   foo\ntags: [a, b]\n---\n\n# bar\n"},
+}
+
+func TestFormatFrontMatter(t *testing.T) {
+	luteEngine := lute.New()
+	luteEngine.SetFrontMatter(true)
+
+	for _, test := range formatFrontMatterTests {
+		formatted := luteEngine.FormatStr(test.name, test.from)
+		if test.to != formatted {
+			t.Fatalf("test case [%s] failed\nexpected\n\t%q\ngot\n\t%q\noriginal markdown text\n\t%q", test.name, test.to, formatted, test.from)
+		}
+	}
+}
+
+func TestFrontMatterData(t *testing.T) {
+	luteEngine := lute.New()
+	luteEngine.SetFrontMatter(true)
+
+	tree := parse.Parse("", []byte("---\ntitle: \"foo\"\ndraft: true\nweight: 2\ntags:\n  - a\n  - b\nparams:\n  ratio: 0.5\n---\nbar\n"), luteEngine.Options)
+	expected := map[string]interface{}{
+		"title":  "foo",
+		"draft":  true,
+		"weight": int64(2),
+		"tags":   []interface{}{"a", "b"},
+		"params": map[string]interface{}{"ratio": 0.5},
+	}
+	if !reflect.DeepEqual(expected, tree.FrontMatter) {
+		t.Fatalf("yaml front matter failed\nexpected\n\t%#v\ngot\n\t%#v", expected, tree.FrontMatter)
+	}
+
+	tree = parse.Parse("", []byte("+++\ntitle = 'foo'\ntags = [\"a\", \"b\"]\n[params]\nratio = 0.5\n+++\nbar\n"), luteEngine.Options)
+	expected = map[string]interface{}{
+		"title":  "foo",
+		"tags":   []interface{}{"a", "b"},
+		"params": map[string]interface{}{"ratio": 0.5},
+	}
+	if !reflect.DeepEqual(expected, tree.FrontMatter) {
+		t.Fatalf("toml front matter failed\nexpected\n\t%#v\ngot\n\t%#v", expected, tree.FrontMatter)
+	}
+
+	tree = parse.Parse("", []byte("foo\n"), luteEngine.Options)
+	if nil != tree.FrontMatter {
+		t.Fatalf("front matter should be nil")
+	}
+}
+
+var vditorFrontMatterTests = []parseTest{
+
+	{"0", "---\ntitle: foo\n---\n\nbar\n", "<div class=\"vditor-wysiwyg__block\" data-type=\"front-matter\" data-block=\"0\" data-marker=\"---\"><pre><code data-type=\"front-matter\">title: foo</code></pre></div><p data-block=\"0\">bar\n</p>"},
+}
+
+func TestVditorFrontMatter(t *testing.T) {
+	luteEngine := lute.New()
+	luteEngine.SetFrontMatter(true)
+
+	for _, test := range vditorFrontMatterTests {
+		vHTML := luteEngine.Md2VditorDOM(test.from)
+		if test.to != vHTML {
+			t.Fatalf("test case [%s] failed\nexpected\n\t%q\ngot\n\t%q\noriginal markdown text\n\t%q", test.name, test.to, vHTML, test.from)
+		}
+		md := luteEngine.VditorDOM2Md(vHTML)
+		if test.from != md {
+			t.Fatalf("test case [%s] failed\nexpected\n\t%q\ngot\n\t%q\noriginal html\n\t%q", test.name, test.from, md, vHTML)
+		}
+		irHTML := luteEngine.Md2VditorIRDOM(test.from)
+		md = luteEngine.VditorIRDOM2Md(irHTML)
+		if test.from != md {
+			t.Fatalf("test case [%s] failed\nexpected\n\t%q\ngot\n\t%q\noriginal html\n\t%q", test.name, test.from, md, irHTML)
+		}
+	}
+}
@@ -0,0 +1,83 @@
+// Lute - 一款对中文语境优化的 Markdown 引擎，支持 Go 和 JavaScript
+// Copyright (c) 2019-present, b3log.org
+//
+// Lute is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//         http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND, EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT, MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+package test
+
+import (
+	"testing"
+
+	"lute"
+)
+
+var gitHubAlertTests = []parseTest{
+
+	{"5", "- > [!TIP]\n  > hi\n", "<ul>\n<li>\n<div class=\"markdown-alert markdown-alert-tip\">\n<p class=\"markdown-alert-title\">Tip</p>\n<p>hi</p>\n</div>\n</li>\n</ul>\n"},
+	{"4", "> text\n> [!NOTE]\n", "<blockquote>\n<p>text<br />\n[!NOTE]</p>\n</blockquote>\n"},
+	{"3", "> [!FOO]\n> x\n", "<blockquote>\n<p>[!FOO]<br />\nx</p>\n</blockquote>\n"},
+	{"2", "> [!NOTE]\n", "<div class=\"markdown-alert markdown-alert-note\">\n<p class=\"markdown-alert-title\">Note</p>\n</div>\n"},
+	{"1", "> [!warning]\n>\n> a\n>\n> b\n", "<div class=\"markdown-alert markdown-alert-warning\">\n<p class=\"markdown-alert-title\">Warning</p>\n<p>a</p>\n<p>b</p>\n</div>\n"},
+	{"0", "> [!NOTE]\n> Useful *info*.\n", "<div class=\"markdown-alert markdown-alert-note\">\n<p class=\"markdown-alert-title\">Note</p>\n<p>Useful <em>info</em>.</p>\n</div>\n"},
+}
+
+func TestGitHubAlert(t *testing.T) {
+	luteEngine := lute.New()
+	luteEngine.SetGitHubAlert(true)
+
+	for _, test := range gitHubAlertTests {
+		html := luteEngine.MarkdownStr(test.name, test.from)
+		if test.to != html {
+			t.Fatalf("test case [%s] failed\nexpected\n\t%q\ngot\n\t%q\noriginal markdown text\n\t%q", test.name, test.to, html, test.from)
+		}
+	}
+}
+
+var formatGitHubAlertTests = []parseTest{
+
+	{"1", "> [!warning]\n>\n> a\n>\n> b\n", "> [!warning]\n> a\n>\n> b\n"},
+	{"0", "> [!NOTE]\n> Useful *info*.\n", "> [!NOTE]\n> Useful *info*.\n"},
+}
+
+func TestFormatGitHubAlert(t *testing.T) {
+	luteEngine := lute.New()
+	luteEngine.SetGitHubAlert(true)
+
+	for _, test := range formatGitHubAlertTests {
+		formatted := luteEngine.FormatStr(test.name, test.from)
+		if test.to != formatted {
+			t.Fatalf("test case [%s] failed\nexpected\n\t%q\ngot\n\t%q\noriginal markdown text\n\t%q", test.name, test.to, formatted, test.from)
+		}
+	}
+}
+
+var vditorGitHubAlertTests = []parseTest{
+
+	{"0", "> [!NOTE]\n> Useful *info*.\n", "<blockquote data-block=\"0\" data-type=\"github-alert\" data-alert-type=\"note\" class=\"vditor-alert vditor-alert--note\"><p data-block=\"0\" data-type=\"github-alert-marker\">[!NOTE]\n</p><p data-block=\"0\">Useful <em data-marker=\"*\">info</em>.\n</p></blockquote>"},
+}
+
+func TestVditorGitHubAlert(t *testing.T) {
+	luteEngine := lute.New()
+	luteEngine.SetGitHubAlert(true)
+
+	for _, test := range vditorGitHubAlertTests {
+		vHTML := luteEngine.Md2VditorDOM(test.from)
+		if test.to != vHTML {
+			t.Fatalf("test case [%s] failed\nexpected\n\t%q\ngot\n\t%q\noriginal markdown text\n\t%q", test.name, test.to, vHTML, test.from)
+		}
+		md := luteEngine.VditorDOM2Md(vHTML)
+		if test.from != md {
+			t.Fatalf("test case [%s] failed\nexpected\n\t%q\ngot\n\t%q\noriginal html\n\t%q", test.name, test.from, md, vHTML)
+		}
+		irHTML := luteEngine.Md2VditorIRDOM(test.from)
+		md = luteEngine.VditorIRDOM2Md(irHTML)
+		if test.from != md {
+			t.Fatalf("test case [%s] failed\nexpected\n\t%q\ngot\n\t%q\noriginal html\n\t%q", test.name, test.from, md, irHTML)
+		}
+	}
+}
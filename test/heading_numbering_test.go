@@ -0,0 +1,104 @@
+// Lute - 一款对中文语境优化的 Markdown 引擎，支持 Go 和 JavaScript
+// Copyright (c) 2019-present, b3log.org
+//
+// Lute is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//         http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND, EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT, MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+package test
+
+import (
+	"testing"
+
+	"lute"
+)
+
+var headingNumberingTests = []parseTest{
+
+	{"6", "[toc]\n\n# A\n\n### C\n\n## D\n", "<div class=\"vditor-toc\"><span class=\"toc-h1\"><a class=\"toc-a\" href=\"#A\"><span class=\"heading-number\">1</span> A</a></span><br>&emsp;&emsp;&emsp;&emsp;<span class=\"toc-h3\"><a class=\"toc-a\" href=\"#C\"><span class=\"heading-number\">1.1</span> C</a></span><br>&emsp;&emsp;<span class=\"toc-h2\"><a class=\"toc-a\" href=\"#D\"><span class=\"heading-number\">1.2</span> D</a></span><br></div>\n<h1 id=\"A\"><span class=\"heading-number\">1</span> A</h1>\n<h3 id=\"C\"><span class=\"heading-number\">1.1</span> C</h3>\n<h2 id=\"D\"><span class=\"heading-number\">1.2</span> D</h2>\n"},
+	{"5", "# Preface {-}\n\n## Thanks\n", "<h1 id=\"Preface\" class=\"unnumbered\">Preface</h1>\n<h2 id=\"Thanks\"><span class=\"heading-number\">1</span> Thanks</h2>\n"},
+	{"4", "# A\n\n### B\n", "<h1 id=\"A\"><span class=\"heading-number\">1</span> A</h1>\n<h3 id=\"B\"><span class=\"heading-number\">1.1</span> B</h3>\n"},
+	{"3", "> ## Quoted\n\n## Next\n", "<blockquote>\n<h2 id=\"Quoted\"><span class=\"heading-number\">1</span> Quoted</h2>\n</blockquote>\n<h2 id=\"Next\"><span class=\"heading-number\">2</span> Next</h2>\n"},
+	{"2", "## A\n\n## B {-}\n\n### C\n", "<h2 id=\"A\"><span class=\"heading-number\">1</span> A</h2>\n<h2 id=\"B\" class=\"unnumbered\">B</h2>\n<h3 id=\"C\"><span class=\"heading-number\">1.1</span> C</h3>\n"},
+	{"1", "# Intro\n\n## Scope\n\n# Spec\n\nSetext\n---\n", "<h1 id=\"Intro\"><span class=\"heading-number\">1</span> Intro</h1>\n<h2 id=\"Scope\"><span class=\"heading-number\">1.1</span> Scope</h2>\n<h1 id=\"Spec\"><span class=\"heading-number\">2</span> Spec</h1>\n<h2 id=\"Setext\"><span class=\"heading-number\">2.1</span> Setext</h2>\n"},
+	{"0", "[toc]\n\n# Intro\n\n## Terms {-}\n\n## Scope\n", "<div class=\"vditor-toc\"><span class=\"toc-h1\"><a class=\"toc-a\" href=\"#Intro\"><span class=\"heading-number\">1</span> Intro</a></span><br>&emsp;&emsp;<span class=\"toc-h2\"><a class=\"toc-a\" href=\"#Terms\">Terms</a></span><br>&emsp;&emsp;<span class=\"toc-h2\"><a class=\"toc-a\" href=\"#Scope\"><span class=\"heading-number\">1.1</span> Scope</a></span><br></div>\n<h1 id=\"Intro\"><span class=\"heading-number\">1</span> Intro</h1>\n<h2 id=\"Terms\" class=\"unnumbered\">Terms</h2>\n<h2 id=\"Scope\"><span class=\"heading-number\">1.1</span> Scope</h2>\n"},
+}
+
+func TestHeadingNumbering(t *testing.T) {
+	luteEngine := lute.New()
+	luteEngine.SetHeadingNumbering(true)
+	luteEngine.SetToC(true)
+
+	for _, test := range headingNumberingTests {
+		html := luteEngine.MarkdownStr(test.name, test.from)
+		if test.to != html {
+			t.Fatalf("test case [%s] failed\nexpected\n\t%q\ngot\n\t%q\noriginal markdown text\n\t%q", test.name, test.to, html, test.from)
+		}
+	}
+}
+
+var headingUnnumberedMarkerTests = []parseTest{
+
+	{"0", "# Foo {-}\n", "<h1 id=\"-\">Foo</h1>\n"},
+}
+
+func TestHeadingUnnumberedMarkerWithoutNumbering(t *testing.T) {
+	luteEngine := lute.New()
+
+	for _, test := range headingUnnumberedMarkerTests {
+		html := luteEngine.MarkdownStr(test.name, test.from)
+		if test.to != html {
+			t.Fatalf("test case [%s] failed\nexpected\n\t%q\ngot\n\t%q\noriginal markdown text\n\t%q", test.name, test.to, html, test.from)
+		}
+	}
+}
+
+var headingNumberingLevelTests = []parseTest{
+
+	{"0", "# Title\n\n## Intro\n\n### Scope\n\n#### Detail\n\n## Spec\n", "<h1 id=\"Title\">Title</h1>\n<h2 id=\"Intro\"><span class=\"heading-number\">1</span> Intro</h2>\n<h3 id=\"Scope\"><span class=\"heading-number\">1-1</span> Scope</h3>\n<h4 id=\"Detail\">Detail</h4>\n<h2 id=\"Spec\"><span class=\"heading-number\">2</span> Spec</h2>\n"},
+}
+
+func TestHeadingNumberingLevel(t *testing.T) {
+	luteEngine := lute.New()
+	luteEngine.SetHeadingNumbering(true)
+	luteEngine.SetHeadingNumberingStartLevel(2)
+	luteEngine.SetHeadingNumberingMaxDepth(2)
+	luteEngine.SetHeadingNumberingSeparator("-")
+
+	for _, test := range headingNumberingLevelTests {
+		html := luteEngine.MarkdownStr(test.name, test.from)
+		if test.to != html {
+			t.Fatalf("test case [%s] failed\nexpected\n\t%q\ngot\n\t%q\noriginal markdown text\n\t%q", test.name, test.to, html, test.from)
+		}
+	}
+}
+
+var formatHeadingNumberTests = []parseTest{
+
+	{"4", "# 2020 Review\n\n## 3 Ways to win\n", "# 1 2020 Review\n\n## 1.1 3 Ways to win\n"},
+	{"3", "# 2 A\n\n## 2.1 B\n\n# 1 C\n", "# 1 A\n\n## 1.1 B\n\n# 2 C\n"},
+	{"2", "# A {- .x}\n\n## B {.unnumbered}\n", "# A {- .x}\n\n## B {-}\n"},
+	{"1", "# 1 Intro\n\n## 1.1 Scope\n", "# 1 Intro\n\n## 1.1 Scope\n"},
+	{"0", "# Intro\n\n## Scope\n\n## Terms {-}\n\n# Spec\n\nSetext\n---\n", "# 1 Intro\n\n## 1.1 Scope\n\n## Terms {-}\n\n# 2 Spec\n\n2.1 Setext\n----------\n"},
+}
+
+func TestFormatHeadingNumber(t *testing.T) {
+	luteEngine := lute.New()
+	luteEngine.SetHeadingNumbering(true)
+	luteEngine.SetFormatHeadingNumber(true)
+	luteEngine.SetAttributeList(true)
+
+	for _, test := range formatHeadingNumberTests {
+		formatted := luteEngine.FormatStr(test.name, test.from)
+		if test.to != formatted {
+			t.Fatalf("test case [%s] failed\nexpected\n\t%q\ngot\n\t%q\noriginal markdown text\n\t%q", test.name, test.to, formatted, test.from)
+		}
+		// 再次格式化时不会重复写入编号
+		if formatted = luteEngine.FormatStr(test.name, formatted); test.to != formatted {
+			t.Fatalf("test case [%s] failed\nexpected\n\t%q\ngot\n\t%q\noriginal markdown text\n\t%q", test.name, test.to, formatted, test.to)
+		}
+	}
+}
@@ -0,0 +1,116 @@
+// Lute - 一款对中文语境优化的 Markdown 引擎，支持 Go 和 JavaScript
+// Copyright (c) 2019-present, b3log.org
+//
+// Lute is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//         http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND, EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT, MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+package test
+
+import (
+	"testing"
+
+	"lute"
+)
+
+var inlineFootnotesTests = []parseTest{
+
+	{"5", "a^[b `]` c] d ``x]`` ^[e\n", "<p>a<sup class=\"footnotes-ref\" id=\"footnotes-ref-1\"><a href=\"#footnotes-def-1\">1</a></sup> d <code>x]</code> ^[e</p>\n<div class=\"footnotes-defs-div\"><hr class=\"footnotes-defs-hr\" />\n<ol class=\"footnotes-defs-ol\"><li id=\"footnotes-def-1\"><p>b <code>]</code> c <a href=\"#footnotes-ref-1\" class=\"vditor-footnotes__goto-ref\">↩</a></p>\n</li>\n</ol></div>"},
+	{"4", "x ^[] ^[unclosed 2^3\n", "<p>x ^[] ^[unclosed 2^3</p>\n"},
+	{"3", "a^[b [c] d] e^[f\\]g]\n", "<p>a<sup class=\"footnotes-ref\" id=\"footnotes-ref-1\"><a href=\"#footnotes-def-1\">1</a></sup> e<sup class=\"footnotes-ref\" id=\"footnotes-ref-2\"><a href=\"#footnotes-def-2\">2</a></sup></p>\n<div class=\"footnotes-defs-div\"><hr class=\"footnotes-defs-hr\" />\n<ol class=\"footnotes-defs-ol\"><li id=\"footnotes-def-1\"><p>b [c] d <a href=\"#footnotes-ref-1\" class=\"vditor-footnotes__goto-ref\">↩</a></p>\n</li>\n<li id=\"footnotes-def-2\"><p>f]g <a href=\"#footnotes-ref-2\" class=\"vditor-footnotes__goto-ref\">↩</a></p>\n</li>\n</ol></div>"},
+	{"2", "Text^[a *note*] and[^1].\n\n[^1]: def\n", "<p>Text<sup class=\"footnotes-ref\" id=\"footnotes-ref-2\"><a href=\"#footnotes-def-2\">2</a></sup> and<sup class=\"footnotes-ref\" id=\"footnotes-ref-1\"><a href=\"#footnotes-def-1\">1</a></sup>.</p>\n<div class=\"footnotes-defs-div\"><hr class=\"footnotes-defs-hr\" />\n<ol class=\"footnotes-defs-ol\"><li id=\"footnotes-def-1\"><p>def <a href=\"#footnotes-ref-1\" class=\"vditor-footnotes__goto-ref\">↩</a></p>\n</li>\n<li id=\"footnotes-def-2\"><p>a <em>note</em> <a href=\"#footnotes-ref-2\" class=\"vditor-footnotes__goto-ref\">↩</a></p>\n</li>\n</ol></div>"},
+	{"1", "[^2]: def\n\nfoo^[bar][^2]\n", "<p>foo<sup class=\"footnotes-ref\" id=\"footnotes-ref-2\"><a href=\"#footnotes-def-2\">2</a></sup><sup class=\"footnotes-ref\" id=\"footnotes-ref-1\"><a href=\"#footnotes-def-1\">1</a></sup></p>\n<div class=\"footnotes-defs-div\"><hr class=\"footnotes-defs-hr\" />\n<ol class=\"footnotes-defs-ol\"><li id=\"footnotes-def-1\"><p>def <a href=\"#footnotes-ref-1\" class=\"vditor-footnotes__goto-ref\">↩</a></p>\n</li>\n<li id=\"footnotes-def-2\"><p>bar <a href=\"#footnotes-ref-2\" class=\"vditor-footnotes__goto-ref\">↩</a></p>\n</li>\n</ol></div>"},
+	{"0", "foo^[bar]\n", "<p>foo<sup class=\"footnotes-ref\" id=\"footnotes-ref-1\"><a href=\"#footnotes-def-1\">1</a></sup></p>\n<div class=\"footnotes-defs-div\"><hr class=\"footnotes-defs-hr\" />\n<ol class=\"footnotes-defs-ol\"><li id=\"footnotes-def-1\"><p>bar <a href=\"#footnotes-ref-1\" class=\"vditor-footnotes__goto-ref\">↩</a></p>\n</li>\n</ol></div>"},
+}
+
+func TestInlineFootnotes(t *testing.T) {
+	luteEngine := lute.New()
+	luteEngine.SetInlineFootnotes(true)
+
+	for _, test := range inlineFootnotesTests {
+		html := luteEngine.MarkdownStr(test.name, test.from)
+		if test.to != html {
+			t.Fatalf("test case [%s] failed\nexpected\n\t%q\ngot\n\t%q\noriginal markdown text\n\t%q", test.name, test.to, html, test.from)
+		}
+	}
+}
+
+var formatInlineFootnotesTests = []parseTest{
+
+	{"1", "a^[b [c] d] e^[f\\]g]\n", "a^[b [c] d] e^[f\\]g]\n"},
+	{"0", "Text^[a  *note*] and[^1].\n\n[^1]: def\n", "Text^[a  *note*] and[^1].\n\n[^1]: def\n"},
+}
+
+func TestFormatInlineFootnotes(t *testing.T) {
+	luteEngine := lute.New()
+	luteEngine.SetInlineFootnotes(true)
+
+	for _, test := range formatInlineFootnotesTests {
+		formatted := luteEngine.FormatStr(test.name, test.from)
+		if test.to != formatted {
+			t.Fatalf("test case [%s] failed\nexpected\n\t%q\ngot\n\t%q\noriginal markdown text\n\t%q", test.name, test.to, formatted, test.from)
+		}
+	}
+}
+
+var formatHoistInlineFootnotesTests = []parseTest{
+
+	{"1", "a^[b [c] d] e^[f\\]g]\n", "a[^1] e[^2]\n\n[^1]: b [c] d\n[^2]: f\\]g\n"},
+	{"0", "Text^[a *note*] and[^1].\n\n[^1]: def\n", "Text[^2] and[^1].\n\n[^1]: def\n\n[^2]: a *note*\n"},
+}
+
+func TestFormatHoistInlineFootnotes(t *testing.T) {
+	luteEngine := lute.New()
+	luteEngine.SetInlineFootnotes(true)
+	luteEngine.SetHoistInlineFootnotes(true)
+
+	for _, test := range formatHoistInlineFootnotesTests {
+		formatted := luteEngine.FormatStr(test.name, test.from)
+		if test.to != formatted {
+			t.Fatalf("test case [%s] failed\nexpected\n\t%q\ngot\n\t%q\noriginal markdown text\n\t%q", test.name, test.to, formatted, test.from)
+		}
+	}
+}
+
+var md2VditorInlineFootnotesTests = []parseTest{
+
+	{"0", "Text^[a *note*] and[^1].\n\n[^1]: def\n", "<p data-block=\"0\">Text<span class=\"vditor-wysiwyg__block\" data-type=\"source-inline\"><code data-type=\"source-inline\">\u200b^[a *note*]</code><span class=\"vditor-wysiwyg__preview\" data-render=\"2\"><sup class=\"footnotes-ref\" id=\"footnotes-ref-2\"><a href=\"#footnotes-def-2\">2</a></sup></span></span>\u200b and<sup data-type=\"footnotes-ref\" data-footnotes-label=\"^1\">1</sup>\u200b.\n</p><div data-block=\"0\" data-type=\"footnotes-block\"><ol data-type=\"footnotes-defs-ol\"><li data-type=\"footnotes-li\" data-marker=\"^1\"><p data-block=\"0\">def\n</p></li></ol></div>"},
+}
+
+func TestMd2VditorInlineFootnotes(t *testing.T) {
+	luteEngine := lute.New()
+	luteEngine.SetInlineFootnotes(true)
+
+	for _, test := range md2VditorInlineFootnotesTests {
+		vHTML := luteEngine.Md2VditorDOM(test.from)
+		if test.to != vHTML {
+			t.Fatalf("test case [%s] failed\nexpected\n\t%q\ngot\n\t%q\noriginal markdown text\n\t%q", test.name, test.to, vHTML, test.from)
+		}
+		if md := luteEngine.VditorDOM2Md(vHTML); test.from != md {
+			t.Fatalf("test case [%s] failed\nexpected\n\t%q\ngot\n\t%q\noriginal html\n\t%q", test.name, test.from, md, vHTML)
+		}
+	}
+}
+
+var md2VditorIRInlineFootnotesTests = []parseTest{
+
+	{"0", "Text^[a *note*] and[^1].\n\n[^1]: def\n", "<p data-block=\"0\">Text<span data-type=\"inline-node\" class=\"vditor-ir__node\"><code class=\"vditor-ir__marker\" data-type=\"source-inline\">^[a *note*]</code></span> and<sup data-type=\"footnotes-ref\" class=\"vditor-ir__node\"><span class=\"vditor-ir__marker vditor-ir__marker--bracket\">[</span><span class=\"vditor-ir__marker vditor-ir__marker--link\">^1</span><span class=\"vditor-ir__marker--hide\" data-render=\"1\">1</span><span class=\"vditor-ir__marker vditor-ir__marker--bracket\">]</span></sup>\u200b.\n</p><div data-block=\"0\" data-type=\"footnotes-block\"><div data-type=\"footnotes-def\"><p data-block=\"0\">[^1]: def\n</p></div></div>"},
+}
+
+func TestMd2VditorIRInlineFootnotes(t *testing.T) {
+	luteEngine := lute.New()
+	luteEngine.SetInlineFootnotes(true)
+
+	for _, test := range md2VditorIRInlineFootnotesTests {
+		vHTML := luteEngine.Md2VditorIRDOM(test.from)
+		if test.to != vHTML {
+			t.Fatalf("test case [%s] failed\nexpected\n\t%q\ngot\n\t%q\noriginal markdown text\n\t%q", test.name, test.to, vHTML, test.from)
+		}
+		if md := luteEngine.VditorIRDOM2Md(vHTML); test.from != md {
+			t.Fatalf("test case [%s] failed\nexpected\n\t%q\ngot\n\t%q\noriginal html\n\t%q", test.name, test.from, md, vHTML)
+		}
+	}
+}
@@ -0,0 +1,147 @@
+// Lute - 一款对中文语境优化的 Markdown 引擎，支持 Go 和 JavaScript
+// Copyright (c) 2019-present, b3log.org
+//
+// Lute is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//         http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND, EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT, MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+package test
+
+import (
+	"testing"
+
+	"lute"
+)
+
+var markSupSubTests = []parseTest{
+
+	{"12", "==www.b3log.org== and 中文https://b3log.org/a==b==c\n", "<p><mark><a href=\"http://www.b3log.org\">www.b3log.org</a></mark> and 中文 <a href=\"https://b3log.org/a==b==c\">https://b3log.org/a==b==c</a></p>\n"},
+	{"11", "x://y==z== and see://www==a==\n", "<p>x://y<mark>z</mark> and see://www<mark>a</mark></p>\n"},
+	{"10", "see https://example.com/?a==b==c and ==d==\n", "<p>see <a href=\"https://example.com/?a==b==c\">https://example.com/?a==b==c</a> and <mark>d</mark></p>\n"},
+	{"9", "x^2 and y^3 and H~2 and O~\n", "<p>x^2 and y^3 and H~2 and O~</p>\n"},
+	{"8", "foo[^1]\n\n[^1]: x^2^\n", "<p>foo<sup class=\"footnotes-ref\" id=\"footnotes-ref-1\"><a href=\"#footnotes-def-1\">1</a></sup></p>\n<div class=\"footnotes-defs-div\"><hr class=\"footnotes-defs-hr\" />\n<ol class=\"footnotes-defs-ol\"><li id=\"footnotes-def-1\"><p>x<sup>2</sup> <a href=\"#footnotes-ref-1\" class=\"vditor-footnotes__goto-ref\">↩</a></p>\n</li>\n</ol></div>"},
+	{"7", "中==文==字\n", "<p>中<mark>文</mark>字</p>\n"},
+	{"6", "==*em*==\n", "<p><mark><em>em</em></mark></p>\n"},
+	{"5", "^^x^^\n", "<p>^^x^^</p>\n"},
+	{"4", "===x===\n", "<p>===x===</p>\n"},
+	{"3", "a = b == c\n", "<p>a = b == c</p>\n"},
+	{"2", "~~del~~\n", "<p><del>del</del></p>\n"},
+	{"1", "H~2~O x^2^\n", "<p>H<sub>2</sub>O x<sup>2</sup></p>\n"},
+	{"0", "==highlighted==\n", "<p><mark>highlighted</mark></p>\n"},
+}
+
+func TestMarkSupSub(t *testing.T) {
+	luteEngine := lute.New()
+	luteEngine.SetMark(true)
+	luteEngine.SetSup(true)
+	luteEngine.SetSub(true)
+
+	for _, test := range markSupSubTests {
+		html := luteEngine.MarkdownStr(test.name, test.from)
+		if test.to != html {
+			t.Fatalf("test case [%s] failed\nexpected\n\t%q\ngot\n\t%q\noriginal markdown text\n\t%q", test.name, test.to, html, test.from)
+		}
+	}
+}
+
+var markSupSubDisabledTests = []parseTest{
+
+	{"0", "==a== ~b~ ^c^\n", "<p>==a== <del>b</del> ^c^</p>\n"},
+}
+
+func TestMarkSupSubDisabled(t *testing.T) {
+	luteEngine := lute.New()
+
+	for _, test := range markSupSubDisabledTests {
+		html := luteEngine.MarkdownStr(test.name, test.from)
+		if test.to != html {
+			t.Fatalf("test case [%s] failed\nexpected\n\t%q\ngot\n\t%q\noriginal markdown text\n\t%q", test.name, test.to, html, test.from)
+		}
+	}
+}
+
+var formatMarkSupSubTests = []parseTest{
+
+	{"0", "==hi== H~2~O x^2^ ~~del~~\n", "==hi== H~2~O x^2^ ~~del~~\n"},
+}
+
+func TestFormatMarkSupSub(t *testing.T) {
+	luteEngine := lute.New()
+	luteEngine.SetMark(true)
+	luteEngine.SetSup(true)
+	luteEngine.SetSub(true)
+
+	for _, test := range formatMarkSupSubTests {
+		formatted := luteEngine.FormatStr(test.name, test.from)
+		if test.to != formatted {
+			t.Fatalf("test case [%s] failed\nexpected\n\t%q\ngot\n\t%q\noriginal markdown text\n\t%q", test.name, test.to, formatted, test.from)
+		}
+	}
+}
+
+var html2MdMarkSupSubTests = []parseTest{
+
+	{"1", "<p>H<sub>2</sub>O x<sup>2</sup> <del>d</del></p>", "H~2~O x^2^~~d~~\n"},
+	{"0", "<p><mark>a</mark></p>", "==a==\n"},
+}
+
+func TestHTML2MdMarkSupSub(t *testing.T) {
+	luteEngine := lute.New()
+	luteEngine.SetMark(true)
+	luteEngine.SetSup(true)
+	luteEngine.SetSub(true)
+
+	for _, test := range html2MdMarkSupSubTests {
+		md := luteEngine.HTML2Md(test.from)
+		if test.to != md {
+			t.Fatalf("test case [%s] failed\nexpected\n\t%q\ngot\n\t%q\noriginal html\n\t%q", test.name, test.to, md, test.from)
+		}
+	}
+}
+
+var md2VditorMarkSupSubTests = []parseTest{
+
+	{"0", "==hi== H~2~O x^2^\n", "<p data-block=\"0\"><mark data-marker=\"==\">hi</mark> H<sub data-marker=\"~\">2</sub>O x<sup data-marker=\"^\">2</sup>\n</p>"},
+}
+
+func TestMd2VditorMarkSupSub(t *testing.T) {
+	luteEngine := lute.New()
+	luteEngine.SetMark(true)
+	luteEngine.SetSup(true)
+	luteEngine.SetSub(true)
+
+	for _, test := range md2VditorMarkSupSubTests {
+		vHTML := luteEngine.Md2VditorDOM(test.from)
+		if test.to != vHTML {
+			t.Fatalf("test case [%s] failed\nexpected\n\t%q\ngot\n\t%q\noriginal markdown text\n\t%q", test.name, test.to, vHTML, test.from)
+		}
+		if md := luteEngine.VditorDOM2Md(vHTML); test.from != md {
+			t.Fatalf("test case [%s] failed\nexpected\n\t%q\ngot\n\t%q\noriginal html\n\t%q", test.name, test.from, md, vHTML)
+		}
+	}
+}
+
+var md2VditorIRMarkSupSubTests = []parseTest{
+
+	{"0", "==hi== x^2^\n", "<p data-block=\"0\"><span data-type=\"inline-node\" class=\"vditor-ir__node\"><span class=\"vditor-ir__marker\">==</span><mark data-newline=\"1\">hi</mark><span class=\"vditor-ir__marker\">==</span></span> x<span data-type=\"inline-node\" class=\"vditor-ir__node\"><span class=\"vditor-ir__marker\">^</span><sup data-newline=\"1\">2</sup><span class=\"vditor-ir__marker\">^</span></span>\n</p>"},
+}
+
+func TestMd2VditorIRMarkSupSub(t *testing.T) {
+	luteEngine := lute.New()
+	luteEngine.SetMark(true)
+	luteEngine.SetSup(true)
+	luteEngine.SetSub(true)
+
+	for _, test := range md2VditorIRMarkSupSubTests {
+		vHTML := luteEngine.Md2VditorIRDOM(test.from)
+		if test.to != vHTML {
+			t.Fatalf("test case [%s] failed\nexpected\n\t%q\ngot\n\t%q\noriginal markdown text\n\t%q", test.name, test.to, vHTML, test.from)
+		}
+		if md := luteEngine.VditorIRDOM2Md(vHTML); test.from != md {
+			t.Fatalf("test case [%s] failed\nexpected\n\t%q\ngot\n\t%q\noriginal html\n\t%q", test.name, test.from, md, vHTML)
+		}
+	}
+}
@@ -0,0 +1,183 @@
+// Lute - 一款对中文语境优化的 Markdown 引擎，支持 Go 和 JavaScript
+// Copyright (c) 2019-present, b3log.org
+//
+// Lute is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//         http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND, EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT, MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+package test
+
+import (
+	"strings"
+	"testing"
+
+	"lute"
+	"lute/parse"
+)
+
+var referenceResolver = parse.ReferenceResolverFunc(func(typ, repo, id string) (url string, exists bool) {
+	if "nobody" == id || "404" == id {
+		return "", false
+	}
+	if "" == repo {
+		repo = "b3log/lute"
+	}
+	switch typ {
+	case parse.ReferenceMention:
+		return "/users/" + id, true
+	case parse.ReferenceIssue:
+		return "/" + repo + "/issues/" + id, true
+	default:
+		return "/" + repo + "/commit/" + id, true
+	}
+})
+
+var referenceTests = []parseTest{
+
+	{"5", "`@code` [@x](/y) https://x.com/@u/#3\n", "<p><code>@code</code> <a href=\"/y\">@x</a> <a href=\"https://x.com/@u/#3\">https://x.com/@u/#3</a></p>\n"},
+	{"4", "a1b2c3d 和 0123456789abcdef0123456789abcdef01234567，deadbeef 1234567 a1b2c3 a1b2c3dx\n", "<p><a href=\"/b3log/lute/commit/a1b2c3d\" class=\"commit\">a1b2c3d</a> 和 <a href=\"/b3log/lute/commit/0123456789abcdef0123456789abcdef01234567\" class=\"commit\">0123456789abcdef0123456789abcdef01234567</a>，deadbeef 1234567 a1b2c3 a1b2c3dx</p>\n"},
+	{"3", "见 b3log/lute#45 和 88250/vditor#1\n", "<p>见 <a href=\"/b3log/lute/issues/45\" class=\"issue\">b3log/lute#45</a> 和 <a href=\"/88250/vditor/issues/1\" class=\"issue\">88250/vditor#1</a></p>\n"},
+	{"2", "修复了#12，x#1 #12a #404\n", "<p>修复了<a href=\"/b3log/lute/issues/12\" class=\"issue\">#12</a>，x#1 #12a #404</p>\n"},
+	{"1", "a@b.com @-x @nobody\n", "<p><a href=\"mailto:a@b.com\">a@b.com</a> @-x @nobody</p>\n"},
+	{"0", "Thanks @alice and @bob.\n", "<p>Thanks <a href=\"/users/alice\" class=\"mention\">@alice</a> and <a href=\"/users/bob\" class=\"mention\">@bob</a>.</p>\n"},
+}
+
+func TestReference(t *testing.T) {
+	luteEngine := lute.New()
+	luteEngine.SetMention(true)
+	luteEngine.SetIssueRef(true)
+	luteEngine.SetRepoIssueRef(true)
+	luteEngine.SetCommitRef(true)
+	luteEngine.SetReferenceResolver(referenceResolver)
+
+	for _, test := range referenceTests {
+		html := luteEngine.MarkdownStr(test.name, test.from)
+		if test.to != html {
+			t.Fatalf("test case [%s] failed\nexpected\n\t%q\ngot\n\t%q\noriginal markdown text\n\t%q", test.name, test.to, html, test.from)
+		}
+	}
+}
+
+var referenceOptionTests = []parseTest{
+
+	{"1", "@alice #12 b3log/lute#45 a1b2c3d\n", "<p>@alice #12 b3log/lute#45 a1b2c3d</p>\n"},
+	{"0", "@alice #12 b3log/lute#45 a1b2c3d\n", "<p><a href=\"/users/alice\" class=\"mention\">@alice</a> #12 b3log/lute#45 a1b2c3d</p>\n"},
+}
+
+func TestReferenceOption(t *testing.T) {
+	luteEngine := lute.New()
+	luteEngine.SetMention(true)
+	luteEngine.SetReferenceResolver(referenceResolver)
+	test := referenceOptionTests[1]
+	if html := luteEngine.MarkdownStr(test.name, test.from); test.to != html {
+		t.Fatalf("test case [%s] failed\nexpected\n\t%q\ngot\n\t%q\noriginal markdown text\n\t%q", test.name, test.to, html, test.from)
+	}
+
+	// 未设置解析器时引用渲染为普通文本
+	luteEngine = lute.New()
+	luteEngine.SetMention(true)
+	luteEngine.SetIssueRef(true)
+	luteEngine.SetRepoIssueRef(true)
+	luteEngine.SetCommitRef(true)
+	test = referenceOptionTests[0]
+	if html := luteEngine.MarkdownStr(test.name, test.from); test.to != html {
+		t.Fatalf("test case [%s] failed\nexpected\n\t%q\ngot\n\t%q\noriginal markdown text\n\t%q", test.name, test.to, html, test.from)
+	}
+}
+
+var formatReferenceTests = []parseTest{
+
+	{"0", "Thanks @alice, 见 b3log/lute#45 和 #12 a1b2c3d\n", "Thanks @alice, 见 b3log/lute#45 和 #12 a1b2c3d\n"},
+}
+
+func TestFormatReference(t *testing.T) {
+	luteEngine := lute.New()
+	luteEngine.SetMention(true)
+	luteEngine.SetIssueRef(true)
+	luteEngine.SetRepoIssueRef(true)
+	luteEngine.SetCommitRef(true)
+	luteEngine.SetReferenceResolver(referenceResolver)
+
+	for _, test := range formatReferenceTests {
+		formatted := luteEngine.FormatStr(test.name, test.from)
+		if test.to != formatted {
+			t.Fatalf("test case [%s] failed\nexpected\n\t%q\ngot\n\t%q\noriginal markdown text\n\t%q", test.name, test.to, formatted, test.from)
+		}
+	}
+}
+
+var md2VditorReferenceTests = []parseTest{
+
+	{"0", "Thanks @alice 修复了#12\n", "<p data-block=\"0\">Thanks <span class=\"vditor-wysiwyg__block\" data-type=\"source-inline\"><code data-type=\"source-inline\">\u200b@alice</code><span class=\"vditor-wysiwyg__preview\" data-render=\"2\"><a href=\"/users/alice\" class=\"mention\">@alice</a></span></span>\u200b 修复了<span class=\"vditor-wysiwyg__block\" data-type=\"source-inline\"><code data-type=\"source-inline\">\u200b#12</code><span class=\"vditor-wysiwyg__preview\" data-render=\"2\"><a href=\"/b3log/lute/issues/12\" class=\"issue\">#12</a></span></span>\u200b\n</p>"},
+}
+
+func TestMd2VditorReference(t *testing.T) {
+	luteEngine := lute.New()
+	luteEngine.SetMention(true)
+	luteEngine.SetIssueRef(true)
+	luteEngine.SetReferenceResolver(referenceResolver)
+
+	for _, test := range md2VditorReferenceTests {
+		vHTML := luteEngine.Md2VditorDOM(test.from)
+		if test.to != vHTML {
+			t.Fatalf("test case [%s] failed\nexpected\n\t%q\ngot\n\t%q\noriginal markdown text\n\t%q", test.name, test.to, vHTML, test.from)
+		}
+		if md := luteEngine.VditorDOM2Md(vHTML); test.from != md {
+			t.Fatalf("test case [%s] failed\nexpected\n\t%q\ngot\n\t%q\noriginal html\n\t%q", test.name, test.from, md, vHTML)
+		}
+	}
+}
+
+var md2VditorIRReferenceTests = []parseTest{
+
+	{"0", "Thanks @alice 修复了#12\n", "<p data-block=\"0\">Thanks <span data-type=\"inline-node\" class=\"vditor-ir__node\"><code class=\"vditor-ir__marker\" data-type=\"source-inline\">@alice</code></span> 修复了<span data-type=\"inline-node\" class=\"vditor-ir__node\"><code class=\"vditor-ir__marker\" data-type=\"source-inline\">#12</code></span>\n</p>"},
+}
+
+func TestMd2VditorIRReference(t *testing.T) {
+	luteEngine := lute.New()
+	luteEngine.SetMention(true)
+	luteEngine.SetIssueRef(true)
+	luteEngine.SetReferenceResolver(referenceResolver)
+
+	for _, test := range md2VditorIRReferenceTests {
+		vHTML := luteEngine.Md2VditorIRDOM(test.from)
+		if test.to != vHTML {
+			t.Fatalf("test case [%s] failed\nexpected\n\t%q\ngot\n\t%q\noriginal markdown text\n\t%q", test.name, test.to, vHTML, test.from)
+		}
+		if md := luteEngine.VditorIRDOM2Md(vHTML); test.from != md {
+			t.Fatalf("test case [%s] failed\nexpected\n\t%q\ngot\n\t%q\noriginal html\n\t%q", test.name, test.from, md, vHTML)
+		}
+	}
+}
+
+func TestTreeMentions(t *testing.T) {
+	luteEngine := lute.New()
+	luteEngine.SetMention(true)
+
+	markdown := []byte("@bob @alice\n\n- @bob 和 @nobody\n")
+	tree := parse.Parse("", markdown, luteEngine.Options)
+	if expected, got := "bob,alice,nobody", strings.Join(tree.Mentions(), ","); expected != got {
+		t.Fatalf("mentions failed\nexpected\n\t%q\ngot\n\t%q", expected, got)
+	}
+
+	luteEngine.SetReferenceResolver(referenceResolver)
+	tree = parse.Parse("", markdown, luteEngine.Options)
+	if expected, got := "bob,alice", strings.Join(tree.Mentions(), ","); expected != got {
+		t.Fatalf("mentions failed\nexpected\n\t%q\ngot\n\t%q", expected, got)
+	}
+}
+
+func TestReferenceSourcePos(t *testing.T) {
+	luteEngine := lute.New()
+	luteEngine.SetMention(true)
+
+	md := "Hi @bob and @amy end\n"
+	expected := "NodeParagraph 1:1-1:21 [0,20) Hi @bob and @amy end\nNodeText 1:1-1:4 [0,3) Hi \nNodeReference 1:4-1:8 [3,7) @bob\nNodeText 1:8-1:13 [7,12)  and \nNodeReference 1:13-1:17 [12,16) @amy\nNodeText 1:17-1:21 [16,20)  end\n"
+	pos := dumpSourcePos(parse.Parse("", []byte(md), luteEngine.Options).Root, md)
+	if expected != pos {
+		t.Fatalf("reference source position failed\nexpected\n\t%q\ngot\n\t%q", expected, pos)
+	}
+}
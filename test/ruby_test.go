@@ -0,0 +1,136 @@
+// Lute - 一款对中文语境优化的 Markdown 引擎，支持 Go 和 JavaScript
+// Copyright (c) 2019-present, b3log.org
+//
+// Lute is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//         http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND, EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT, MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+package test
+
+import (
+	"testing"
+
+	"lute"
+)
+
+var rubyTests = []parseTest{
+
+	{"7", "| a |\n| - |\n| {漢字\\|かんじ} |\n", "<table>\n<thead>\n<tr>\n<th>a</th>\n</tr>\n</thead>\n<tbody>\n<tr>\n<td><ruby><rb>漢字</rb><rt>かんじ</rt></ruby></td>\n</tr>\n</tbody>\n</table>\n"},
+	{"6", "set {x | x > 0} and {x |y} {x| y}\n", "<p>set {x | x &gt; 0} and {x |y} {x| y}</p>\n"},
+	{"5", "*强调*{.cls} {#id} {a|b|c}\n", "<p><em>强调</em>{.cls} {#id} {a|b|c}</p>\n"},
+	{"4", "`{汉字|hàn}` {<b>|x}\n", "<p><code>{汉字|hàn}</code> <ruby><rb>&lt;b&gt;</rb><rt>x</rt></ruby></p>\n"},
+	{"3", "学习{中文English|zhōng wén}很有趣\n", "<p>学习<ruby><rb>中文English</rb><rt>zhōng wén</rt></ruby>很有趣</p>\n"},
+	{"2", "{漢字|かん|じ}を書く\n", "<p><ruby><rb>漢</rb><rt>かん</rt><rb>字</rb><rt>じ</rt></ruby>を書く</p>\n"},
+	{"1", "{汉字|hàn|zì}\n", "<p><ruby><rb>汉</rb><rt>hàn</rt><rb>字</rb><rt>zì</rt></ruby></p>\n"},
+	{"0", "{漢字|かんじ}\n", "<p><ruby><rb>漢字</rb><rt>かんじ</rt></ruby></p>\n"},
+}
+
+func TestRuby(t *testing.T) {
+	luteEngine := lute.New()
+	luteEngine.SetRuby(true)
+
+	for _, test := range rubyTests {
+		html := luteEngine.MarkdownStr(test.name, test.from)
+		if test.to != html {
+			t.Fatalf("test case [%s] failed\nexpected\n\t%q\ngot\n\t%q\noriginal markdown text\n\t%q", test.name, test.to, html, test.from)
+		}
+	}
+}
+
+var rubyAutoSpaceTests = []parseTest{
+
+	{"0", "学习{中文English|zhōng wén}和English\n", "<p>学习<ruby><rb>中文English</rb><rt>zhōng wén</rt></ruby>和 English</p>\n"},
+}
+
+func TestRubyAutoSpace(t *testing.T) {
+	luteEngine := lute.New()
+	luteEngine.SetRuby(true)
+	luteEngine.SetAutoSpace(true)
+
+	for _, test := range rubyAutoSpaceTests {
+		html := luteEngine.MarkdownStr(test.name, test.from)
+		if test.to != html {
+			t.Fatalf("test case [%s] failed\nexpected\n\t%q\ngot\n\t%q\noriginal markdown text\n\t%q", test.name, test.to, html, test.from)
+		}
+	}
+}
+
+var md2VditorRubyTests = []parseTest{
+
+	{"0", "学习{中文English|zhōng wén}和 English\n", "<p data-block=\"0\">学习<span class=\"vditor-wysiwyg__block\" data-type=\"source-inline\"><code data-type=\"source-inline\">\u200b{中文English|zhōng wén}</code><span class=\"vditor-wysiwyg__preview\" data-render=\"2\"><ruby><rb>中文English</rb><rt>zhōng wén</rt></ruby></span></span>\u200b和 English\n</p>"},
+}
+
+func TestMd2VditorRuby(t *testing.T) {
+	luteEngine := lute.New()
+	luteEngine.SetRuby(true)
+
+	for _, test := range md2VditorRubyTests {
+		vHTML := luteEngine.Md2VditorDOM(test.from)
+		if test.to != vHTML {
+			t.Fatalf("test case [%s] failed\nexpected\n\t%q\ngot\n\t%q\noriginal markdown text\n\t%q", test.name, test.to, vHTML, test.from)
+		}
+		if md := luteEngine.VditorDOM2Md(vHTML); test.from != md {
+			t.Fatalf("test case [%s] failed\nexpected\n\t%q\ngot\n\t%q\noriginal html\n\t%q", test.name, test.from, md, vHTML)
+		}
+	}
+}
+
+var md2VditorIRRubyTests = []parseTest{
+
+	{"0", "学习{中文English|zhōng wén}和 English\n", "<p data-block=\"0\">学习<span data-type=\"inline-node\" class=\"vditor-ir__node\"><code class=\"vditor-ir__marker\" data-type=\"source-inline\">{中文English|zhōng wén}</code></span>和 English\n</p>"},
+}
+
+func TestMd2VditorIRRuby(t *testing.T) {
+	luteEngine := lute.New()
+	luteEngine.SetRuby(true)
+
+	for _, test := range md2VditorIRRubyTests {
+		vHTML := luteEngine.Md2VditorIRDOM(test.from)
+		if test.to != vHTML {
+			t.Fatalf("test case [%s] failed\nexpected\n\t%q\ngot\n\t%q\noriginal markdown text\n\t%q", test.name, test.to, vHTML, test.from)
+		}
+		if md := luteEngine.VditorIRDOM2Md(vHTML); test.from != md {
+			t.Fatalf("test case [%s] failed\nexpected\n\t%q\ngot\n\t%q\noriginal html\n\t%q", test.name, test.from, md, vHTML)
+		}
+	}
+}
+
+var formatRubyTests = []parseTest{
+
+	{"0", "学习{中文English|zhōng wén}和{汉字|hàn|zì}\n", "学习{中文English|zhōng wén}和{汉字|hàn|zì}\n"},
+}
+
+func TestFormatRuby(t *testing.T) {
+	luteEngine := lute.New()
+	luteEngine.SetRuby(true)
+
+	for _, test := range formatRubyTests {
+		formatted := luteEngine.FormatStr(test.name, test.from)
+		if test.to != formatted {
+			t.Fatalf("test case [%s] failed\nexpected\n\t%q\ngot\n\t%q\noriginal markdown text\n\t%q", test.name, test.to, formatted, test.from)
+		}
+	}
+}
+
+var html2MdRubyTests = []parseTest{
+
+	{"3", "<p><ruby>a|b<rt>x</rt></ruby></p>", "a|bx\n"},
+	{"2", "<p><ruby>東京<rp>(</rp><rt>とうきょう</rt><rp>)</rp>大学<rt>だいがく</rt></ruby></p>", "{東京|とうきょう}{大学|だいがく}\n"},
+	{"1", "<p><ruby>汉<rt>hàn</rt>字<rt>zì</rt></ruby></p>", "{汉字|hàn|zì}\n"},
+	{"0", "<p><ruby><rb>漢字</rb><rt>かんじ</rt></ruby></p>", "{漢字|かんじ}\n"},
+}
+
+func TestHTML2MdRuby(t *testing.T) {
+	luteEngine := lute.New()
+	luteEngine.SetRuby(true)
+
+	for _, test := range html2MdRubyTests {
+		md := luteEngine.HTML2Md(test.from)
+		if test.to != md {
+			t.Fatalf("test case [%s] failed\nexpected\n\t%q\ngot\n\t%q\noriginal html\n\t%q", test.name, test.to, md, test.from)
+		}
+	}
+}
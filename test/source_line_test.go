@@ -0,0 +1,38 @@
+// Lute - 一款对中文语境优化的 Markdown 引擎，支持 Go 和 JavaScript
+// Copyright (c) 2019-present, b3log.org
+//
+// Lute is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//         http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND, EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT, MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+package test
+
+import (
+	"testing"
+
+	"lute"
+)
+
+var sourceLineTests = []parseTest{
+
+	{"3", "| a |\n| - |\n| b |\n", "<table>\n<thead>\n<tr data-source-line=\"1\" data-source-end=\"1\">\n<th>a</th>\n</tr>\n</thead>\n<tbody>\n<tr data-source-line=\"3\" data-source-end=\"3\">\n<td>b</td>\n</tr>\n</tbody>\n</table>\n"},
+	{"2", "```go\nfoo\n```\n\n    bar\n", "<pre data-source-line=\"1\" data-source-end=\"3\"><code class=\"language-go\">foo\n</code></pre>\n<pre data-source-line=\"5\" data-source-end=\"5\"><code>bar\n</code></pre>\n"},
+	{"1", "> foo\n> bar\n\n- a\n- b\n\n  c\n", "<blockquote data-source-line=\"1\" data-source-end=\"2\">\n<p data-source-line=\"1\" data-source-end=\"2\">foo<br />\nbar</p>\n</blockquote>\n<ul>\n<li data-source-line=\"4\" data-source-end=\"4\">\n<p data-source-line=\"4\" data-source-end=\"4\">a</p>\n</li>\n<li data-source-line=\"5\" data-source-end=\"7\">\n<p data-source-line=\"5\" data-source-end=\"5\">b</p>\n<p data-source-line=\"7\" data-source-end=\"7\">c</p>\n</li>\n</ul>\n"},
+	{"0", "# foo\n\nbar\nbaz\n", "<h1 id=\"foo\" data-source-line=\"1\" data-source-end=\"1\">foo</h1>\n<p data-source-line=\"3\" data-source-end=\"4\">bar<br />\nbaz</p>\n"},
+}
+
+func TestSourceLine(t *testing.T) {
+	luteEngine := lute.New()
+	luteEngine.SetRenderSourceLine(true)
+	luteEngine.SetCodeSyntaxHighlight(false)
+
+	for _, test := range sourceLineTests {
+		html := luteEngine.MarkdownStr(test.name, test.from)
+		if test.to != html {
+			t.Fatalf("test case [%s] failed\nexpected\n\t%q\ngot\n\t%q\noriginal markdown text\n\t%q", test.name, test.to, html, test.from)
+		}
+	}
+}
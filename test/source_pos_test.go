@@ -0,0 +1,67 @@
+// Lute - 一款对中文语境优化的 Markdown 引擎，支持 Go 和 JavaScript
+// Copyright (c) 2019-present, b3log.org
+//
+// Lute is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//         http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND, EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT, MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+package test
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+
+	"lute"
+	"lute/ast"
+	"lute/parse"
+)
+
+var sourcePosTests = []parseTest{
+
+	{"9", "a\x00b *c*\r\nd\x00 e\n", "NodeParagraph 1:1-2:5 [0,13) a\x00b *c*\r\\nd\x00 e\nNodeText 1:1-1:5 [0,4) a\x00b \nNodeEmphasis 1:5-1:8 [4,7) *c*\nNodeText 1:6-1:7 [5,6) c\nNodeText 2:1-2:5 [9,13) d\x00 e\n"},
+	{"8", "[a]: /bar\nbar\n", "NodeParagraph 2:1-2:4 [10,13) bar\nNodeText 2:1-2:4 [10,13) bar\n"},
+	{"7", "foo *a*\n---\n", "NodeHeading 1:1-2:4 [0,11) foo *a*\\n---\nNodeText 1:1-1:5 [0,4) foo \nNodeEmphasis 1:5-1:8 [4,7) *a*\nNodeText 1:6-1:7 [5,6) a\n"},
+
+	{"6", "| a | b |\n|---|---|\n| 1 | *2* |\n", "NodeTable 1:1-3:12 [0,31) | a | b |\\n|---|---|\\n| 1 | *2* |\nNodeTableCell 1:3-1:4 [2,3) a\nNodeText 1:3-1:4 [2,3) a\nNodeTableCell 1:7-1:8 [6,7) b\nNodeText 1:7-1:8 [6,7) b\nNodeTableCell 3:3-3:4 [22,23) 1\nNodeText 3:3-3:4 [22,23) 1\nNodeTableCell 3:7-3:10 [26,29) *2*\nNodeEmphasis 3:7-3:10 [26,29) *2*\nNodeText 3:8-3:9 [27,28) 2\n"},
+	{"5", "```go\ncode\n```\n", "NodeCodeBlock 1:1-3:4 [0,14) ```go\\ncode\\n```\nNodeCodeBlockFenceInfoMarker 1:4-1:6 [3,5) go\nNodeCodeBlockCode 2:1-2:5 [6,10) code\n"},
+	{"4", "- [x] foo :smile: www.b3log.org bar\n", "NodeList 1:1-1:36 [0,35) - [x] foo :smile: www.b3log.org bar\nNodeParagraph 1:3-1:36 [2,35) [x] foo :smile: www.b3log.org bar\nNodeText 1:6-1:11 [5,10)  foo \nNodeEmoji 1:11-1:18 [10,17) :smile:\nNodeText 1:18-1:19 [17,18)  \nNodeLink 1:19-1:32 [18,31) www.b3log.org\nNodeText 1:32-1:36 [31,35)  bar\n"},
+	{"3", "[foo]: /url\nbar [foo] ![img](/i)\n", "NodeParagraph 2:1-2:21 [12,32) bar [foo] ![img](/i)\nNodeText 2:1-2:5 [12,16) bar \nNodeLink 2:5-2:10 [16,21) [foo]\nNodeText 2:10-2:11 [21,22)  \nNodeImage 2:11-2:21 [22,32) ![img](/i)\n"},
+	{"2", "> foo **bar**\n> baz\\*\n", "NodeBlockquote 1:1-2:8 [0,21) > foo **bar**\\n> baz\\\\*\nNodeParagraph 1:3-2:8 [2,21) foo **bar**\\n> baz\\\\*\nNodeText 1:3-1:7 [2,6) foo \nNodeStrong 1:7-1:14 [6,13) **bar**\nNodeText 1:9-1:12 [8,11) bar\nNodeText 2:3-2:6 [16,19) baz\n"},
+	{"1", "# foo\r\n\r\nbar\r\n", "NodeHeading 1:1-1:6 [0,5) # foo\nNodeText 1:3-1:6 [2,5) foo\nNodeParagraph 3:1-3:4 [9,12) bar\nNodeText 3:1-3:4 [9,12) bar\n"},
+	{"0", "foo *bar*\n", "NodeParagraph 1:1-1:10 [0,9) foo *bar*\nNodeText 1:1-1:5 [0,4) foo \nNodeEmphasis 1:5-1:10 [4,9) *bar*\nNodeText 1:6-1:9 [5,8) bar\n"},
+}
+
+func TestSourcePos(t *testing.T) {
+	luteEngine := lute.New()
+
+	for _, test := range sourcePosTests {
+		tree := parse.Parse("", []byte(test.from), luteEngine.Options)
+		pos := dumpSourcePos(tree.Root, test.from)
+		if test.to != pos {
+			t.Fatalf("test case [%s] failed\nexpected\n\t%q\ngot\n\t%q\noriginal markdown text\n\t%q", test.name, test.to, pos, test.from)
+		}
+	}
+}
+
+func dumpSourcePos(root *ast.Node, markdown string) string {
+	buf := &strings.Builder{}
+	ast.Walk(root, func(n *ast.Node, entering bool) ast.WalkStatus {
+		if !entering {
+			return ast.WalkContinue
+		}
+		switch n.Type {
+		case ast.NodeParagraph, ast.NodeHeading, ast.NodeBlockquote, ast.NodeList, ast.NodeCodeBlock, ast.NodeCodeBlockFenceInfoMarker,
+			ast.NodeCodeBlockCode, ast.NodeTable, ast.NodeTableCell, ast.NodeText, ast.NodeEmphasis, ast.NodeStrong, ast.NodeLink,
+			ast.NodeImage, ast.NodeEmoji, ast.NodeCrossRef, ast.NodeReference:
+			buf.WriteString(n.Type.String() + " " + strconv.Itoa(n.StartLn) + ":" + strconv.Itoa(n.StartCol) + "-" + strconv.Itoa(n.EndLn) + ":" + strconv.Itoa(n.EndCol))
+			buf.WriteString(" [" + strconv.Itoa(n.StartOffset) + "," + strconv.Itoa(n.EndOffset) + ") ")
+			buf.WriteString(strings.ReplaceAll(strings.ReplaceAll(markdown[n.StartOffset:n.EndOffset], "\\", "\\\\"), "\n", "\\n") + "\n")
+		}
+		return ast.WalkContinue
+	})
+	return buf.String()
+}
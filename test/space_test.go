@@ -18,6 +18,8 @@ import (
 
 var spaceTests = []parseTest{
 
+	{"37", "第１章 中文１２３", "<p>第 １章 中文 １２３</p>\n"},
+
 	// 井号 # 前后自动空格问题 https://github.com/88250/lute/issues/62
 	{"36", "前#foo", "<p>前 #foo</p>\n"},
 	{"35", "foo#后", "<p>foo# 后</p>\n"},
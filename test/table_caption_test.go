@@ -0,0 +1,148 @@
+// Lute - 一款对中文语境优化的 Markdown 引擎，支持 Go 和 JavaScript
+// Copyright (c) 2019-present, b3log.org
+//
+// Lute is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//         http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND, EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT, MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+package test
+
+import (
+	"testing"
+
+	"lute"
+	"lute/parse"
+)
+
+var tableCaptionTests = []parseTest{
+
+	{"3", "Table: nothing\n", "<p>Table: nothing</p>\n"},
+	{"2", "| a |\n| - |\n| 1 |\nTable: trailing\n", "<table>\n<caption>trailing</caption>\n<thead>\n<tr>\n<th>a</th>\n</tr>\n</thead>\n<tbody>\n<tr>\n<td>1</td>\n</tr>\n</tbody>\n</table>\n"},
+	{"1", "Table: before\n| a |\n| - |\n| 1 |\n", "<table>\n<caption>before</caption>\n<thead>\n<tr>\n<th>a</th>\n</tr>\n</thead>\n<tbody>\n<tr>\n<td>1</td>\n</tr>\n</tbody>\n</table>\n"},
+	{"0", "| a |\n| - |\n| 1 |\n\nTable: Quarterly *revenue*\n", "<table>\n<caption>Quarterly <em>revenue</em></caption>\n<thead>\n<tr>\n<th>a</th>\n</tr>\n</thead>\n<tbody>\n<tr>\n<td>1</td>\n</tr>\n</tbody>\n</table>\n"},
+}
+
+func TestTableCaption(t *testing.T) {
+	luteEngine := lute.New()
+	luteEngine.SetTableCaption(true)
+
+	for _, test := range tableCaptionTests {
+		html := luteEngine.MarkdownStr(test.name, test.from)
+		if test.to != html {
+			t.Fatalf("test case [%s] failed\nexpected\n\t%q\ngot\n\t%q\noriginal markdown text\n\t%q", test.name, test.to, html, test.from)
+		}
+	}
+}
+
+var captionNumberingTests = []parseTest{
+
+	{"3", "see ![b](b.png \"B\") inline\n", "<p>see <img src=\"b.png\" alt=\"b\" title=\"B\" /> inline</p>\n"},
+	{"2", "[lot]\n\n[lof]\n\n| a |\n| - |\n\nTable: A\n\n![x](a.png \"Fig <1>\")\n\n| b |\n| - |\n", "<div class=\"vditor-toc\"><span class=\"toc-table\"><a class=\"toc-a\" href=\"#table-1\">表 1 A</a></span><br></div><div class=\"vditor-toc\"><span class=\"toc-figure\"><a class=\"toc-a\" href=\"#figure-1\">图 1 Fig &lt;1&gt;</a></span><br></div><table id=\"table-1\">\n<caption><span class=\"caption-number\">表 1</span> A</caption>\n<thead>\n<tr>\n<th>a</th>\n</tr>\n</thead>\n</table>\n<p><img src=\"a.png\" alt=\"x\" title=\"Fig &lt;1&gt;\" id=\"figure-1\" /><span class=\"figure-caption\"><span class=\"caption-number\">图 1</span> Fig &lt;1&gt;</span></p>\n<table>\n<thead>\n<tr>\n<th>b</th>\n</tr>\n</thead>\n</table>\n"},
+	{"1", "![a](a.png)\n\n![b](b.png \"B\")\n", "<p><img src=\"a.png\" alt=\"a\" /></p>\n<p><img src=\"b.png\" alt=\"b\" title=\"B\" id=\"figure-1\" /><span class=\"figure-caption\"><span class=\"caption-number\">图 1</span> B</span></p>\n"},
+	{"0", "| a |\n| - |\n\nTable: 一\n\n| b |\n| - |\n\nTable: 二\n", "<table id=\"table-1\">\n<caption><span class=\"caption-number\">表 1</span> 一</caption>\n<thead>\n<tr>\n<th>a</th>\n</tr>\n</thead>\n</table>\n<table id=\"table-2\">\n<caption><span class=\"caption-number\">表 2</span> 二</caption>\n<thead>\n<tr>\n<th>b</th>\n</tr>\n</thead>\n</table>\n"},
+}
+
+func TestCaptionNumbering(t *testing.T) {
+	luteEngine := lute.New()
+	luteEngine.SetToC(true)
+	luteEngine.SetTableCaption(true)
+	luteEngine.SetCaptionNumbering(true)
+	luteEngine.SetTableNumberPrefix("表 ")
+	luteEngine.SetFigureNumberPrefix("图 ")
+
+	for _, test := range captionNumberingTests {
+		html := luteEngine.MarkdownStr(test.name, test.from)
+		if test.to != html {
+			t.Fatalf("test case [%s] failed\nexpected\n\t%q\ngot\n\t%q\noriginal markdown text\n\t%q", test.name, test.to, html, test.from)
+		}
+	}
+}
+
+var formatTableCaptionTests = []parseTest{
+
+	{"2", "[lot]\n\n[LOF]\n", "[lot]\n\n[lof]\n"},
+	{"1", "Table: before\n| a |\n| - |\n", "Table: before\n\n| a |\n| - |\n"},
+	{"0", "| a |\n| - |\nTable:   after\n", "| a |\n| - |\n\nTable: after\n"},
+}
+
+func TestFormatTableCaption(t *testing.T) {
+	luteEngine := lute.New()
+	luteEngine.SetToC(true)
+	luteEngine.SetTableCaption(true)
+	luteEngine.SetCaptionNumbering(true)
+
+	for _, test := range formatTableCaptionTests {
+		formatted := luteEngine.FormatStr(test.name, test.from)
+		if test.to != formatted {
+			t.Fatalf("test case [%s] failed\nexpected\n\t%q\ngot\n\t%q\noriginal markdown text\n\t%q", test.name, test.to, formatted, test.from)
+		}
+	}
+}
+
+var md2VditorTableCaptionTests = []parseTest{
+
+	{"2", "[lof]\n", "<div class=\"vditor-toc\" data-block=\"0\" data-type=\"toc-block\" data-marker=\"[lof]\" contenteditable=\"false\">[lof]<br></div><p data-block=\"0\"></p>"},
+	{"1", "Table: before {#tbl:x}\n\n| a |\n| - |\n", "<table data-block=\"0\"><caption data-type=\"table-caption\" data-before=\"1\">before {#tbl:x}</caption><thead><tr><th>a</th></tr></thead></table>"},
+	{"0", "| a |\n| - |\n| b |\n\nTable: *A* <b>\n", "<table data-block=\"0\"><caption data-type=\"table-caption\">*A* &lt;b&gt;</caption><thead><tr><th>a</th></tr></thead><tbody><tr><td>b</td></tr></tbody></table>"},
+}
+
+func TestMd2VditorTableCaption(t *testing.T) {
+	luteEngine := lute.New()
+	luteEngine.SetToC(true)
+	luteEngine.SetTableCaption(true)
+	luteEngine.SetCaptionNumbering(true)
+	luteEngine.SetCrossRef(true)
+
+	for _, test := range md2VditorTableCaptionTests {
+		vHTML := luteEngine.Md2VditorDOM(test.from)
+		if test.to != vHTML {
+			t.Fatalf("test case [%s] failed\nexpected\n\t%q\ngot\n\t%q\noriginal markdown text\n\t%q", test.name, test.to, vHTML, test.from)
+		}
+		if md := luteEngine.VditorDOM2Md(vHTML); test.from != md {
+			t.Fatalf("test case [%s] failed\nexpected\n\t%q\ngot\n\t%q\noriginal html\n\t%q", test.name, test.from, md, vHTML)
+		}
+	}
+}
+
+var md2VditorIRTableCaptionTests = []parseTest{
+
+	{"1", "[lot]\n", "<div class=\"vditor-toc\" data-block=\"0\" data-type=\"toc-block\" data-marker=\"[lot]\" contenteditable=\"false\">[lot]<br></div><p data-block=\"0\"></p>"},
+	{"0", "Table: before {#tbl:x}\n\n| a |\n| - |\n", "<table data-block=\"0\" data-type=\"table\"><caption data-type=\"table-caption\" data-before=\"1\">before {#tbl:x}</caption><thead><tr><th>a</th></tr></thead></table>"},
+}
+
+func TestMd2VditorIRTableCaption(t *testing.T) {
+	luteEngine := lute.New()
+	luteEngine.SetToC(true)
+	luteEngine.SetTableCaption(true)
+	luteEngine.SetCaptionNumbering(true)
+	luteEngine.SetCrossRef(true)
+
+	for _, test := range md2VditorIRTableCaptionTests {
+		vHTML := luteEngine.Md2VditorIRDOM(test.from)
+		if test.to != vHTML {
+			t.Fatalf("test case [%s] failed\nexpected\n\t%q\ngot\n\t%q\noriginal markdown text\n\t%q", test.name, test.to, vHTML, test.from)
+		}
+		if md := luteEngine.VditorIRDOM2Md(vHTML); test.from != md {
+			t.Fatalf("test case [%s] failed\nexpected\n\t%q\ngot\n\t%q\noriginal html\n\t%q", test.name, test.from, md, vHTML)
+		}
+	}
+}
+
+func TestTreeNumberedItems(t *testing.T) {
+	luteEngine := lute.New()
+	luteEngine.SetTableCaption(true)
+	luteEngine.SetCaptionNumbering(true)
+
+	tree := parse.Parse("", []byte("| a |\n| - |\n\nTable: Quarterly *revenue*\n\n![x](a.png \"Chart\")\n"), luteEngine.Options)
+	tables := tree.NumberedTables()
+	if 1 != len(tables) || "Table 1" != tables[0].Label || "Quarterly revenue" != tables[0].Caption || "table-1" != tables[0].ID {
+		t.Fatalf("numbered tables failed: %+v", tables)
+	}
+	figures := tree.NumberedFigures()
+	if 1 != len(figures) || "Figure 1" != figures[0].Label || "Chart" != figures[0].Caption || "figure-1" != figures[0].ID {
+		t.Fatalf("numbered figures failed: %+v", figures)
+	}
+}
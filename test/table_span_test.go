@@ -0,0 +1,101 @@
+// Lute - 一款对中文语境优化的 Markdown 引擎，支持 Go 和 JavaScript
+// Copyright (c) 2019-present, b3log.org
+//
+// Lute is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//         http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND, EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT, MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+package test
+
+import (
+	"testing"
+
+	"lute"
+)
+
+var tableSpanTests = []parseTest{
+
+	{"9", "| a | b |\n| - | - |\n| | x |\\\n| y | z |\n", "<table>\n<thead>\n<tr>\n<th>a</th>\n<th>b</th>\n</tr>\n</thead>\n<tbody>\n<tr>\n<td>y</td>\n<td>x<br />\nz</td>\n</tr>\n</tbody>\n</table>\n"},
+	{"8", "| a | b | c |\n| - | - | - |\n| x || y |\n| ^^ || q |\n", "<table>\n<thead>\n<tr>\n<th>a</th>\n<th>b</th>\n<th>c</th>\n</tr>\n</thead>\n<tbody>\n<tr>\n<td colspan=\"2\" rowspan=\"2\">x</td>\n<td>y</td>\n</tr>\n<tr>\n<td>q</td>\n</tr>\n</tbody>\n</table>\n"},
+	{"7", "| a | b | c |\n| - | - | - |\n| x || y |\n| ^^ | p | q |\n", "<table>\n<thead>\n<tr>\n<th>a</th>\n<th>b</th>\n<th>c</th>\n</tr>\n</thead>\n<tbody>\n<tr>\n<td colspan=\"2\">x</td>\n<td>y</td>\n</tr>\n<tr>\n<td>^^</td>\n<td>p</td>\n<td>q</td>\n</tr>\n</tbody>\n</table>\n"},
+	{"6", "| a ||\n| - | - |\n| 1 | 2 |\n", "<table>\n<thead>\n<tr>\n<th colspan=\"2\">a</th>\n</tr>\n</thead>\n<tbody>\n<tr>\n<td>1</td>\n<td>2</td>\n</tr>\n</tbody>\n</table>\n"},
+	{"5", "| a | b |\n| - | - |\n|| x |\n", "<table>\n<thead>\n<tr>\n<th>a</th>\n<th>b</th>\n</tr>\n</thead>\n<tbody>\n<tr>\n<td></td>\n<td>x</td>\n</tr>\n</tbody>\n</table>\n"},
+	{"4", "| a | b |\n| - | - |\n| a\\\\ | b\\\\\\\\ |\n", "<table>\n<thead>\n<tr>\n<th>a</th>\n<th>b</th>\n</tr>\n</thead>\n<tbody>\n<tr>\n<td>a\\</td>\n<td>b\\\\</td>\n</tr>\n</tbody>\n</table>\n"},
+	{"3", "| a | b |\n| - | - |\n| line1 | x |\\\n| line2 | |\n", "<table>\n<thead>\n<tr>\n<th>a</th>\n<th>b</th>\n</tr>\n</thead>\n<tbody>\n<tr>\n<td>line1<br />\nline2</td>\n<td>x</td>\n</tr>\n</tbody>\n</table>\n"},
+	{"2", "| a | b |\n| - | - |\n| ^^ | 1 |\n", "<table>\n<thead>\n<tr>\n<th>a</th>\n<th>b</th>\n</tr>\n</thead>\n<tbody>\n<tr>\n<td>^^</td>\n<td>1</td>\n</tr>\n</tbody>\n</table>\n"},
+	{"1", "| a | b |\n| - | - |\n| 1 | 2 |\n| ^^ | 3 |\n| ^^ | 4 |\n", "<table>\n<thead>\n<tr>\n<th>a</th>\n<th>b</th>\n</tr>\n</thead>\n<tbody>\n<tr>\n<td rowspan=\"3\">1</td>\n<td>2</td>\n</tr>\n<tr>\n<td>3</td>\n</tr>\n<tr>\n<td>4</td>\n</tr>\n</tbody>\n</table>\n"},
+	{"0", "| a | b | c |\n| - | - | - |\n| x || y |\n| 1 | 2 | 3 |\n", "<table>\n<thead>\n<tr>\n<th>a</th>\n<th>b</th>\n<th>c</th>\n</tr>\n</thead>\n<tbody>\n<tr>\n<td colspan=\"2\">x</td>\n<td>y</td>\n</tr>\n<tr>\n<td>1</td>\n<td>2</td>\n<td>3</td>\n</tr>\n</tbody>\n</table>\n"},
+}
+
+func TestTableSpan(t *testing.T) {
+	luteEngine := lute.New()
+	luteEngine.SetTableSpan(true)
+
+	for _, test := range tableSpanTests {
+		html := luteEngine.MarkdownStr(test.name, test.from)
+		if test.to != html {
+			t.Fatalf("test case [%s] failed\nexpected\n\t%q\ngot\n\t%q\noriginal markdown text\n\t%q", test.name, test.to, html, test.from)
+		}
+	}
+}
+
+var tableSpanDisabledTests = []parseTest{
+
+	{"0", "| a | b |\n| - | - |\n| x || \n", "<table>\n<thead>\n<tr>\n<th>a</th>\n<th>b</th>\n</tr>\n</thead>\n<tbody>\n<tr>\n<td>x</td>\n<td></td>\n</tr>\n</tbody>\n</table>\n"},
+}
+
+func TestTableSpanDisabled(t *testing.T) {
+	luteEngine := lute.New()
+
+	for _, test := range tableSpanDisabledTests {
+		html := luteEngine.MarkdownStr(test.name, test.from)
+		if test.to != html {
+			t.Fatalf("test case [%s] failed\nexpected\n\t%q\ngot\n\t%q\noriginal markdown text\n\t%q", test.name, test.to, html, test.from)
+		}
+	}
+}
+
+var formatTableSpanTests = []parseTest{
+
+	{"4", "| a | b |\n| - | - |\n| | x |\\\n| y | z |\n", "| a | b |\n| - | - |\n| y | x | \\\n|   | z |\n"},
+	{"3", "| a | b |\n| - | - |\n| || x |\n", "| a | b |\n| - | - |\n|      ||\n"},
+	{"2", "| a ||\n| - | - |\n| x ||\n", "| a ||\n| - | - |\n| x ||\n"},
+	{"1", "| a | b |\n| - | - |\n| line1 | x |\\\n| line2 | |\n", "| a     | b |\n| ----- | - |\n| line1 | x | \\\n| line2 |   |\n"},
+	{"0", "| a | b | c |\n| - | - | - |\n| long content || y |\n| 1 | 2 | 3 |\n| ^^ | 4 | 5 |\n", "| a  | b        | c |\n| -- | -------- | - |\n| long content || y |\n| 1  | 2        | 3 |\n| ^^ | 4        | 5 |\n"},
+}
+
+func TestFormatTableSpan(t *testing.T) {
+	luteEngine := lute.New()
+	luteEngine.SetTableSpan(true)
+
+	for _, test := range formatTableSpanTests {
+		formatted := luteEngine.FormatStr(test.name, test.from)
+		if test.to != formatted {
+			t.Fatalf("test case [%s] failed\nexpected\n\t%q\ngot\n\t%q\noriginal markdown text\n\t%q", test.name, test.to, formatted, test.from)
+		}
+		if formatted = luteEngine.FormatStr(test.name, test.to); test.to != formatted {
+			t.Fatalf("test case [%s] failed\nexpected\n\t%q\ngot\n\t%q\noriginal markdown text\n\t%q", test.name, test.to, formatted, test.to)
+		}
+	}
+}
+
+var html2MdTableSpanTests = []parseTest{
+
+	{"1", "<table><thead><tr><th colspan=\"2\">a</th></tr></thead><tbody><tr><td>1<br>2</td><td>3</td></tr></tbody></table>", "| a ||\n| - | - |\n| 1 | 3 | \\\n| 2 |  |\n"},
+	{"0", "<table><thead><tr><th>a</th><th>b</th><th>c</th></tr></thead><tbody><tr><td rowspan=\"2\" colspan=\"2\">x</td><td>y</td></tr><tr><td>z</td></tr></tbody></table>", "| a | b | c |\n| - | - | - |\n| x || y |\n| ^^ || z |\n"},
+}
+
+func TestHTML2MdTableSpan(t *testing.T) {
+	luteEngine := lute.New()
+	luteEngine.SetTableSpan(true)
+
+	for _, test := range html2MdTableSpanTests {
+		md := luteEngine.HTML2Md(test.from)
+		if test.to != md {
+			t.Fatalf("test case [%s] failed\nexpected\n\t%q\ngot\n\t%q\noriginal html\n\t%q", test.name, test.to, md, test.from)
+		}
+	}
+}
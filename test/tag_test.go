@@ -0,0 +1,127 @@
+// Lute - 一款对中文语境优化的 Markdown 引擎，支持 Go 和 JavaScript
+// Copyright (c) 2019-present, b3log.org
+//
+// Lute is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//         http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND, EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT, MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+package test
+
+import (
+	"strings"
+	"testing"
+
+	"lute"
+	"lute/parse"
+)
+
+var tagTests = []parseTest{
+
+	{"5", "#multi word tag# and #a #b# c#\n", "<p><span class=\"tag\">#multi word tag#</span> and <span class=\"tag\">#a</span> <span class=\"tag\">#b#</span> c#</p>\n"},
+	{"4", "#foo and #bar# [#link](/a) `#code`\n", "<p><span class=\"tag\">#foo</span> and <span class=\"tag\">#bar#</span> <a href=\"/a\">#link</a> <code>#code</code></p>\n"},
+	{"3", "C# a#b http://x.com/#frag #123 ##no\n", "<p>C# a#b <a href=\"http://x.com/#frag\">http://x.com/#frag</a> #123 ##no</p>\n"},
+	{"2", "# Heading #h {#id}\n", "<h1 id=\"id\">Heading <span class=\"tag\">#h</span></h1>\n"},
+	{"1", "#x/y- (#paren)\n", "<p><span class=\"tag\">#x/y</span>- (<span class=\"tag\">#paren</span>)</p>\n"},
+	{"0", "#tag 和 #标签，以及 #多个词的标签#内容\n", "<p><span class=\"tag\">#tag</span> 和 <span class=\"tag\">#标签</span>，以及 <span class=\"tag\">#多个词的标签#</span>内容</p>\n"},
+}
+
+func TestTag(t *testing.T) {
+	luteEngine := lute.New()
+	luteEngine.SetTag(true)
+
+	for _, test := range tagTests {
+		html := luteEngine.MarkdownStr(test.name, test.from)
+		if test.to != html {
+			t.Fatalf("test case [%s] failed\nexpected\n\t%q\ngot\n\t%q\noriginal markdown text\n\t%q", test.name, test.to, html, test.from)
+		}
+	}
+}
+
+var tagURLTemplateTests = []parseTest{
+
+	{"1", "#标签 #c?d#\n", "<p><a href=\"/tags/%E6%A0%87%E7%AD%BE\" class=\"tag\">#标签</a> <a href=\"/tags/c%3Fd\" class=\"tag\">#c?d#</a></p>\n"},
+	{"0", "#a<b #x y#\n", "<p><a href=\"/tags/a\" class=\"tag\">#a</a>&lt;b <a href=\"/tags/x%20y\" class=\"tag\">#x y#</a></p>\n"},
+}
+
+func TestTagURLTemplate(t *testing.T) {
+	luteEngine := lute.New()
+	luteEngine.SetTag(true)
+	luteEngine.SetTagURLTemplate("/tags/${tag}")
+
+	for _, test := range tagURLTemplateTests {
+		html := luteEngine.MarkdownStr(test.name, test.from)
+		if test.to != html {
+			t.Fatalf("test case [%s] failed\nexpected\n\t%q\ngot\n\t%q\noriginal markdown text\n\t%q", test.name, test.to, html, test.from)
+		}
+	}
+}
+
+var formatTagTests = []parseTest{
+
+	{"0", "#tag 和 #标签，以及 #多个词的标签#内容\n", "#tag 和 #标签，以及 #多个词的标签#内容\n"},
+}
+
+func TestFormatTag(t *testing.T) {
+	luteEngine := lute.New()
+	luteEngine.SetTag(true)
+
+	for _, test := range formatTagTests {
+		formatted := luteEngine.FormatStr(test.name, test.from)
+		if test.to != formatted {
+			t.Fatalf("test case [%s] failed\nexpected\n\t%q\ngot\n\t%q\noriginal markdown text\n\t%q", test.name, test.to, formatted, test.from)
+		}
+	}
+}
+
+var md2VditorTagTests = []parseTest{
+
+	{"0", "a #标签# and #x\n", "<p data-block=\"0\">a <span class=\"vditor-wysiwyg__block\" data-type=\"source-inline\"><code data-type=\"source-inline\">\u200b#标签#</code><span class=\"vditor-wysiwyg__preview\" data-render=\"2\"><span class=\"tag\">#标签#</span></span></span>\u200b and <span class=\"vditor-wysiwyg__block\" data-type=\"source-inline\"><code data-type=\"source-inline\">\u200b#x</code><span class=\"vditor-wysiwyg__preview\" data-render=\"2\"><span class=\"tag\">#x</span></span></span>\u200b\n</p>"},
+}
+
+func TestMd2VditorTag(t *testing.T) {
+	luteEngine := lute.New()
+	luteEngine.SetTag(true)
+
+	for _, test := range md2VditorTagTests {
+		vHTML := luteEngine.Md2VditorDOM(test.from)
+		if test.to != vHTML {
+			t.Fatalf("test case [%s] failed\nexpected\n\t%q\ngot\n\t%q\noriginal markdown text\n\t%q", test.name, test.to, vHTML, test.from)
+		}
+		if md := luteEngine.VditorDOM2Md(vHTML); test.from != md {
+			t.Fatalf("test case [%s] failed\nexpected\n\t%q\ngot\n\t%q\noriginal html\n\t%q", test.name, test.from, md, vHTML)
+		}
+	}
+}
+
+var md2VditorIRTagTests = []parseTest{
+
+	{"0", "a #标签# and #x\n", "<p data-block=\"0\">a <span data-type=\"inline-node\" class=\"vditor-ir__node\"><code class=\"vditor-ir__marker\" data-type=\"source-inline\">#标签#</code></span> and <span data-type=\"inline-node\" class=\"vditor-ir__node\"><code class=\"vditor-ir__marker\" data-type=\"source-inline\">#x</code></span>\n</p>"},
+}
+
+func TestMd2VditorIRTag(t *testing.T) {
+	luteEngine := lute.New()
+	luteEngine.SetTag(true)
+
+	for _, test := range md2VditorIRTagTests {
+		vHTML := luteEngine.Md2VditorIRDOM(test.from)
+		if test.to != vHTML {
+			t.Fatalf("test case [%s] failed\nexpected\n\t%q\ngot\n\t%q\noriginal markdown text\n\t%q", test.name, test.to, vHTML, test.from)
+		}
+		if md := luteEngine.VditorIRDOM2Md(vHTML); test.from != md {
+			t.Fatalf("test case [%s] failed\nexpected\n\t%q\ngot\n\t%q\noriginal html\n\t%q", test.name, test.from, md, vHTML)
+		}
+	}
+}
+
+func TestTreeTags(t *testing.T) {
+	luteEngine := lute.New()
+	luteEngine.SetTag(true)
+
+	tree := parse.Parse("", []byte("#b #a\n\n- #b 和 #多个词#\n"), luteEngine.Options)
+	if expected, got := "b,a,多个词", strings.Join(tree.Tags(), ","); expected != got {
+		t.Fatalf("tags failed\nexpected\n\t%q\ngot\n\t%q", expected, got)
+	}
+}
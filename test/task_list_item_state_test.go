@@ -0,0 +1,91 @@
+// Lute - 一款对中文语境优化的 Markdown 引擎，支持 Go 和 JavaScript
+// Copyright (c) 2019-present, b3log.org
+//
+// Lute is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//         http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND, EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT, MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+package test
+
+import (
+	"testing"
+
+	"lute"
+	"lute/parse"
+)
+
+var taskListItemStateTests = []parseTest{
+
+	{"2", "- [!] a\n", "<ul>\n<li>[!] a</li>\n</ul>\n"},
+	{"1", "- [>] a\n- [?] b\n", "<ul>\n<li class=\"vditor-task\"><input disabled=\"\" type=\"checkbox\" data-task=\"&gt;\" class=\"task-deferred\" /> a</li>\n<li class=\"vditor-task\"><input disabled=\"\" type=\"checkbox\" data-task=\"?\" class=\"task-question\" /> b</li>\n</ul>\n"},
+	{"0", "- [x] a\n- [-] b\n- [/] c\n", "<ul>\n<li class=\"vditor-task\"><input checked=\"\" disabled=\"\" type=\"checkbox\" /> a</li>\n<li class=\"vditor-task\"><input disabled=\"\" type=\"checkbox\" data-task=\"-\" class=\"task-cancelled\" /> b</li>\n<li class=\"vditor-task\"><input disabled=\"\" type=\"checkbox\" data-task=\"/\" class=\"task-in-progress\" /> c</li>\n</ul>\n"},
+}
+
+func TestTaskListItemState(t *testing.T) {
+	luteEngine := lute.New()
+	luteEngine.SetTaskListItemStates(parse.ExtendedTaskListItemStates)
+
+	for _, test := range taskListItemStateTests {
+		html := luteEngine.MarkdownStr(test.name, test.from)
+		if test.to != html {
+			t.Fatalf("test case [%s] failed\nexpected\n\t%q\ngot\n\t%q\noriginal markdown text\n\t%q", test.name, test.to, html, test.from)
+		}
+	}
+}
+
+var taskListItemCustomStateTests = []parseTest{
+
+	{"0", "- [!] a\n- [-] b\n", "<ul>\n<li class=\"vditor-task\"><input disabled=\"\" type=\"checkbox\" data-task=\"!\" class=\"task-important\" /> a</li>\n</ul>\n<ul>\n<li>[-] b</li>\n</ul>\n"},
+}
+
+func TestTaskListItemCustomState(t *testing.T) {
+	luteEngine := lute.New()
+	luteEngine.SetTaskListItemStates(map[byte]string{'!': "important"})
+
+	for _, test := range taskListItemCustomStateTests {
+		html := luteEngine.MarkdownStr(test.name, test.from)
+		if test.to != html {
+			t.Fatalf("test case [%s] failed\nexpected\n\t%q\ngot\n\t%q\noriginal markdown text\n\t%q", test.name, test.to, html, test.from)
+		}
+	}
+}
+
+var formatTaskListItemStateTests = []parseTest{
+
+	{"0", "- [ ] a\n- [x] b\n- [X] c\n- [-] d\n- [/] e\n- [>] f\n- [?] g\n", "- [ ] a\n- [x] b\n- [X] c\n- [-] d\n- [/] e\n- [>] f\n- [?] g\n"},
+}
+
+func TestFormatTaskListItemState(t *testing.T) {
+	luteEngine := lute.New()
+	luteEngine.SetTaskListItemStates(parse.ExtendedTaskListItemStates)
+
+	for _, test := range formatTaskListItemStateTests {
+		formatted := luteEngine.FormatStr(test.name, test.from)
+		if test.to != formatted {
+			t.Fatalf("test case [%s] failed\nexpected\n\t%q\ngot\n\t%q\noriginal markdown text\n\t%q", test.name, test.to, formatted, test.from)
+		}
+	}
+}
+
+var vditorIRTaskListItemStateTests = []parseTest{
+
+	{"0", "- [-] a\n- [/] b\n", "<ul data-tight=\"true\" data-marker=\"-\" data-block=\"0\"><li data-marker=\"-\" class=\"vditor-task\"><input type=\"checkbox\" data-task=\"-\" class=\"task-cancelled\" /> a</li><li data-marker=\"-\" class=\"vditor-task\"><input type=\"checkbox\" data-task=\"/\" class=\"task-in-progress\" /> b</li></ul>"},
+}
+
+func TestVditorIRTaskListItemState(t *testing.T) {
+	luteEngine := lute.New()
+	luteEngine.SetTaskListItemStates(parse.ExtendedTaskListItemStates)
+
+	for _, test := range vditorIRTaskListItemStateTests {
+		html := luteEngine.Md2VditorIRDOM(test.from)
+		if test.to != html {
+			t.Fatalf("test case [%s] failed\nexpected\n\t%q\ngot\n\t%q\noriginal markdown text\n\t%q", test.name, test.to, html, test.from)
+		}
+		if md := luteEngine.VditorIRDOM2Md(html); test.from != md {
+			t.Fatalf("test case [%s] failed\nexpected\n\t%q\ngot\n\t%q\noriginal markdown text\n\t%q", test.name, test.from, md, test.from)
+		}
+	}
+}
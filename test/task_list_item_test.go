@@ -0,0 +1,104 @@
+// Lute - 一款对中文语境优化的 Markdown 引擎，支持 Go 和 JavaScript
+// Copyright (c) 2019-present, b3log.org
+//
+// Lute is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//         http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND, EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT, MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+package test
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"testing"
+
+	"lute"
+	"lute/parse"
+)
+
+const taskListItemMarkdown = "# T\r\n\r\n- [ ] a *b*\r\n  - [x] c\r\n    1. [-] d\r\n- [ ]\te\r\n\r\n> * [X] quote\r\n"
+
+func TestTaskListItems(t *testing.T) {
+	luteEngine := lute.New()
+	luteEngine.SetTaskListItemStates(parse.ExtendedTaskListItemStates)
+
+	var items []string
+	for _, item := range luteEngine.TaskListItems([]byte(taskListItemMarkdown)) {
+		items = append(items, fmt.Sprintf("%d %q %q %v %v %d", item.Index, item.Text, item.State, item.Checked, item.Path, item.Offset))
+	}
+	expected := `0 "a b" " " false [0] 9
+1 "c" "x" true [0 0] 24
+2 "d" "-" false [0 0 0] 38
+3 "e" " " false [1] 47
+4 "quote" "X" true [0] 60`
+	if got := strings.Join(items, "\n"); expected != got {
+		t.Fatalf("task list items failed\nexpected\n\t%s\ngot\n\t%s", expected, got)
+	}
+}
+
+var toggleTaskListItemTests = []parseTest{
+
+	{"4", taskListItemMarkdown, "# T\r\n\r\n- [ ] a *b*\r\n  - [x] c\r\n    1. [-] d\r\n- [ ]\te\r\n\r\n> * [ ] quote\r\n"},
+	{"3", taskListItemMarkdown, "# T\r\n\r\n- [ ] a *b*\r\n  - [x] c\r\n    1. [-] d\r\n- [x]\te\r\n\r\n> * [X] quote\r\n"},
+	{"2", taskListItemMarkdown, "# T\r\n\r\n- [ ] a *b*\r\n  - [x] c\r\n    1. [ ] d\r\n- [ ]\te\r\n\r\n> * [X] quote\r\n"},
+	{"1", taskListItemMarkdown, "# T\r\n\r\n- [ ] a *b*\r\n  - [ ] c\r\n    1. [-] d\r\n- [ ]\te\r\n\r\n> * [X] quote\r\n"},
+	{"0", taskListItemMarkdown, "# T\r\n\r\n- [x] a *b*\r\n  - [x] c\r\n    1. [-] d\r\n- [ ]\te\r\n\r\n> * [X] quote\r\n"},
+}
+
+func TestToggleTaskListItem(t *testing.T) {
+	luteEngine := lute.New()
+	luteEngine.SetTaskListItemStates(parse.ExtendedTaskListItemStates)
+
+	for _, test := range toggleTaskListItemTests {
+		index, _ := strconv.Atoi(test.name)
+		md, err := luteEngine.ToggleTaskListItem([]byte(test.from), index)
+		if nil != err {
+			t.Fatalf("test case [%s] failed: %s", test.name, err)
+		}
+		if test.to != string(md) {
+			t.Fatalf("test case [%s] failed\nexpected\n\t%q\ngot\n\t%q\noriginal markdown text\n\t%q", test.name, test.to, md, test.from)
+		}
+	}
+
+	if _, err := luteEngine.ToggleTaskListItem([]byte(taskListItemMarkdown), 5); nil == err {
+		t.Fatalf("toggle task list item out of range should fail")
+	}
+}
+
+func TestUpdateTaskListItem(t *testing.T) {
+	luteEngine := lute.New()
+	luteEngine.SetTaskListItemStates(parse.ExtendedTaskListItemStates)
+
+	md, err := luteEngine.UpdateTaskListItem([]byte(taskListItemMarkdown), 0, '/')
+	if nil != err {
+		t.Fatalf("update task list item failed: %s", err)
+	}
+	if expected := "# T\r\n\r\n- [/] a *b*\r\n  - [x] c\r\n    1. [-] d\r\n- [ ]\te\r\n\r\n> * [X] quote\r\n"; expected != string(md) {
+		t.Fatalf("update task list item failed\nexpected\n\t%q\ngot\n\t%q", expected, md)
+	}
+
+	if _, err = luteEngine.UpdateTaskListItem([]byte(taskListItemMarkdown), 0, '!'); nil == err {
+		t.Fatalf("update task list item with unknown state should fail")
+	}
+}
+
+var taskListItemIndexTests = []parseTest{
+
+	{"0", "- [ ] a\n  - [x] b\n\n> - [ ] c\n", "<ul>\n<li class=\"vditor-task\"><input disabled=\"\" type=\"checkbox\" data-task-index=\"0\" /> a\n<ul>\n<li class=\"vditor-task\"><input checked=\"\" disabled=\"\" type=\"checkbox\" data-task-index=\"1\" /> b</li>\n</ul>\n</li>\n</ul>\n<blockquote>\n<ul>\n<li class=\"vditor-task\"><input disabled=\"\" type=\"checkbox\" data-task-index=\"2\" /> c</li>\n</ul>\n</blockquote>\n"},
+}
+
+func TestTaskListItemIndex(t *testing.T) {
+	luteEngine := lute.New()
+	luteEngine.SetTaskListItemIndex(true)
+
+	for _, test := range taskListItemIndexTests {
+		html := luteEngine.MarkdownStr(test.name, test.from)
+		if test.to != html {
+			t.Fatalf("test case [%s] failed\nexpected\n\t%q\ngot\n\t%q\noriginal markdown text\n\t%q", test.name, test.to, html, test.from)
+		}
+	}
+}
@@ -0,0 +1,142 @@
+// Lute - 一款对中文语境优化的 Markdown 引擎，支持 Go 和 JavaScript
+// Copyright (c) 2019-present, b3log.org
+//
+// Lute is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//         http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND, EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT, MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+package test
+
+import (
+	"io/fs"
+	"testing"
+	"testing/fstest"
+
+	"lute"
+	"lute/parse"
+)
+
+var transclusionFS = fstest.MapFS{
+	"chapter1.md": {Data: []byte("# Chapter 1\n\nIntro[^1] see [docs].\n\n## Setup\n\nRun it[^1].\n\n## Usage\n\nUse it.\n\n[^1]: Child note.\n\n[docs]: https://child.example \"Child\"\n")},
+	"a.md":        {Data: []byte("A\n\n!include b.md\n")},
+	"b.md":        {Data: []byte("B\n\n!include a.md\n")},
+	"dir/x.md":    {Data: []byte("X\n\n!include y.md\n")},
+	"dir/y.md":    {Data: []byte("Y\n")},
+	"fig.md":      {Data: []byte("C\n\nD\n\nsee @fig:none\n")},
+	"list.md":     {Data: []byte("* item\n")},
+	"meta.md":     {Data: []byte("---\ntitle: Meta\n---\n\nBody\n")},
+	"tasks.md":    {Data: []byte("- [ ] child\n")},
+}
+
+func newTransclusionLute() *lute.Lute {
+	luteEngine := lute.New()
+	luteEngine.SetFrontMatter(true)
+	luteEngine.SetTransclusion(true)
+	luteEngine.SetContentLoader(parse.ContentLoaderFunc(func(path string) ([]byte, error) {
+		return fs.ReadFile(transclusionFS, path)
+	}))
+	return luteEngine
+}
+
+var transclusionTests = []parseTest{
+
+	{"6", "!include meta.md\n", "<p>Body</p>\n"},
+	{"5", "```\n!include a.md\n```\n", "<pre><code class=\"highlight-chroma\">!include a.md\n</code></pre>\n"},
+	{"4", "!include a.md\n", "<p>A</p>\n<p>B</p>\n<p>!include a.md</p>\n"},
+	{"3", "> ![[list]]\n", "<blockquote>\n<ul>\n<li>item</li>\n</ul>\n</blockquote>\n"},
+	{"2", "!include dir/x.md\n", "<p>X</p>\n<p>Y</p>\n"},
+	{"1", "![[chapter1#Setup]]\n", "<h2 id=\"Setup\">Setup</h2>\n<p>Run it<sup class=\"footnotes-ref\" id=\"footnotes-ref-1\"><a href=\"#footnotes-def-1\">1</a></sup>.</p>\n<div class=\"footnotes-defs-div\"><hr class=\"footnotes-defs-hr\" />\n<ol class=\"footnotes-defs-ol\"><li id=\"footnotes-def-1\"><p>Child note. <a href=\"#footnotes-ref-1\" class=\"vditor-footnotes__goto-ref\">↩</a></p>\n</li>\n</ol></div>"},
+	{"0", "Host[^1] [docs]\n\n!include chapter1.md\n\n[^1]: Host note.\n\n[docs]: https://host.example\n", "<p>Host<sup class=\"footnotes-ref\" id=\"footnotes-ref-1\"><a href=\"#footnotes-def-1\">1</a></sup> <a href=\"https://host.example\">docs</a></p>\n<h1 id=\"Chapter-1\">Chapter 1</h1>\n<p>Intro<sup class=\"footnotes-ref\" id=\"footnotes-ref-2\"><a href=\"#footnotes-def-2\">2</a></sup> see <a href=\"https://child.example\" title=\"Child\">docs</a>.</p>\n<h2 id=\"Setup\">Setup</h2>\n<p>Run it<sup class=\"footnotes-ref\" id=\"footnotes-ref-2:2\"><a href=\"#footnotes-def-2\">2</a></sup>.</p>\n<h2 id=\"Usage\">Usage</h2>\n<p>Use it.</p>\n<div class=\"footnotes-defs-div\"><hr class=\"footnotes-defs-hr\" />\n<ol class=\"footnotes-defs-ol\"><li id=\"footnotes-def-1\"><p>Host note. <a href=\"#footnotes-ref-1\" class=\"vditor-footnotes__goto-ref\">↩</a></p>\n</li>\n<li id=\"footnotes-def-2\"><p>Child note. <a href=\"#footnotes-ref-2\" class=\"vditor-footnotes__goto-ref\">↩</a> <a href=\"#footnotes-ref-2:2\" class=\"vditor-footnotes__goto-ref\">↩</a></p>\n</li>\n</ol></div>"},
+}
+
+func TestTransclusion(t *testing.T) {
+	luteEngine := newTransclusionLute()
+
+	for _, test := range transclusionTests {
+		html := luteEngine.MarkdownStr(test.name, test.from)
+		if test.to != html {
+			t.Fatalf("test case [%s] failed\nexpected\n\t%q\ngot\n\t%q\noriginal markdown text\n\t%q", test.name, test.to, html, test.from)
+		}
+	}
+}
+
+var formatTransclusionTests = []parseTest{
+
+	{"1", "![[chapter1#Setup]]\n", "![[chapter1#Setup]]\n"},
+	{"0", "Host[^1] [docs]\n\n!include   chapter1.md\n\n[^1]: Host note.\n\n[docs]: https://host.example\n", "Host[^1] [docs]\n\n!include   chapter1.md\n\n[^1]: Host note.\n\n[docs]: https://host.example\n"},
+}
+
+func TestFormatTransclusion(t *testing.T) {
+	luteEngine := newTransclusionLute()
+
+	for _, test := range formatTransclusionTests {
+		formatted := luteEngine.FormatStr(test.name, test.from)
+		if test.to != formatted {
+			t.Fatalf("test case [%s] failed\nexpected\n\t%q\ngot\n\t%q\noriginal markdown text\n\t%q", test.name, test.to, formatted, test.from)
+		}
+	}
+}
+
+func TestTransclusionTaskListItems(t *testing.T) {
+	luteEngine := newTransclusionLute()
+	luteEngine.SetTaskListItemIndex(true)
+
+	markdown := "!include tasks.md\n\n- [ ] host\n"
+	html := luteEngine.MarkdownStr("", markdown)
+	expected := "<ul>\n<li class=\"vditor-task\"><input disabled=\"\" type=\"checkbox\" /> child</li>\n</ul>\n<ul>\n<li class=\"vditor-task\"><input disabled=\"\" type=\"checkbox\" data-task-index=\"0\" /> host</li>\n</ul>\n"
+	if expected != html {
+		t.Fatalf("render included task list items failed\nexpected\n\t%q\ngot\n\t%q", expected, html)
+	}
+
+	items := luteEngine.TaskListItems([]byte(markdown))
+	if 1 != len(items) || 0 != items[0].Index || "host" != items[0].Text {
+		t.Fatalf("included task list items should be excluded: %+v", items)
+	}
+	toggled, err := luteEngine.ToggleTaskListItem([]byte(markdown), 0)
+	if nil != err || "!include tasks.md\n\n- [x] host\n" != string(toggled) {
+		t.Fatalf("toggle host task list item failed: %q, %v", toggled, err)
+	}
+}
+
+func TestTransclusionSourceLine(t *testing.T) {
+	luteEngine := newTransclusionLute()
+	luteEngine.SetRenderSourceLine(true)
+
+	markdown := "a\n\nb\n\n!include fig.md\n"
+	html := luteEngine.MarkdownStr("", markdown)
+	expected := "<p data-source-line=\"1\" data-source-end=\"1\">a</p>\n<p data-source-line=\"3\" data-source-end=\"3\">b</p>\n<p data-source-line=\"5\" data-source-end=\"5\">C</p>\n<p data-source-line=\"5\" data-source-end=\"5\">D</p>\n<p data-source-line=\"5\" data-source-end=\"5\">see @fig:none</p>\n"
+	if expected != html {
+		t.Fatalf("included nodes should be located at the include directive\nexpected\n\t%q\ngot\n\t%q", expected, html)
+	}
+}
+
+func TestTransclusionDiagnostics(t *testing.T) {
+	luteEngine := newTransclusionLute()
+	luteEngine.SetCrossRef(true)
+
+	tests := []struct {
+		maxIncludeDepth int
+		markdown        string
+		diagnostics     []string
+	}{
+		{8, "!include fig.md\n", []string{"1:1: undefined label [fig:none]"}},
+		{8, "!include a.md\n", []string{"b.md:3:1: include cycle [a.md -> b.md -> a.md]"}},
+		{1, "!include dir/x.md\n", []string{"dir/x.md:3:1: maximum include depth [1] exceeded when including [dir/y.md]"}},
+		{8, "!include missing.md\n\n![[chapter1#Nope]]\n", []string{"1:1: load [missing.md] failed: open missing.md: file does not exist", "3:1: heading [Nope] not found in [chapter1.md]"}},
+	}
+	for _, test := range tests {
+		luteEngine.SetMaxIncludeDepth(test.maxIncludeDepth)
+		diagnostics := luteEngine.Diagnostics([]byte(test.markdown))
+		if len(test.diagnostics) != len(diagnostics) {
+			t.Fatalf("expected [%d] diagnostics, got [%d]: %v", len(test.diagnostics), len(diagnostics), diagnostics)
+		}
+		for i, diagnostic := range diagnostics {
+			if test.diagnostics[i] != diagnostic.String() {
+				t.Fatalf("diagnostic [%d] failed\nexpected\n\t%q\ngot\n\t%q", i, test.diagnostics[i], diagnostic.String())
+			}
+		}
+	}
+}
@@ -0,0 +1,137 @@
+// Lute - 一款对中文语境优化的 Markdown 引擎，支持 Go 和 JavaScript
+// Copyright (c) 2019-present, b3log.org
+//
+// Lute is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//         http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND, EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT, MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+package test
+
+import (
+	"strings"
+	"testing"
+
+	"lute"
+	"lute/parse"
+)
+
+var wikiLinkTests = []parseTest{
+
+	{"5", "[[a/b c]]\n", "<p><a href=\"a/b%20c\" class=\"wiki-link\">a/b c</a></p>\n"},
+	{"4", "[[中文页面]]\n", "<p><a href=\"%E4%B8%AD%E6%96%87%E9%A1%B5%E9%9D%A2\" class=\"wiki-link\">中文页面</a></p>\n"},
+	{"3", "[[]] [[x]y]] [not wiki]\n", "<p>[[]] [[x]y]] [not wiki]</p>\n"},
+	{"2", "[[Other Page#Sec]] [[#local]]\n", "<p><a href=\"Other%20Page#Sec\" class=\"wiki-link\">Other Page#Sec</a> <a href=\"#local\" class=\"wiki-link\">#local</a></p>\n"},
+	{"1", "[[Page|the *label*]]\n", "<p><a href=\"Page\" class=\"wiki-link\">the *label*</a></p>\n"},
+	{"0", "see [[Page]].\n", "<p>see <a href=\"Page\" class=\"wiki-link\">Page</a>.</p>\n"},
+}
+
+func TestWikiLink(t *testing.T) {
+	luteEngine := lute.New()
+	luteEngine.SetWikiLink(true)
+
+	for _, test := range wikiLinkTests {
+		html := luteEngine.MarkdownStr(test.name, test.from)
+		if test.to != html {
+			t.Fatalf("test case [%s] failed\nexpected\n\t%q\ngot\n\t%q\noriginal markdown text\n\t%q", test.name, test.to, html, test.from)
+		}
+	}
+}
+
+var wikiLinkResolverTests = []parseTest{
+
+	{"1", "[[Page#Intro|intro]]\n", "<p><a href=\"/wiki/Page#intro\" class=\"wiki-link\">intro</a></p>\n"},
+	{"0", "[[Page]] [[Missing]]\n", "<p><a href=\"/wiki/Page\" class=\"wiki-link\">Page</a> <a href=\"/wiki/Missing\" class=\"wiki-link wiki-link--new\">Missing</a></p>\n"},
+}
+
+func TestWikiLinkResolver(t *testing.T) {
+	luteEngine := lute.New()
+	luteEngine.SetWikiLink(true)
+	luteEngine.SetWikiLinkResolver(parse.WikiLinkResolverFunc(func(page, anchor string) (url string, exists bool) {
+		url = "/wiki/" + page
+		if "" != anchor {
+			url += "#" + strings.ToLower(anchor)
+		}
+		return url, "Page" == page
+	}))
+
+	for _, test := range wikiLinkResolverTests {
+		html := luteEngine.MarkdownStr(test.name, test.from)
+		if test.to != html {
+			t.Fatalf("test case [%s] failed\nexpected\n\t%q\ngot\n\t%q\noriginal markdown text\n\t%q", test.name, test.to, html, test.from)
+		}
+	}
+}
+
+var formatWikiLinkTests = []parseTest{
+
+	{"0", "see [[Page]], [[Page|the label]] and [[Other Page#Sec 1]]\n", "see [[Page]], [[Page|the label]] and [[Other Page#Sec 1]]\n"},
+}
+
+func TestFormatWikiLink(t *testing.T) {
+	luteEngine := lute.New()
+	luteEngine.SetWikiLink(true)
+
+	for _, test := range formatWikiLinkTests {
+		formatted := luteEngine.FormatStr(test.name, test.from)
+		if test.to != formatted {
+			t.Fatalf("test case [%s] failed\nexpected\n\t%q\ngot\n\t%q\noriginal markdown text\n\t%q", test.name, test.to, formatted, test.from)
+		}
+	}
+}
+
+var vditorWikiLinkTests = []parseTest{
+
+	{"0", "see [[Other Page|label]]\n", "<p data-block=\"0\">see <span class=\"vditor-wysiwyg__block\" data-type=\"source-inline\"><code data-type=\"source-inline\">\u200b[[Other Page|label]]</code><span class=\"vditor-wysiwyg__preview\" data-render=\"2\"><a href=\"Other%20Page\" class=\"wiki-link\">label</a></span></span>\u200b\n</p>"},
+}
+
+func TestVditorWikiLink(t *testing.T) {
+	luteEngine := lute.New()
+	luteEngine.SetWikiLink(true)
+
+	for _, test := range vditorWikiLinkTests {
+		html := luteEngine.Md2VditorDOM(test.from)
+		if test.to != html {
+			t.Fatalf("test case [%s] failed\nexpected\n\t%q\ngot\n\t%q\noriginal markdown text\n\t%q", test.name, test.to, html, test.from)
+		}
+		if md := luteEngine.VditorDOM2Md(html); test.from != md {
+			t.Fatalf("test case [%s] failed\nexpected\n\t%q\ngot\n\t%q\noriginal markdown text\n\t%q", test.name, test.from, md, test.from)
+		}
+	}
+}
+
+var vditorIRWikiLinkTests = []parseTest{
+
+	{"0", "see [[Page|label]]\n", "<p data-block=\"0\">see <span data-type=\"wiki-link\" class=\"vditor-ir__node\" data-href=\"Page\"><span class=\"vditor-ir__marker vditor-ir__marker--bracket\">[[</span><span class=\"vditor-ir__link\">Page|label</span><span class=\"vditor-ir__marker vditor-ir__marker--bracket\">]]</span></span>\n</p>"},
+}
+
+func TestVditorIRWikiLink(t *testing.T) {
+	luteEngine := lute.New()
+	luteEngine.SetWikiLink(true)
+
+	for _, test := range vditorIRWikiLinkTests {
+		html := luteEngine.Md2VditorIRDOM(test.from)
+		if test.to != html {
+			t.Fatalf("test case [%s] failed\nexpected\n\t%q\ngot\n\t%q\noriginal markdown text\n\t%q", test.name, test.to, html, test.from)
+		}
+		if md := luteEngine.VditorIRDOM2Md(html); test.from != md {
+			t.Fatalf("test case [%s] failed\nexpected\n\t%q\ngot\n\t%q\noriginal markdown text\n\t%q", test.name, test.from, md, test.from)
+		}
+	}
+}
+
+func TestTreeWikiLinks(t *testing.T) {
+	luteEngine := lute.New()
+	luteEngine.SetWikiLink(true)
+
+	tree := parse.Parse("", []byte("[[A]] x [[B#c|see]]\n\n- [[C]]\n"), luteEngine.Options)
+	var links []string
+	for _, link := range tree.WikiLinks() {
+		links = append(links, link.WikiLinkPage+"#"+link.WikiLinkAnchor)
+	}
+	if expected, got := "A#,B#c,C#", strings.Join(links, ","); expected != got {
+		t.Fatalf("wiki links failed\nexpected\n\t%q\ngot\n\t%q", expected, got)
+	}
+}
@@ -167,7 +167,7 @@ func (lute *Lute) vditorDOM2Md(htmlStr string) (markdown string) {
 	ast.Walk(tree.Root, func(n *ast.Node, entering bool) ast.WalkStatus {
 		if entering {
 			switch n.Type {
-			case ast.NodeInlineHTML, ast.NodeCodeSpan, ast.NodeInlineMath, ast.NodeHTMLBlock, ast.NodeCodeBlockCode, ast.NodeMathBlockContent:
+			case ast.NodeInlineHTML, ast.NodeCodeSpan, ast.NodeInlineMath, ast.NodeHTMLBlock, ast.NodeCodeBlockCode, ast.NodeMathBlockContent, ast.NodeFrontMatterContent:
 				n.Tokens = util.UnescapeHTML(n.Tokens)
 				if nil != n.Next && ast.NodeCodeSpan == n.Next.Type && n.CodeMarkerLen == n.Next.CodeMarkerLen {
 					// 合并代码节点 https://github.com/Vanessa219/vditor/issues/167
@@ -214,7 +214,7 @@ func (lute *Lute) adjustVditorDOM(nodes []*html.Node) {
 	}
 
 	for _, emptyTextNode := range emptyTextNodes {
-		if parent := emptyTextNode.Parent; nil != parent && (atom.Table == parent.DataAtom || atom.Thead == parent.DataAtom || atom.Tbody == parent.DataAtom || atom.Tr == parent.DataAtom) {
+		if parent := emptyTextNode.Parent; nil != parent && (atom.Table == parent.DataAtom || atom.Thead == parent.DataAtom || atom.Tbody == parent.DataAtom || atom.Tr == parent.DataAtom || atom.Dl == parent.DataAtom) {
 			emptyTextNode.Unlink()
 			continue
 		}
@@ -311,7 +311,7 @@ func (lute *Lute) genASTByVditorDOM(n *html.Node, tree *parse.Tree) {
 	dataType := lute.domAttrValue(n, "data-type")
 
 	if atom.Div == n.DataAtom {
-		if "code-block" == dataType || "html-block" == dataType || "math-block" == dataType {
+		if "code-block" == dataType || "html-block" == dataType || "math-block" == dataType || "front-matter" == dataType {
 			for c := n.FirstChild; c != nil; c = c.NextSibling {
 				lute.genASTByVditorDOM(c, tree)
 			}
@@ -353,7 +353,16 @@ func (lute *Lute) genASTByVditorDOM(n *html.Node, tree *parse.Tree) {
 				}
 			}
 		} else if "toc-block" == dataType {
-			node := &ast.Node{Type: ast.NodeText, Tokens: []byte("[toc]\n\n")}
+			marker := lute.domAttrValue(n, "data-marker")
+			if "" == marker {
+				marker = "[toc]"
+			}
+			node := &ast.Node{Type: ast.NodeText, Tokens: []byte(marker + "\n\n")}
+			tree.Context.Tip.AppendChild(node)
+		} else if "source-block" == dataType {
+			// 扩展语法块以源码形式输出，直接使用源码
+			text := strings.TrimSpace(lute.domText(n.FirstChild))
+			node := &ast.Node{Type: ast.NodeInlineHTML, Tokens: []byte(text + "\n\n")}
 			tree.Context.Tip.AppendChild(node)
 		}
 		return
@@ -394,7 +403,14 @@ func (lute *Lute) genASTByVditorDOM(n *html.Node, tree *parse.Tree) {
 		}
 		tree.Context.Tip.AppendChild(node)
 	case atom.P, atom.Div:
+		if "github-alert-marker" == dataType && ast.NodeBlockquote == tree.Context.Tip.Type {
+			node.Type = ast.NodeGitHubAlertMarker
+			node.Tokens = bytes.TrimSpace([]byte(lute.domText(n)))
+			tree.Context.Tip.AppendChild(node)
+			return
+		}
 		node.Type = ast.NodeParagraph
+		node.Attributes, _ = parse.ParseAttributeList([]byte(lute.domAttrValue(n, "data-attrs")))
 		tree.Context.Tip.AppendChild(node)
 		tree.Context.Tip = node
 		defer tree.Context.ParentTip()
@@ -409,6 +425,7 @@ func (lute *Lute) genASTByVditorDOM(n *html.Node, tree *parse.Tree) {
 		if "" != id {
 			node.HeadingID = []byte(id)
 		}
+		node.Attributes, _ = parse.ParseAttributeList([]byte(lute.domAttrValue(n, "data-attrs")))
 		node.HeadingSetext = "=" == marker || "-" == marker
 		if !node.HeadingSetext {
 			headingC8hMarker := &ast.Node{Type: ast.NodeHeadingC8hMarker}
@@ -534,6 +551,16 @@ func (lute *Lute) genASTByVditorDOM(n *html.Node, tree *parse.Tree) {
 				node.AppendChild(&ast.Node{Type: ast.NodeMathBlockContent, Tokens: codeTokens})
 				node.AppendChild(&ast.Node{Type: ast.NodeMathBlockCloseMarker})
 				tree.Context.Tip.AppendChild(node)
+			case "front-matter":
+				if "```" == marker {
+					marker = string(parse.YamlFrontMatterMarker)
+				}
+				node.Type = ast.NodeFrontMatter
+				node.FrontMatterMarker = []byte(marker)
+				node.AppendChild(&ast.Node{Type: ast.NodeFrontMatterOpenMarker, Tokens: []byte(marker)})
+				node.AppendChild(&ast.Node{Type: ast.NodeFrontMatterContent, Tokens: bytes.TrimRight(codeTokens, "\n")})
+				node.AppendChild(&ast.Node{Type: ast.NodeFrontMatterCloseMarker, Tokens: []byte(marker)})
+				tree.Context.Tip.AppendChild(node)
 			case "html-block":
 				node.Type = ast.NodeHTMLBlock
 				node.Tokens = codeTokens
@@ -840,6 +867,9 @@ func (lute *Lute) genASTByVditorDOM(n *html.Node, tree *parse.Tree) {
 		if lute.hasAttr(n, "checked") {
 			node.TaskListItemChecked = true
 		}
+		if state := lute.domAttrValue(n, "data-task"); "" != state {
+			node.TaskListItemState = state[0]
+		}
 		tree.Context.Tip.AppendChild(node)
 		if nil != node.Parent.Parent && nil != node.Parent.Parent.ListData { // ul.li.input
 			node.Parent.Parent.ListData.Typ = 3
@@ -850,23 +880,36 @@ func (lute *Lute) genASTByVditorDOM(n *html.Node, tree *parse.Tree) {
 	case atom.Table:
 		node.Type = ast.NodeTable
 		var tableAligns []int
-		for th := n.FirstChild.FirstChild.FirstChild; nil != th; th = th.NextSibling {
-			align := lute.domAttrValue(th, "align")
-			switch align {
-			case "left":
-				tableAligns = append(tableAligns, 1)
-			case "center":
-				tableAligns = append(tableAligns, 2)
-			case "right":
-				tableAligns = append(tableAligns, 3)
-			default:
-				tableAligns = append(tableAligns, 0)
+		if thead := lute.domChild(n, atom.Thead); nil != thead && nil != thead.FirstChild {
+			for th := thead.FirstChild.FirstChild; nil != th; th = th.NextSibling {
+				align := lute.domAttrValue(th, "align")
+				switch align {
+				case "left":
+					tableAligns = append(tableAligns, 1)
+				case "center":
+					tableAligns = append(tableAligns, 2)
+				case "right":
+					tableAligns = append(tableAligns, 3)
+				default:
+					tableAligns = append(tableAligns, 0)
+				}
 			}
 		}
 		node.TableAligns = tableAligns
 		tree.Context.Tip.AppendChild(node)
 		tree.Context.Tip = node
 		defer tree.Context.ParentTip()
+	case atom.Caption:
+		// 表标题以源码形式输出，直接使用源码
+		node.Type = ast.NodeTableCaption
+		node.TableCaptionBefore = "1" == lute.domAttrValue(n, "data-before")
+		text := strings.TrimSpace(strings.ReplaceAll(lute.domText(n), parse.Zwsp, ""))
+		if "" == text {
+			return
+		}
+		node.AppendChild(&ast.Node{Type: ast.NodeInlineHTML, Tokens: []byte(text)})
+		tree.Context.Tip.AppendChild(node)
+		return
 	case atom.Thead:
 		node.Type = ast.NodeTableHead
 		tree.Context.Tip.AppendChild(node)
@@ -896,7 +939,7 @@ func (lute *Lute) genASTByVditorDOM(n *html.Node, tree *parse.Tree) {
 		tree.Context.Tip.AppendChild(node)
 		tree.Context.Tip = node
 		defer tree.Context.ParentTip()
-	case atom.Sup:
+	case atom.Mark, atom.Sup, atom.Sub:
 		if nil == n.FirstChild {
 			break
 		}
@@ -904,8 +947,29 @@ func (lute *Lute) genASTByVditorDOM(n *html.Node, tree *parse.Tree) {
 			node.Type = ast.NodeText
 			node.Tokens = []byte("[" + lute.domAttrValue(n, "data-footnotes-label") + "]")
 			tree.Context.Tip.AppendChild(node)
+			return
 		}
-		return
+		if "" == lute.domAttrValue(n, "data-marker") { // 不是编辑器生成的高亮、上标、下标时仅保留文本
+			break
+		}
+		if lute.isEmptyText(n) {
+			return
+		}
+
+		switch n.DataAtom {
+		case atom.Mark:
+			node.Type = ast.NodeMark
+			node.AppendChild(&ast.Node{Type: ast.NodeMarkOpenMarker, Tokens: []byte("==")})
+		case atom.Sup:
+			node.Type = ast.NodeSup
+			node.AppendChild(&ast.Node{Type: ast.NodeSupOpenMarker, Tokens: []byte("^")})
+		case atom.Sub:
+			node.Type = ast.NodeSub
+			node.AppendChild(&ast.Node{Type: ast.NodeSubOpenMarker, Tokens: []byte("~")})
+		}
+		tree.Context.Tip.AppendChild(node)
+		tree.Context.Tip = node
+		defer tree.Context.ParentTip()
 	case atom.Span:
 		if nil == n.FirstChild {
 			break
@@ -946,6 +1010,10 @@ func (lute *Lute) genASTByVditorDOM(n *html.Node, tree *parse.Tree) {
 			node.Type = ast.NodeText
 			node.Tokens = codeTokens
 			tree.Context.Tip.AppendChild(node)
+		} else if "source-inline" == dataType {
+			node.Type = ast.NodeInlineHTML
+			node.Tokens = bytes.ReplaceAll(codeTokens, []byte(parse.Zwsp), nil)
+			tree.Context.Tip.AppendChild(node)
 		}
 		return
 	case atom.Font:
@@ -1015,6 +1083,15 @@ func (lute *Lute) genASTByVditorDOM(n *html.Node, tree *parse.Tree) {
 		} else {
 			node.AppendChild(&ast.Node{Type: ast.NodeStrikethrough2CloseMarker, Tokens: []byte(marker)})
 		}
+	case atom.Mark, atom.Sup, atom.Sub:
+		switch node.Type {
+		case ast.NodeMark:
+			node.AppendChild(&ast.Node{Type: ast.NodeMarkCloseMarker, Tokens: []byte("==")})
+		case ast.NodeSup:
+			node.AppendChild(&ast.Node{Type: ast.NodeSupCloseMarker, Tokens: []byte("^")})
+		case ast.NodeSub:
+			node.AppendChild(&ast.Node{Type: ast.NodeSubCloseMarker, Tokens: []byte("~")})
+		}
 	case atom.Details:
 		tree.Context.Tip.AppendChild(&ast.Node{Type: ast.NodeHTMLBlock, Tokens: []byte("</details>")})
 	}
@@ -1042,6 +1119,16 @@ func (lute *Lute) domAttrValue(n *html.Node, attrName string) string {
 	return ""
 }
 
+// domChild 返回 n 下第一个 DataAtom 为 dataAtom 的子节点，没有的话返回 nil。
+func (lute *Lute) domChild(n *html.Node, dataAtom atom.Atom) *html.Node {
+	for c := n.FirstChild; nil != c; c = c.NextSibling {
+		if dataAtom == c.DataAtom {
+			return c
+		}
+	}
+	return nil
+}
+
 func (lute *Lute) domCode(n *html.Node) string {
 	buf := &bytes.Buffer{}
 	lute.domCode0(n, buf)
@@ -140,7 +140,7 @@ func (lute *Lute) vditorIRDOM2Md(htmlStr string) (markdown string) {
 	ast.Walk(tree.Root, func(n *ast.Node, entering bool) ast.WalkStatus {
 		if entering {
 			switch n.Type {
-			case ast.NodeInlineHTML, ast.NodeCodeSpan, ast.NodeInlineMath, ast.NodeHTMLBlock, ast.NodeCodeBlockCode, ast.NodeMathBlockContent:
+			case ast.NodeInlineHTML, ast.NodeCodeSpan, ast.NodeInlineMath, ast.NodeHTMLBlock, ast.NodeCodeBlockCode, ast.NodeMathBlockContent, ast.NodeFrontMatterContent:
 				n.Tokens = util.UnescapeHTML(n.Tokens)
 				if nil != n.Next && ast.NodeCodeSpan == n.Next.Type && n.CodeMarkerLen == n.Next.CodeMarkerLen {
 					// 合并代码节点 https://github.com/Vanessa219/vditor/issues/167
@@ -177,7 +177,7 @@ func (lute *Lute) genASTByVditorIRDOM(n *html.Node, tree *parse.Tree) {
 	dataType := lute.domAttrValue(n, "data-type")
 
 	if atom.Div == n.DataAtom {
-		if "code-block" == dataType || "html-block" == dataType || "math-block" == dataType {
+		if "code-block" == dataType || "html-block" == dataType || "math-block" == dataType || "front-matter" == dataType {
 			if ("code-block" == dataType || "math-block" == dataType) &&
 				!strings.Contains(lute.domAttrValue(n.FirstChild, "data-type"), "-block-open-marker") {
 				// 处理在结尾 ``` 或者 $$ 后换行的情况
@@ -220,7 +220,16 @@ func (lute *Lute) genASTByVditorIRDOM(n *html.Node, tree *parse.Tree) {
 				}
 			}
 		} else if "toc-block" == dataType {
-			node := &ast.Node{Type: ast.NodeText, Tokens: []byte("[toc]\n\n")}
+			marker := lute.domAttrValue(n, "data-marker")
+			if "" == marker {
+				marker = "[toc]"
+			}
+			node := &ast.Node{Type: ast.NodeText, Tokens: []byte(marker + "\n\n")}
+			tree.Context.Tip.AppendChild(node)
+		} else if "source-block" == dataType {
+			// 扩展语法块以源码形式输出，直接使用源码
+			text := strings.TrimSpace(lute.domText(n.FirstChild))
+			node := &ast.Node{Type: ast.NodeInlineHTML, Tokens: []byte(text + "\n\n")}
 			tree.Context.Tip.AppendChild(node)
 		} else {
 			text := lute.domText(n)
@@ -266,7 +275,14 @@ func (lute *Lute) genASTByVditorIRDOM(n *html.Node, tree *parse.Tree) {
 		}
 		tree.Context.Tip.AppendChild(node)
 	case atom.P, atom.Div:
+		if "github-alert-marker" == dataType && ast.NodeBlockquote == tree.Context.Tip.Type {
+			node.Type = ast.NodeGitHubAlertMarker
+			node.Tokens = bytes.TrimSpace([]byte(lute.domText(n)))
+			tree.Context.Tip.AppendChild(node)
+			return
+		}
 		node.Type = ast.NodeParagraph
+		node.Attributes, _ = parse.ParseAttributeList([]byte(lute.domAttrValue(n, "data-attrs")))
 		tree.Context.Tip.AppendChild(node)
 		tree.Context.Tip = node
 		defer tree.Context.ParentTip()
@@ -280,6 +296,7 @@ func (lute *Lute) genASTByVditorIRDOM(n *html.Node, tree *parse.Tree) {
 		if "" != id {
 			node.HeadingID = []byte(id)
 		}
+		node.Attributes, _ = parse.ParseAttributeList([]byte(lute.domAttrValue(n, "data-attrs")))
 		node.HeadingSetext = "=" == marker || "-" == marker
 		if !node.HeadingSetext {
 			marker := lute.domText(n.FirstChild)
@@ -406,6 +423,10 @@ func (lute *Lute) genASTByVditorIRDOM(n *html.Node, tree *parse.Tree) {
 				node.Type = ast.NodeMathBlock
 				node.AppendChild(&ast.Node{Type: ast.NodeMathBlockContent, Tokens: codeTokens})
 				tree.Context.Tip.AppendChild(node)
+			case "front-matter":
+				node.Type = ast.NodeFrontMatterContent
+				node.Tokens = bytes.TrimRight(codeTokens, "\n")
+				tree.Context.Tip.AppendChild(node)
 			case "html-block":
 				node.Type = ast.NodeHTMLBlock
 				node.Tokens = codeTokens
@@ -567,6 +588,9 @@ func (lute *Lute) genASTByVditorIRDOM(n *html.Node, tree *parse.Tree) {
 		if lute.hasAttr(n, "checked") {
 			node.TaskListItemChecked = true
 		}
+		if state := lute.domAttrValue(n, "data-task"); "" != state {
+			node.TaskListItemState = state[0]
+		}
 		tree.Context.Tip.AppendChild(node)
 		if nil != node.Parent.Parent && nil != node.Parent.Parent.ListData { // ul.li.input
 			node.Parent.Parent.ListData.Typ = 3
@@ -577,17 +601,19 @@ func (lute *Lute) genASTByVditorIRDOM(n *html.Node, tree *parse.Tree) {
 	case atom.Table:
 		node.Type = ast.NodeTable
 		var tableAligns []int
-		for th := n.FirstChild.FirstChild.FirstChild; nil != th; th = th.NextSibling {
-			align := lute.domAttrValue(th, "align")
-			switch align {
-			case "left":
-				tableAligns = append(tableAligns, 1)
-			case "center":
-				tableAligns = append(tableAligns, 2)
-			case "right":
-				tableAligns = append(tableAligns, 3)
-			default:
-				tableAligns = append(tableAligns, 0)
+		if thead := lute.domChild(n, atom.Thead); nil != thead && nil != thead.FirstChild {
+			for th := thead.FirstChild.FirstChild; nil != th; th = th.NextSibling {
+				align := lute.domAttrValue(th, "align")
+				switch align {
+				case "left":
+					tableAligns = append(tableAligns, 1)
+				case "center":
+					tableAligns = append(tableAligns, 2)
+				case "right":
+					tableAligns = append(tableAligns, 3)
+				default:
+					tableAligns = append(tableAligns, 0)
+				}
 			}
 		}
 		node.TableAligns = tableAligns
@@ -595,6 +621,17 @@ func (lute *Lute) genASTByVditorIRDOM(n *html.Node, tree *parse.Tree) {
 		tree.Context.Tip.AppendChild(node)
 		tree.Context.Tip = node
 		defer tree.Context.ParentTip()
+	case atom.Caption:
+		// 表标题以源码形式输出，直接使用源码
+		node.Type = ast.NodeTableCaption
+		node.TableCaptionBefore = "1" == lute.domAttrValue(n, "data-before")
+		text := strings.TrimSpace(strings.ReplaceAll(lute.domText(n), parse.Zwsp, ""))
+		if "" == text {
+			return
+		}
+		node.AppendChild(&ast.Node{Type: ast.NodeInlineHTML, Tokens: []byte(text)})
+		tree.Context.Tip.AppendChild(node)
+		return
 	case atom.Thead:
 		node.Type = ast.NodeTableHead
 		tree.Context.Tip.AppendChild(node)
@@ -636,11 +673,27 @@ func (lute *Lute) genASTByVditorIRDOM(n *html.Node, tree *parse.Tree) {
 		return
 	case atom.Span:
 		switch dataType {
-		case "inline-node", "em", "strong", "s", "a", "link-ref", "img", "code":
+		case "inline-node", "em", "strong", "s", "a", "link-ref", "img", "code", "wiki-link":
 			node.Type = ast.NodeText
+			if nil != n.FirstChild && "source-inline" == lute.domAttrValue(n.FirstChild, "data-type") {
+				// 扩展语法以源码形式输出，直接使用源码
+				node.Type = ast.NodeInlineHTML
+			}
 			node.Tokens = []byte(lute.domText(n))
 			tree.Context.Tip.AppendChild(node)
 			return
+		case "front-matter-close-marker":
+			tree.Context.Tip.AppendChild(&ast.Node{Type: ast.NodeFrontMatterCloseMarker, Tokens: tree.Context.Tip.FrontMatterMarker})
+			defer tree.Context.ParentTip()
+			return
+		case "front-matter-open-marker":
+			marker := []byte(lute.domText(n))
+			node.Type = ast.NodeFrontMatter
+			node.FrontMatterMarker = marker
+			node.AppendChild(&ast.Node{Type: ast.NodeFrontMatterOpenMarker, Tokens: marker})
+			tree.Context.Tip.AppendChild(node)
+			tree.Context.Tip = node
+			return
 		case "math-block-close-marker":
 			tree.Context.Tip.AppendChild(&ast.Node{Type: ast.NodeMathBlockCloseMarker, Tokens: []byte("$$")})
 			defer tree.Context.ParentTip()